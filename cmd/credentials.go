@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"go.infratographer.com/x/viperx"
+
+	"go.hollow.sh/serverservice/internal/dbtools"
+)
+
+// credentialsCmd represents the credentials command
+var credentialsCmd = &cobra.Command{
+	Use:   "credentials",
+	Short: "manage the server credentials stored in the database",
+}
+
+// rekeyCmd represents the credentials rekey command
+var rekeyCmd = &cobra.Command{
+	Use:   "rekey",
+	Short: "re-encrypt all stored credentials with the current encryption key",
+	Long: `Re-encrypt all stored credentials with the key configured by --db-encryption-driver.
+Credentials encrypted with another key are decrypted with the keys given by
+--db-decryption-drivers. Credentials already encrypted with the current key are skipped,
+so an interrupted run can safely be restarted.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		rekey(cmd.Context())
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(credentialsCmd)
+	credentialsCmd.AddCommand(rekeyCmd)
+
+	rekeyCmd.Flags().Int("batch-size", dbtools.DefaultRekeyBatchSize, "number of credentials re-encrypted per transaction")
+	viperx.MustBindFlag(viper.GetViper(), "rekey.batch_size", rekeyCmd.Flags().Lookup("batch-size"))
+	rekeyCmd.Flags().Bool("dry-run", false, "report the credentials that would be re-encrypted without changing them")
+	viperx.MustBindFlag(viper.GetViper(), "rekey.dry_run", rekeyCmd.Flags().Lookup("dry-run"))
+}
+
+func rekey(ctx context.Context) {
+	db := initDB()
+	defer db.Close()
+
+	keyring := initKeyring(ctx)
+	defer keyring.Close()
+
+	dryRun := viper.GetBool("rekey.dry_run")
+
+	logger.Infow("re-encrypting credentials",
+		"key_id", keyring.PrimaryKeyID(),
+		"dry_run", dryRun,
+	)
+
+	stats, err := dbtools.Rekey(ctx, db, keyring, dbtools.RekeyOptions{
+		BatchSize: viper.GetInt("rekey.batch_size"),
+		DryRun:    dryRun,
+		Progress: func(table string, stats dbtools.RekeyStats) {
+			logger.Infow("progress",
+				"table", table,
+				"scanned", stats.Scanned,
+				"rekeyed", stats.Rekeyed,
+				"skipped", stats.Skipped,
+			)
+		},
+	})
+	if err != nil {
+		logger.Fatalw("failed re-encrypting credentials", "error", err,
+			"scanned", stats.Scanned,
+			"rekeyed", stats.Rekeyed,
+			"skipped", stats.Skipped,
+		)
+	}
+
+	logger.Infow("finished re-encrypting credentials",
+		"dry_run", dryRun,
+		"scanned", stats.Scanned,
+		"rekeyed", stats.Rekeyed,
+		"skipped", stats.Skipped,
+	)
+}
@@ -0,0 +1,292 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"go.infratographer.com/x/viperx"
+
+	"go.hollow.sh/serverservice/internal/dbtools"
+	"go.hollow.sh/serverservice/internal/fwcatalog"
+	"go.hollow.sh/serverservice/internal/fwverify"
+	"go.hollow.sh/serverservice/internal/models"
+	serverservice "go.hollow.sh/serverservice/pkg/api/v1"
+)
+
+// firmwareCmd represents the firmware command
+var firmwareCmd = &cobra.Command{
+	Use:   "firmware",
+	Short: "manage the component firmware stored in the database",
+}
+
+// reindexVersionsCmd represents the firmware reindex-versions command
+var reindexVersionsCmd = &cobra.Command{
+	Use:   "reindex-versions",
+	Short: "compute the sort keys used to compare firmware versions",
+	Long: `Compute the sort key of every firmware version that doesn't have one or has a key
+from older comparison rules. The API keeps the keys up to date, so this only needs
+to be run after the migration that adds them or after upgrading the comparison rules.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		reindexVersions(cmd.Context())
+	},
+}
+
+// importFirmwareCmd represents the firmware import command
+var importFirmwareCmd = &cobra.Command{
+	Use:   "import <catalog>",
+	Short: "add or update the firmware listed in a vendor catalog",
+	Long: `Add or update the firmware listed in a vendor catalog. The catalog can be a Dell
+Catalog.xml or a YAML or JSON manifest with a list of firmware under "firmware".
+Firmware is matched by vendor, component, version and filename. Firmware already
+stored has its models replaced by the models in the catalog, and its checksum and
+URLs replaced when the catalog has them. Firmware that isn't stored yet needs a
+checksum and URLs. Dell catalogs don't have repository URLs, set --repository-base
+to the mirror of the catalog to derive them. All the firmware is imported in one
+transaction.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		importFirmware(cmd.Context(), args[0])
+	},
+}
+
+// importSetCmd represents the firmware import-set command
+var importSetCmd = &cobra.Command{
+	Use:   "import-set <document>...",
+	Short: "create or update firmware sets from YAML or JSON documents",
+	Long: `Create or update firmware sets from the YAML or JSON documents exported by
+GET /api/v1/server-component-firmware-sets/:uuid/export. A firmware set is matched
+by name and updated so its attributes and firmware are the ones in the document.
+Firmware sets that already match their document are left unchanged.`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		importSets(cmd.Context(), args)
+	},
+}
+
+// verifyFirmwareCmd represents the firmware verify command
+var verifyFirmwareCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "verify the checksum of the firmware artifacts in the repository",
+	Long: `Read the artifact of each firmware from the repository and compare its checksum
+with the checksum stored for the firmware. Checksums are prefixed with their
+algorithm, sha256:, sha512: or md5:, or the algorithm is taken from the length of
+the checksum. Artifacts are read from the path of the repository URL below
+--firmware-repository-root, or from the repository URL when no root is set.
+The verification status and time are recorded on each firmware. The command
+fails when any artifact doesn't match its checksum or can't be read.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		verifyFirmware(cmd.Context())
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(firmwareCmd)
+	firmwareCmd.AddCommand(reindexVersionsCmd)
+	firmwareCmd.AddCommand(importFirmwareCmd)
+	firmwareCmd.AddCommand(importSetCmd)
+	firmwareCmd.AddCommand(verifyFirmwareCmd)
+
+	importFirmwareCmd.Flags().String("format", "", "format of the catalog, dell-catalog or manifest (default from the file extension)")
+	viperx.MustBindFlag(viper.GetViper(), "firmware.import.format", importFirmwareCmd.Flags().Lookup("format"))
+	importFirmwareCmd.Flags().String("repository-base", "", "URL of the mirror the catalog files are copied to, sets the repository URL of firmware without one")
+	viperx.MustBindFlag(viper.GetViper(), "firmware.import.repository_base", importFirmwareCmd.Flags().Lookup("repository-base"))
+	importFirmwareCmd.Flags().Bool("dry-run", false, "report the firmware that would be added or changed without changing it")
+	viperx.MustBindFlag(viper.GetViper(), "firmware.import.dry_run", importFirmwareCmd.Flags().Lookup("dry-run"))
+
+	verifyFirmwareCmd.Flags().Duration("max-age", 0, "only verify firmware that hasn't been verified within the duration (default all firmware)")
+	viperx.MustBindFlag(viper.GetViper(), "firmware.verify.max_age", verifyFirmwareCmd.Flags().Lookup("max-age"))
+}
+
+func reindexVersions(ctx context.Context) {
+	db := initDB()
+	defer db.Close()
+
+	updated, err := dbtools.ReindexFirmwareVersions(ctx, db)
+	if err != nil {
+		logger.Fatalw("failed reindexing firmware versions", "error", err, "updated", updated)
+	}
+
+	logger.Infow("finished reindexing firmware versions", "updated", updated)
+}
+
+func importFirmware(ctx context.Context, catalog string) {
+	format := fwcatalog.Format(viper.GetString("firmware.import.format"))
+	if format == "" {
+		var err error
+
+		format, err = fwcatalog.FormatFromFilename(catalog)
+		if err != nil {
+			logger.Fatalw("failed reading firmware catalog", "error", err, "catalog", catalog)
+		}
+	}
+
+	file, err := os.Open(catalog)
+	if err != nil {
+		logger.Fatalw("failed reading firmware catalog", "error", err, "catalog", catalog)
+	}
+	defer file.Close()
+
+	firmware, err := fwcatalog.Parse(file, format, fwcatalog.Options{
+		RepositoryBase: viper.GetString("firmware.import.repository_base"),
+	})
+	if err != nil {
+		logger.Fatalw("failed reading firmware catalog", "error", err, "catalog", catalog, "format", format)
+	}
+
+	db := initDB()
+	defer db.Close()
+
+	dbtools.RegisterHooks()
+
+	dryRun := viper.GetBool("firmware.import.dry_run")
+
+	logger.Infow("importing firmware",
+		"catalog", catalog,
+		"format", format,
+		"firmware", len(firmware),
+		"dry_run", dryRun,
+	)
+
+	stats, err := dbtools.ImportFirmware(ctx, db, firmware, dbtools.FirmwareImportOptions{
+		DryRun: dryRun,
+		Report: func(f *models.ComponentFirmwareVersion, status string) {
+			log := logger.Infow
+			if status == dbtools.FirmwareSkipped {
+				log = logger.Debugw
+			}
+
+			log("firmware "+status,
+				"id", f.ID,
+				"vendor", f.Vendor,
+				"component", f.Component,
+				"version", f.Version,
+				"filename", f.Filename,
+				"model", f.Model,
+			)
+		},
+	})
+	if err != nil {
+		logger.Fatalw("failed importing firmware", "error", err)
+	}
+
+	logger.Infow("finished importing firmware",
+		"dry_run", dryRun,
+		"added", stats.Added,
+		"changed", stats.Changed,
+		"skipped", stats.Skipped,
+	)
+}
+
+func importSets(ctx context.Context, documents []string) {
+	docs := make([]*serverservice.ComponentFirmwareSetDocument, 0, len(documents))
+
+	for _, document := range documents {
+		doc, err := readSetDocument(document)
+		if err != nil {
+			logger.Fatalw("failed reading firmware set document", "error", err, "document", document)
+		}
+
+		docs = append(docs, doc)
+	}
+
+	db := initDB()
+	defer db.Close()
+
+	for i, doc := range docs {
+		result, err := dbtools.ImportFirmwareSet(ctx, db, setDocument(doc), dbtools.FirmwareSetImportOptions{
+			Revisions: viper.GetBool("firmware.set_revisions"),
+		})
+		if err != nil {
+			logger.Fatalw("failed importing firmware set", "error", err, "document", documents[i], "name", doc.Name)
+		}
+
+		logger.Infow("firmware set "+result.Status,
+			"document", documents[i],
+			"name", result.Name,
+			"id", result.ID,
+		)
+	}
+}
+
+// setDocument returns the firmware set document in the form the import takes
+func setDocument(doc *serverservice.ComponentFirmwareSetDocument) *dbtools.FirmwareSetDocument {
+	dbDoc := &dbtools.FirmwareSetDocument{Name: doc.Name}
+
+	for _, a := range doc.Attributes {
+		dbDoc.Attributes = append(dbDoc.Attributes, dbtools.FirmwareSetDocumentAttribute{Namespace: a.Namespace, Data: a.Data})
+	}
+
+	for _, f := range doc.Firmware {
+		dbDoc.Firmware = append(dbDoc.Firmware, dbtools.FirmwareReference(f))
+	}
+
+	return dbDoc
+}
+
+func readSetDocument(document string) (*serverservice.ComponentFirmwareSetDocument, error) {
+	file, err := os.Open(document)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return serverservice.DecodeComponentFirmwareSetDocument(file)
+}
+
+func verifyFirmware(ctx context.Context) {
+	verifier := initFirmwareVerifier()
+
+	db := initDB()
+	defer db.Close()
+
+	opts := dbtools.FirmwareVerifyOptions{
+		Report: func(f *models.ComponentFirmwareVersion, status string, err error) {
+			log := logger.Debugw
+			if err != nil {
+				log = logger.Errorw
+			}
+
+			log("firmware "+status,
+				"id", f.ID,
+				"vendor", f.Vendor,
+				"component", f.Component,
+				"version", f.Version,
+				"repository_url", f.RepositoryURL,
+				"error", err,
+			)
+		},
+	}
+
+	if maxAge := viper.GetDuration("firmware.verify.max_age"); maxAge > 0 {
+		opts.VerifiedBefore = time.Now().Add(-maxAge)
+	}
+
+	stats, err := dbtools.VerifyFirmware(ctx, db, verifier, opts)
+	if err != nil {
+		logger.Fatalw("failed verifying firmware", "error", err)
+	}
+
+	if stats.Mismatched > 0 || stats.Failed > 0 {
+		logger.Fatalw("firmware verification found artifacts that don't match",
+			"verified", stats.Verified,
+			"mismatched", stats.Mismatched,
+			"failed", stats.Failed,
+		)
+	}
+
+	logger.Infow("finished verifying firmware", "verified", stats.Verified, "skipped", stats.Skipped)
+}
+
+func initFirmwareVerifier() *fwverify.Verifier {
+	root := viper.GetString("firmware.repository_root")
+
+	verifier, err := fwverify.New(root, nil)
+	if err != nil {
+		logger.Fatalw("invalid firmware repository root", "error", err, "root", root)
+	}
+
+	return verifier
+}
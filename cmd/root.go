@@ -11,6 +11,7 @@ import (
 	"go.infratographer.com/x/goosex"
 	"go.infratographer.com/x/loggingx"
 	"go.infratographer.com/x/versionx"
+	"go.infratographer.com/x/viperx"
 	"go.uber.org/zap"
 
 	dbm "go.hollow.sh/serverservice/db"
@@ -42,6 +43,10 @@ func init() {
 	// Logging flags
 	loggingx.MustViperFlags(viper.GetViper(), rootCmd.PersistentFlags())
 
+	// firmware sets are changed by serve and by firmware import-set
+	rootCmd.PersistentFlags().Bool("firmware-set-revisions", false, "record a revision of a firmware set each time it changes")
+	viperx.MustBindFlag(viper.GetViper(), "firmware.set_revisions", rootCmd.PersistentFlags().Lookup("firmware-set-revisions"))
+
 	// Register version command
 	versionx.RegisterCobraCommand(rootCmd, func() { versionx.PrintVersion(logger) })
 
@@ -0,0 +1,10 @@
+package cmd
+
+import (
+	// import gocdk secret drivers, drivers for cloud key management services are
+	// included with build tags, see the secrets_*.go files
+	_ "gocloud.dev/secrets/localsecrets"
+
+	// import the local versioned keyring driver
+	_ "go.hollow.sh/serverservice/internal/filekeyring"
+)
@@ -0,0 +1,8 @@
+//go:build awskms
+
+package cmd
+
+import (
+	// import the AWS KMS secrets driver for awskms:// encryption drivers
+	_ "gocloud.dev/secrets/awskms"
+)
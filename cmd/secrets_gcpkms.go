@@ -0,0 +1,8 @@
+//go:build gcpkms
+
+package cmd
+
+import (
+	// import the GCP KMS secrets driver for gcpkms:// encryption drivers
+	_ "gocloud.dev/secrets/gcpkms"
+)
@@ -0,0 +1,8 @@
+//go:build vault
+
+package cmd
+
+import (
+	// import the HashiCorp Vault transit secrets driver for hashivault:// encryption drivers
+	_ "gocloud.dev/secrets/hashivault"
+)
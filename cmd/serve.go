@@ -2,9 +2,16 @@ package cmd
 
 import (
 	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/nats-io/nats.go"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"go.hollow.sh/toolbox/events"
@@ -12,19 +19,27 @@ import (
 	"go.infratographer.com/x/crdbx"
 	"go.infratographer.com/x/otelx"
 	"go.infratographer.com/x/viperx"
-	"gocloud.dev/secrets"
-
-	// import gocdk secret drivers
-	_ "gocloud.dev/secrets/localsecrets"
 
+	dbm "go.hollow.sh/serverservice/db"
 	"go.hollow.sh/serverservice/internal/config"
 	"go.hollow.sh/serverservice/internal/dbtools"
 	"go.hollow.sh/serverservice/internal/httpsrv"
+	serverservice "go.hollow.sh/serverservice/pkg/api/v1"
 )
 
 var (
 	apiDefaultListen   = "0.0.0.0:8000"
 	natsConnectTimeout = 100 * time.Millisecond
+	// credentialExpiryInterval is how often credentials due for rotation are published
+	credentialExpiryInterval = time.Hour
+	// metricsCollectInterval is how often the inventory metrics are counted
+	metricsCollectInterval = time.Minute
+	// shutdownGracePeriod is how long in-flight requests have to finish on shutdown
+	shutdownGracePeriod = 30 * time.Second
+	// shutdownDelay is how long requests are still served once readiness fails on shutdown
+	shutdownDelay = 5 * time.Second
+	// shutdownDrainTimeout is how long the event stream has to drain on shutdown
+	shutdownDrainTimeout = 10 * time.Second
 )
 
 // serveCmd represents the serve command
@@ -42,7 +57,6 @@ func init() {
 	viperx.MustBindFlag(viper.GetViper(), "listen", serveCmd.Flags().Lookup("listen"))
 
 	otelx.MustViperFlags(viper.GetViper(), serveCmd.Flags())
-	crdbx.MustViperFlags(viper.GetViper(), serveCmd.Flags())
 
 	// OIDC Flags
 	serveCmd.Flags().Bool("oidc", true, "use oidc auth")
@@ -57,9 +71,56 @@ func init() {
 	viperx.MustBindFlag(viper.GetViper(), "oidc.claims.roles", serveCmd.Flags().Lookup("oidc-roles-claim"))
 	serveCmd.Flags().String("oidc-username-claim", "", "additional fields to output in logs from the JWT token, ex (email)")
 	viperx.MustBindFlag(viper.GetViper(), "oidc.claims.username", serveCmd.Flags().Lookup("oidc-username-claim"))
-	// DB Flags
-	serveCmd.Flags().String("db-encryption-driver", "", "encryption driver uri; 32 byte base64 encoded string, (example: base64key://your-encoded-secret-key)")
-	viperx.MustBindFlag(viper.GetViper(), "db.encryption_driver", serveCmd.Flags().Lookup("db-encryption-driver"))
+
+	serveCmd.Flags().Duration("credential-expiry-interval", credentialExpiryInterval, "how often credentials older than the max age of their type are published to the event stream, 0 disables it")
+	viperx.MustBindFlag(viper.GetViper(), "credentials.expiry_interval", serveCmd.Flags().Lookup("credential-expiry-interval"))
+	serveCmd.Flags().Int("credential-versions-retained", serverservice.DefaultCredentialVersionsRetained, "number of previous values kept for each credential")
+	viperx.MustBindFlag(viper.GetViper(), "credentials.versions_retained", serveCmd.Flags().Lookup("credential-versions-retained"))
+
+	// TLS Flags
+	serveCmd.Flags().String("tls-cert", "", "path to the TLS certificate, the server listens with TLS when it is set")
+	viperx.MustBindFlag(viper.GetViper(), "tls.cert", serveCmd.Flags().Lookup("tls-cert"))
+	serveCmd.Flags().String("tls-key", "", "path to the TLS private key")
+	viperx.MustBindFlag(viper.GetViper(), "tls.key", serveCmd.Flags().Lookup("tls-key"))
+	serveCmd.Flags().String("tls-client-ca", "", "path to the CA bundle used to verify client certificates, clients must present a certificate when it is set")
+	viperx.MustBindFlag(viper.GetViper(), "tls.client_ca", serveCmd.Flags().Lookup("tls-client-ca"))
+
+	serveCmd.Flags().Duration("metrics-collect-interval", metricsCollectInterval, "how often the inventory metrics are counted from the database, 0 disables them")
+	viperx.MustBindFlag(viper.GetViper(), "metrics.collect_interval", serveCmd.Flags().Lookup("metrics-collect-interval"))
+
+	serveCmd.Flags().StringSlice("readiness-optional-checks", []string{}, "readiness checks that are reported but don't fail readiness, from db, migrations, keeper and stream")
+	viperx.MustBindFlag(viper.GetViper(), "readiness.optional_checks", serveCmd.Flags().Lookup("readiness-optional-checks"))
+	serveCmd.Flags().Duration("readiness-stream-error-window", httpsrv.DefaultStreamErrorWindow, "how long a failed publish makes the stream readiness check fail when no publish succeeds after it")
+	viperx.MustBindFlag(viper.GetViper(), "readiness.stream_error_window", serveCmd.Flags().Lookup("readiness-stream-error-window"))
+
+	serveCmd.Flags().StringSlice("trusted-proxies", []string{}, "addresses or CIDRs of the proxies trusted to set the client address with X-Forwarded-For")
+	viperx.MustBindFlag(viper.GetViper(), "http.trusted_proxies", serveCmd.Flags().Lookup("trusted-proxies"))
+
+	serveCmd.Flags().Duration("shutdown-grace-period", shutdownGracePeriod, "how long in-flight requests have to finish once a shutdown signal is received")
+	viperx.MustBindFlag(viper.GetViper(), "shutdown.grace_period", serveCmd.Flags().Lookup("shutdown-grace-period"))
+	serveCmd.Flags().Duration("shutdown-delay", shutdownDelay, "how long requests are still served while readiness fails before the server shuts down")
+	viperx.MustBindFlag(viper.GetViper(), "shutdown.delay", serveCmd.Flags().Lookup("shutdown-delay"))
+	serveCmd.Flags().Duration("shutdown-drain-timeout", shutdownDrainTimeout, "how long the event stream has to finish its publishes once the server has stopped")
+	viperx.MustBindFlag(viper.GetViper(), "shutdown.drain_timeout", serveCmd.Flags().Lookup("shutdown-drain-timeout"))
+
+	serveCmd.Flags().Duration("firmware-verify-interval", 0, "how often the firmware artifacts not verified within the interval have their checksum verified, 0 disables it, requires --firmware-repository-root")
+	viperx.MustBindFlag(viper.GetViper(), "firmware.verify.interval", serveCmd.Flags().Lookup("firmware-verify-interval"))
+
+	// DB Flags, shared with the commands that need to read or write credentials
+	crdbx.MustViperFlags(viper.GetViper(), rootCmd.PersistentFlags())
+
+	rootCmd.PersistentFlags().String("db-encryption-driver", "", "encryption driver uri; 32 byte base64 encoded string, (example: base64key://your-encoded-secret-key)")
+	viperx.MustBindFlag(viper.GetViper(), "db.encryption_driver", rootCmd.PersistentFlags().Lookup("db-encryption-driver"))
+
+	rootCmd.PersistentFlags().String("db-encryption-key-id", "", "id stored with values encrypted by the encryption driver, leave empty to store values untagged")
+	viperx.MustBindFlag(viper.GetViper(), "db.encryption_key_id", rootCmd.PersistentFlags().Lookup("db-encryption-key-id"))
+
+	rootCmd.PersistentFlags().StringSlice("db-decryption-drivers", []string{}, "additional driver uris only used to decrypt values, as id=uri or uri for untagged values")
+	viperx.MustBindFlag(viper.GetViper(), "db.decryption_drivers", rootCmd.PersistentFlags().Lookup("db-decryption-drivers"))
+
+	// Firmware repository, shared by the firmware verify command and job
+	rootCmd.PersistentFlags().String("firmware-repository-root", "", "http, https or file URL of the repository mirror firmware artifacts are read from, the repository URL of the firmware is read when it's empty")
+	viperx.MustBindFlag(viper.GetViper(), "firmware.repository_root", rootCmd.PersistentFlags().Lookup("firmware-repository-root"))
 
 	// NATs Flags
 	rootCmd.PersistentFlags().String("nats-url", "", "NATS server connection url")
@@ -100,22 +161,57 @@ func serve(ctx context.Context) {
 
 	dbtools.RegisterHooks()
 
-	keeper, err := secrets.OpenKeeper(ctx, viper.GetString("db.encryption_driver"))
-	if err != nil {
-		logger.Fatalw("failed to open secrets keeper", "error", err)
+	// firmware written before the version sort key migration doesn't have a key, and
+	// the version filters would leave it out. Serve anyway when the migration is pending,
+	// readiness reports it. Keys computed by older rules are updated by the firmware
+	// reindex-versions command, so replicas don't all read every firmware on start.
+	if updated, err := dbtools.IndexMissingFirmwareVersions(ctx, db); err != nil {
+		logger.Errorw("failed reindexing firmware versions", "error", err, "updated", updated)
+	} else if updated != 0 {
+		logger.Infow("reindexed firmware versions", "updated", updated)
 	}
-	defer keeper.Close()
+
+	keyring := initKeyring(ctx)
+	defer keyring.Close()
 
 	logger.Infow("starting server",
 		"address", viper.GetString("listen"),
+		"tls", viper.GetString("tls.cert") != "",
 	)
 
+	migrations, err := fs.Sub(dbm.Migrations, "migrations")
+	if err != nil {
+		logger.Fatalw("failed to load database migrations", "error", err)
+	}
+
 	hs := &httpsrv.Server{
-		Logger:        logger.Desugar(),
-		Listen:        viper.GetString("listen"),
-		Debug:         config.AppConfig.Logging.Debug,
-		DB:            db,
-		SecretsKeeper: keeper,
+		Logger:  logger.Desugar(),
+		Listen:  viper.GetString("listen"),
+		Debug:   config.AppConfig.Logging.Debug,
+		DB:      db,
+		Keyring: keyring,
+		// time given to in-flight requests once SIGINT or SIGTERM is received
+		ShutdownGracePeriod: viper.GetDuration("shutdown.grace_period"),
+		// lets load balancers see the failing readiness before the listener is closed
+		ShutdownDelay: viper.GetDuration("shutdown.delay"),
+		// readiness fails while the database has pending migrations
+		Migrations:           migrations,
+		OptionalHealthChecks: viper.GetStringSlice("readiness.optional_checks"),
+		// the forwarded headers of other clients are ignored
+		TrustedProxies: viper.GetStringSlice("http.trusted_proxies"),
+		// exported with the http metrics on /metrics
+		MetricsCollectInterval: viper.GetDuration("metrics.collect_interval"),
+		// the certificate files are reloaded when they change
+		TLS: httpsrv.TLSConfig{
+			CertFile:     viper.GetString("tls.cert"),
+			KeyFile:      viper.GetString("tls.key"),
+			ClientCAFile: viper.GetString("tls.client_ca"),
+			ClientScopes: initClientScopes(),
+		},
+		// only used when the event stream is configured
+		CredentialExpiryInterval:   viper.GetDuration("credentials.expiry_interval"),
+		CredentialVersionsRetained: viper.GetInt("credentials.versions_retained"),
+		FirmwareSetRevisions:       viper.GetBool("firmware.set_revisions"),
 		AuthConfig: ginjwt.AuthConfig{
 			Enabled:       viper.GetBool("oidc.enabled"),
 			Audience:      viper.GetString("oidc.audience"),
@@ -127,16 +223,72 @@ func serve(ctx context.Context) {
 		},
 	}
 
+	if interval := viper.GetDuration("firmware.verify.interval"); interval > 0 {
+		// the job runs unattended, it only reads artifacts from a mirror the operator chose
+		// rather than any URL stored with the firmware
+		if viper.GetString("firmware.repository_root") == "" {
+			logger.Fatalw("the firmware verify job requires a firmware repository root", "interval", interval)
+		}
+
+		hs.FirmwareVerifyInterval = interval
+		hs.FirmwareVerifier = initFirmwareVerifier()
+	}
+
 	// init event stream - for now, only when nats.url is specified
-	eventstream := initStream()
-	if eventstream != nil {
+	var eventstream *httpsrv.MonitoredStream
+	if stream := initStream(); stream != nil {
+		eventstream = httpsrv.NewMonitoredStream(stream)
+		eventstream.ErrorWindow = viper.GetDuration("readiness.stream_error_window")
+		eventstream.Conn = initStreamConn()
 		hs.EventStream = eventstream
-		defer hs.EventStream.Close()
 	}
 
-	if err := hs.Run(); err != nil {
-		logger.Fatalw("failed starting server", "error", err)
+	// a stream that failed to open, or that fails to publish, is reported by the readiness check
+	if viper.GetString("nats.url") != "" {
+		hs.HealthCheckers = append(hs.HealthCheckers, httpsrv.NewStreamChecker(eventstream))
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := hs.Run(ctx); err != nil {
+		// the connections are still closed when in-flight requests didn't finish in time
+		if !errors.Is(err, httpsrv.ErrShutdown) {
+			logger.Fatalw("failed starting server", "error", err)
+		}
+
+		logger.Errorw("server didn't shut down cleanly", "error", err)
+	}
+
+	// the server has finished handling requests, let the publishes still running finish
+	// before closing the connections it used
+	if eventstream != nil {
+		drainCtx, cancel := context.WithTimeout(context.Background(), viper.GetDuration("shutdown.drain_timeout"))
+
+		if err := eventstream.Drain(drainCtx); err != nil {
+			logger.Errorw("failed to drain event stream", "error", err)
+		}
+
+		cancel()
+	}
+
+	if hs.EventStream != nil {
+		if err := hs.EventStream.Close(); err != nil {
+			logger.Errorw("failed to close event stream", "error", err)
+		}
 	}
+
+	if eventstream != nil {
+		if conn, ok := eventstream.Conn.(*nats.Conn); ok {
+			conn.Close()
+		}
+	}
+
+	if err := db.Close(); err != nil {
+		logger.Errorw("failed to close database connection", "error", err)
+	}
+
+	logger.Info("server stopped")
 }
 
 func initStream() events.Stream {
@@ -161,6 +313,34 @@ func initStream() events.Stream {
 	return stream
 }
 
+// initStreamConn connects to the NATS servers of the event stream with the stream
+// credentials, the readiness check uses the connection to see if the servers are still
+// reachable while nothing is published. It keeps reconnecting until it's closed. It
+// returns nil when the connection can't be set up.
+func initStreamConn() httpsrv.StreamConn {
+	opts := []nats.Option{
+		nats.Name(appName + "-readiness"),
+		nats.Timeout(viper.GetDuration("nats.connect.timeout")),
+		nats.MaxReconnects(-1),
+		nats.RetryOnFailedConnect(true),
+	}
+
+	if creds := viper.GetString("nats.creds.file"); creds != "" {
+		opts = append(opts, nats.UserCredentials(creds))
+	} else if user := viper.GetString("nats.stream.user"); user != "" {
+		opts = append(opts, nats.UserInfo(user, viper.GetString("nats.stream.pass")))
+	}
+
+	conn, err := nats.Connect(viper.GetString("nats.url"), opts...)
+	if err != nil {
+		logger.Warnw("error connecting to NATS for the readiness check", "error", err.Error())
+
+		return nil
+	}
+
+	return conn
+}
+
 func natsOptions(appName, serverURL string) events.NatsOptions {
 	return events.NatsOptions{
 		AppName:                appName,
@@ -178,6 +358,46 @@ func natsOptions(appName, serverURL string) events.NatsOptions {
 	}
 }
 
+// initClientScopes reads the scopes granted to client certificates from the tls.client_scopes
+// config, a list of certificate subjects with their scopes. It's read as a list so the
+// subjects keep their case.
+func initClientScopes() map[string][]string {
+	var clients []struct {
+		Subject string   `mapstructure:"subject"`
+		Scopes  []string `mapstructure:"scopes"`
+	}
+
+	if err := viper.UnmarshalKey("tls.client_scopes", &clients); err != nil {
+		logger.Fatalw("failed to read client certificate scopes", "error", err)
+	}
+
+	scopes := map[string][]string{}
+	for _, c := range clients {
+		// requests without a client certificate have an empty subject
+		if strings.TrimSpace(c.Subject) == "" {
+			logger.Fatalw("client certificate scopes need a subject", "scopes", c.Scopes)
+		}
+
+		scopes[c.Subject] = append(scopes[c.Subject], c.Scopes...)
+	}
+
+	return scopes
+}
+
+func initKeyring(ctx context.Context) *dbtools.Keyring {
+	keyring, err := dbtools.OpenKeyring(
+		ctx,
+		viper.GetString("db.encryption_key_id"),
+		viper.GetString("db.encryption_driver"),
+		viper.GetStringSlice("db.decryption_drivers"),
+	)
+	if err != nil {
+		logger.Fatalw("failed to open secrets keyring", "error", err)
+	}
+
+	return keyring
+}
+
 func initDB() *sqlx.DB {
 	dbDriverName := "postgres"
 
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- keeps the previous encrypted values of a server credential
+CREATE TABLE server_credential_versions (
+  id UUID PRIMARY KEY NOT NULL DEFAULT gen_random_uuid(),
+  server_credential_id UUID NOT NULL REFERENCES server_credentials(id) ON DELETE CASCADE,
+  version INT8 NOT NULL,
+  username STRING NOT NULL,
+  password STRING NOT NULL,
+  created_by STRING NOT NULL DEFAULT '',
+  created_at TIMESTAMPTZ NOT NULL,
+  UNIQUE INDEX idx_server_credential_versions_by_version (server_credential_id, version)
+);
+
+-- seed the history with the current value of every credential
+INSERT INTO server_credential_versions(server_credential_id, version, username, password, created_at)
+  SELECT id, 1, username, password, updated_at FROM server_credentials;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE server_credential_versions;
+
+-- +goose StatementEnd
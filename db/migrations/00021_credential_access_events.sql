@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- audit trail of reads of decrypted server credentials. server_id isn't a foreign
+-- key so the trail is kept after a server is deleted.
+CREATE TABLE credential_access_events (
+  id UUID PRIMARY KEY NOT NULL DEFAULT gen_random_uuid(),
+  server_id UUID NOT NULL,
+  credential_slug STRING NOT NULL,
+  action STRING NOT NULL,
+  subject STRING NOT NULL DEFAULT '',
+  username STRING NOT NULL DEFAULT '',
+  source_ip STRING NOT NULL DEFAULT '',
+  created_at TIMESTAMPTZ NOT NULL,
+  INDEX idx_credential_access_events_created_at (created_at),
+  INDEX idx_credential_access_events_server_id (server_id, created_at)
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE credential_access_events;
+
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE server_credential_types ADD COLUMN password_policy JSONB NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE server_credential_types DROP COLUMN password_policy;
+
+-- +goose StatementEnd
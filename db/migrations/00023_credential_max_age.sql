@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE server_credential_types ADD COLUMN max_age INT8 NULL;
+ALTER TABLE server_credentials ADD COLUMN expiry_notified_at TIMESTAMPTZ NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE server_credentials DROP COLUMN expiry_notified_at;
+ALTER TABLE server_credential_types DROP COLUMN max_age;
+
+-- +goose StatementEnd
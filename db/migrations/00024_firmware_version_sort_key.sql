@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE component_firmware_version ADD COLUMN version_sort_key STRING NULL;
+CREATE INDEX idx_firmware_version_sort_key ON component_firmware_version (vendor, component, version_sort_key);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX component_firmware_version@idx_firmware_version_sort_key;
+ALTER TABLE component_firmware_version DROP COLUMN version_sort_key;
+
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE component_firmware_version ADD COLUMN status STRING NOT NULL DEFAULT 'active';
+ALTER TABLE component_firmware_version ADD COLUMN status_reason STRING NULL;
+ALTER TABLE component_firmware_version ADD CONSTRAINT check_firmware_status CHECK (status IN ('active', 'deprecated', 'recalled'));
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE component_firmware_version DROP CONSTRAINT check_firmware_status;
+ALTER TABLE component_firmware_version DROP COLUMN status_reason;
+ALTER TABLE component_firmware_version DROP COLUMN status;
+
+-- +goose StatementEnd
@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- keeps the firmware set document as of each change to a firmware set
+CREATE TABLE component_firmware_set_revision (
+  id UUID PRIMARY KEY NOT NULL DEFAULT gen_random_uuid(),
+  firmware_set_id UUID NOT NULL REFERENCES component_firmware_set(id) ON DELETE CASCADE,
+  revision INT8 NOT NULL,
+  document JSONB NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL,
+  UNIQUE INDEX idx_component_firmware_set_revision_by_revision (firmware_set_id, revision)
+);
+
+-- seed the history with the current content of every firmware set, in the same shape
+-- as the documents recorded by the API
+INSERT INTO component_firmware_set_revision(firmware_set_id, revision, document, created_at)
+  SELECT s.id, 1, jsonb_build_object(
+    'name', s.name,
+    'attributes', COALESCE((
+      SELECT jsonb_agg(jsonb_build_object('namespace', a.namespace, 'data', a.data) ORDER BY a.namespace)
+      FROM attributes_firmware_set a WHERE a.firmware_set_id = s.id
+    ), '[]'::JSONB),
+    'firmware', COALESCE((
+      SELECT jsonb_agg(jsonb_build_object('vendor', f.vendor, 'component', f.component, 'version', f.version, 'filename', f.filename,
+        'uuid', f.id, 'checksum', f.checksum))
+      FROM component_firmware_set_map m JOIN component_firmware_version f ON f.id = m.firmware_id WHERE m.firmware_set_id = s.id
+    ), '[]'::JSONB)
+  ), COALESCE(s.updated_at, now())
+  FROM component_firmware_set s;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE component_firmware_set_revision;
+
+-- +goose StatementEnd
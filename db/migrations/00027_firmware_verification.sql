@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE component_firmware_version ADD COLUMN verification_status STRING NULL;
+ALTER TABLE component_firmware_version ADD COLUMN verified_at TIMESTAMPTZ NULL;
+ALTER TABLE component_firmware_version ADD CONSTRAINT check_firmware_verification_status CHECK (verification_status IN ('verified', 'mismatch', 'failed'));
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE component_firmware_version DROP CONSTRAINT check_firmware_verification_status;
+ALTER TABLE component_firmware_version DROP COLUMN verified_at;
+ALTER TABLE component_firmware_version DROP COLUMN verification_status;
+
+-- +goose StatementEnd
@@ -3,24 +3,38 @@ package dbtools
 import (
 	"context"
 	"encoding/base64"
-
-	"gocloud.dev/secrets"
+	"fmt"
+	"strings"
 )
 
-// Encrypt provides a wrapper to handle encrypting a string with the secrets keeper
-// and returns it already base64 encoded
-func Encrypt(ctx context.Context, keeper *secrets.Keeper, str string) (string, error) {
-	cipher, err := keeper.Encrypt(ctx, []byte(str))
+// Encrypt provides a wrapper to handle encrypting a string with the primary key of
+// the keyring and returns it already base64 encoded. The value is prefixed with the
+// id of the key that encrypted it, unless the key has no id.
+func Encrypt(ctx context.Context, keyring *Keyring, str string) (string, error) {
+	cipher, err := keyring.primary().Encrypt(ctx, []byte(str))
 	if err != nil {
 		return "", err
 	}
 
-	return base64.StdEncoding.EncodeToString(cipher), nil
+	value := base64.StdEncoding.EncodeToString(cipher)
+
+	if keyring.primaryID == "" {
+		return value, nil
+	}
+
+	return keyring.primaryID + keyIDSeparator + value, nil
 }
 
 // Decrypt provides a wrapper to handle decrypting a base64 encoded string with
-// the secrets keeper
-func Decrypt(ctx context.Context, keeper *secrets.Keeper, base64str string) (string, error) {
+// the key from the keyring that encrypted it
+func Decrypt(ctx context.Context, keyring *Keyring, value string) (string, error) {
+	keyID, base64str := splitKeyID(value)
+
+	keeper, ok := keyring.keepers[keyID]
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnknownKeyID, keyID)
+	}
+
 	plain, err := base64.StdEncoding.DecodeString(base64str)
 	if err != nil {
 		return "", err
@@ -30,3 +44,21 @@ func Decrypt(ctx context.Context, keeper *secrets.Keeper, base64str string) (str
 
 	return string(decrypted), err
 }
+
+// KeyID returns the id of the key that was used to encrypt the value. Values that
+// were encrypted with a key that has no id return an empty string.
+func KeyID(value string) string {
+	keyID, _ := splitKeyID(value)
+
+	return keyID
+}
+
+func splitKeyID(value string) (string, string) {
+	// the separator is not part of the base64 alphabet so untagged values never contain it
+	keyID, base64str, found := strings.Cut(value, keyIDSeparator)
+	if !found {
+		return "", value
+	}
+
+	return keyID, base64str
+}
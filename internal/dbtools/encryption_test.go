@@ -2,24 +2,126 @@ package dbtools_test
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gocloud.dev/secrets"
 
 	"go.hollow.sh/serverservice/internal/dbtools"
 )
 
 func TestEncryptandDecrypt(t *testing.T) {
 	ctx := context.TODO()
-	keeper := dbtools.TestSecretKeeper(t)
+	keyring := dbtools.TestKeyring(t)
 
 	secretKey := "NotARealPassword"
 
-	encrypted, err := dbtools.Encrypt(ctx, keeper, secretKey)
+	encrypted, err := dbtools.Encrypt(ctx, keyring, secretKey)
 	assert.NoError(t, err)
 	assert.NotEqual(t, secretKey, encrypted)
 
-	decrypted, err := dbtools.Decrypt(ctx, keeper, encrypted)
+	decrypted, err := dbtools.Decrypt(ctx, keyring, encrypted)
 	assert.NoError(t, err)
 	assert.Equal(t, secretKey, decrypted)
 }
+
+func TestEncryptWithKeyID(t *testing.T) {
+	ctx := context.TODO()
+
+	oldKeeper, err := secrets.OpenKeeper(ctx, "base64key://")
+	require.NoError(t, err)
+
+	newKeeper, err := secrets.OpenKeeper(ctx, "base64key://")
+	require.NoError(t, err)
+
+	oldKeyring, err := dbtools.NewKeyring("", oldKeeper)
+	require.NoError(t, err)
+
+	legacy, err := dbtools.Encrypt(ctx, oldKeyring, "NotARealPassword")
+	require.NoError(t, err)
+	assert.Equal(t, "", dbtools.KeyID(legacy))
+
+	keyring, err := dbtools.NewKeyring("key-2", newKeeper)
+	require.NoError(t, err)
+
+	encrypted, err := dbtools.Encrypt(ctx, keyring, "NotARealPassword")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(encrypted, "key-2:"))
+	assert.Equal(t, "key-2", dbtools.KeyID(encrypted))
+	assert.False(t, keyring.NeedsRekey(encrypted))
+
+	t.Run("unknown key id", func(t *testing.T) {
+		_, err := dbtools.Decrypt(ctx, keyring, legacy)
+		assert.ErrorIs(t, err, dbtools.ErrUnknownKeyID)
+		assert.True(t, keyring.NeedsRekey(legacy))
+	})
+
+	t.Run("decrypts with legacy key", func(t *testing.T) {
+		require.NoError(t, keyring.AddDecryptionKey("", oldKeeper))
+
+		decrypted, err := dbtools.Decrypt(ctx, keyring, legacy)
+		assert.NoError(t, err)
+		assert.Equal(t, "NotARealPassword", decrypted)
+	})
+
+	t.Run("duplicate key id", func(t *testing.T) {
+		err := keyring.AddDecryptionKey("key-2", oldKeeper)
+		assert.ErrorIs(t, err, dbtools.ErrDuplicateKeyID)
+	})
+
+	t.Run("invalid key id", func(t *testing.T) {
+		_, err := dbtools.NewKeyring("key:3", newKeeper)
+		assert.ErrorIs(t, err, dbtools.ErrInvalidKeyID)
+	})
+}
+
+func TestOpenKeyring(t *testing.T) {
+	ctx := context.TODO()
+
+	old := "base64key://smGbjm71Nxd1Ig5FS0wj9SlbzAIrnolCz9bQQ6uAhl4="
+	older := "base64key://vVy0h4jrCz1s2V4TyTXnXnMp9xwD3pPi0TCBH3HWrGQ="
+
+	oldKeyring, err := dbtools.OpenKeyring(ctx, "", old, nil)
+	require.NoError(t, err)
+
+	untagged, err := dbtools.Encrypt(ctx, oldKeyring, "untagged")
+	require.NoError(t, err)
+
+	olderKeyring, err := dbtools.OpenKeyring(ctx, "older", older, nil)
+	require.NoError(t, err)
+
+	tagged, err := dbtools.Encrypt(ctx, olderKeyring, "tagged")
+	require.NoError(t, err)
+
+	keyring, err := dbtools.OpenKeyring(ctx, "current", "base64key://", []string{old, "older=" + older})
+	require.NoError(t, err)
+
+	defer keyring.Close()
+
+	assert.Equal(t, "current", keyring.PrimaryKeyID())
+
+	decrypted, err := dbtools.Decrypt(ctx, keyring, untagged)
+	assert.NoError(t, err)
+	assert.Equal(t, "untagged", decrypted)
+
+	decrypted, err = dbtools.Decrypt(ctx, keyring, tagged)
+	assert.NoError(t, err)
+	assert.Equal(t, "tagged", decrypted)
+}
+
+func TestKeyringCheck(t *testing.T) {
+	ctx := context.TODO()
+
+	assert.NoError(t, dbtools.TestKeyring(t).Check(ctx))
+
+	keeper, err := secrets.OpenKeeper(ctx, "base64key://")
+	require.NoError(t, err)
+
+	keyring, err := dbtools.NewKeyring("key-1", keeper)
+	require.NoError(t, err)
+
+	require.NoError(t, keyring.Close())
+	assert.Error(t, keyring.Check(ctx))
+}
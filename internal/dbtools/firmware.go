@@ -0,0 +1,333 @@
+package dbtools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"go.hollow.sh/serverservice/internal/fwverify"
+	"go.hollow.sh/serverservice/internal/fwversion"
+	"go.hollow.sh/serverservice/internal/models"
+)
+
+// ReindexFirmwareVersions sets the version sort key of every firmware whose key is
+// missing or was computed by an older version of the comparison rules, and returns
+// the number of firmware updated. Firmware written by the API always has a current key.
+func ReindexFirmwareVersions(ctx context.Context, db *sqlx.DB) (int, error) {
+	return reindexFirmwareVersions(ctx, db)
+}
+
+// IndexMissingFirmwareVersions sets the version sort key of the firmware that doesn't
+// have one, and returns the number of firmware updated. Unlike ReindexFirmwareVersions
+// it only reads the firmware it updates.
+func IndexMissingFirmwareVersions(ctx context.Context, db *sqlx.DB) (int, error) {
+	return reindexFirmwareVersions(ctx, db, models.ComponentFirmwareVersionWhere.VersionSortKey.IsNull())
+}
+
+func reindexFirmwareVersions(ctx context.Context, db *sqlx.DB, mods ...qm.QueryMod) (int, error) {
+	firmware, err := models.ComponentFirmwareVersions(mods...).All(ctx, db)
+	if err != nil {
+		return 0, err
+	}
+
+	updated := 0
+
+	for _, f := range firmware {
+		key := null.StringFrom(fwversion.SortKey(f.Version))
+		if f.VersionSortKey == key {
+			continue
+		}
+
+		f.VersionSortKey = key
+
+		if _, err := f.Update(ctx, db, boil.Whitelist(models.ComponentFirmwareVersionColumns.VersionSortKey)); err != nil {
+			return updated, err
+		}
+
+		updated++
+	}
+
+	return updated, nil
+}
+
+const (
+	// FirmwareAdded is the import status of firmware that wasn't in the database
+	FirmwareAdded = "added"
+	// FirmwareChanged is the import status of firmware that was updated
+	FirmwareChanged = "changed"
+	// FirmwareSkipped is the import status of firmware already in the database as it is in the catalog
+	FirmwareSkipped = "skipped"
+)
+
+var (
+	// ErrFirmwareIncomplete is returned when firmware that isn't in the database yet is
+	// imported without a checksum or URLs
+	ErrFirmwareIncomplete = errors.New("firmware requires a checksum, upstream URL and repository URL")
+	// ErrFirmwareAmbiguousVendor is returned when the database has the same firmware
+	// stored with vendors that only differ by case
+	ErrFirmwareAmbiguousVendor = errors.New("firmware is stored with several vendors differing by case")
+)
+
+// FirmwareImportOptions controls how firmware is imported
+type FirmwareImportOptions struct {
+	// DryRun imports the firmware in a transaction that is rolled back
+	DryRun bool
+	// Report is called with the status of each firmware after it's written
+	Report func(f *models.ComponentFirmwareVersion, status string)
+}
+
+// FirmwareImportStats counts the firmware processed by ImportFirmware
+type FirmwareImportStats struct {
+	Added   int
+	Changed int
+	Skipped int
+}
+
+// ImportFirmware upserts the firmware by vendor, component, version and filename in one
+// transaction. The models of firmware already in the database are replaced when the
+// firmware lists models, the checksum and URLs are replaced when they aren't empty.
+// Firmware that isn't in the database must have a checksum and URLs. The firmware
+// passed in isn't changed, Report gets the firmware as it's stored.
+func ImportFirmware(ctx context.Context, db *sqlx.DB, firmware []*models.ComponentFirmwareVersion, opts FirmwareImportOptions) (FirmwareImportStats, error) {
+	var stats FirmwareImportStats
+
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return stats, err
+	}
+
+	// nolint:errcheck // rollback is a no-op once the transaction is committed
+	defer tx.Rollback()
+
+	for _, f := range firmware {
+		status, stored, err := importFirmware(ctx, tx, f)
+		if err != nil {
+			return stats, err
+		}
+
+		switch status {
+		case FirmwareAdded:
+			stats.Added++
+		case FirmwareChanged:
+			stats.Changed++
+		default:
+			stats.Skipped++
+		}
+
+		if opts.Report != nil {
+			opts.Report(stored, status)
+		}
+	}
+
+	if opts.DryRun {
+		return stats, tx.Rollback()
+	}
+
+	return stats, tx.Commit()
+}
+
+// importFirmware writes the firmware and returns it as it's stored, matching the stored
+// firmware by vendor ignoring case, since catalogs and the API don't always agree on it
+func importFirmware(ctx context.Context, exec boil.ContextExecutor, f *models.ComponentFirmwareVersion) (string, *models.ComponentFirmwareVersion, error) {
+	// more than one match means vendors differing by case, which one to update can't be told
+	matches, err := models.ComponentFirmwareVersions(
+		qm.Where("lower("+models.ComponentFirmwareVersionColumns.Vendor+") = lower(?)", f.Vendor),
+		models.ComponentFirmwareVersionWhere.Component.EQ(f.Component),
+		models.ComponentFirmwareVersionWhere.Version.EQ(f.Version),
+		models.ComponentFirmwareVersionWhere.Filename.EQ(f.Filename),
+		qm.Limit(2),
+	).All(ctx, exec)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if len(matches) > 1 {
+		return "", nil, fmt.Errorf("%w: %s/%s/%s/%s is stored with the vendors %s and %s", ErrFirmwareAmbiguousVendor,
+			f.Vendor, f.Component, f.Version, f.Filename, matches[0].Vendor, matches[1].Vendor)
+	}
+
+	if len(matches) == 0 {
+		if f.Checksum == "" || f.UpstreamURL == "" || f.RepositoryURL == "" {
+			return "", nil, fmt.Errorf("%w: %s", ErrFirmwareIncomplete, strings.Join([]string{f.Vendor, f.Component, f.Version, f.Filename}, "/"))
+		}
+
+		added := *f
+
+		return FirmwareAdded, &added, added.Insert(ctx, exec, boil.Infer())
+	}
+
+	existing := matches[0]
+	changed := false
+
+	update := func(dst *string, src string) {
+		if src != "" && *dst != src {
+			*dst = src
+			changed = true
+		}
+	}
+
+	update(&existing.Checksum, f.Checksum)
+	update(&existing.RepositoryURL, f.RepositoryURL)
+
+	if changed {
+		ResetFirmwareVerification(existing)
+	}
+
+	update(&existing.UpstreamURL, f.UpstreamURL)
+
+	if len(f.Model) != 0 && !sameModels(existing.Model, f.Model) {
+		existing.Model = f.Model
+		changed = true
+	}
+
+	if !changed {
+		return FirmwareSkipped, existing, nil
+	}
+
+	if _, err := existing.Update(ctx, exec, boil.Infer()); err != nil {
+		return "", nil, err
+	}
+
+	return FirmwareChanged, existing, nil
+}
+
+// sameModels returns if the firmware supports the same models, ignoring their order
+func sameModels(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	count := map[string]int{}
+
+	for _, m := range a {
+		count[m]++
+	}
+
+	for _, m := range b {
+		if count[m] == 0 {
+			return false
+		}
+
+		count[m]--
+	}
+
+	return true
+}
+
+// FirmwareVerifyOptions controls which firmware is verified
+type FirmwareVerifyOptions struct {
+	// VerifiedBefore limits the verification to firmware that hasn't been verified
+	// since, all firmware is verified when it's zero
+	VerifiedBefore time.Time
+	// Report is called with the status of each firmware after it's recorded, the
+	// error explains a mismatch or failed status
+	Report func(f *models.ComponentFirmwareVersion, status string, err error)
+}
+
+// FirmwareVerifyStats counts the firmware processed by VerifyFirmware. Skipped counts the
+// firmware verified by another run, or changed while it was verified, whose result isn't
+// recorded.
+type FirmwareVerifyStats struct {
+	Verified   int
+	Mismatched int
+	Failed     int
+	Skipped    int
+}
+
+// VerifyFirmware computes the checksum of the artifact of each firmware and records the
+// verification status and time on the firmware. Each firmware is claimed by setting its
+// verification time before the artifact is read, so when several replicas run this only
+// one of them verifies it. The status is only recorded when the checksum and repository
+// URL of the firmware didn't change while it was verified.
+func VerifyFirmware(ctx context.Context, db *sqlx.DB, v *fwverify.Verifier, opts FirmwareVerifyOptions) (FirmwareVerifyStats, error) {
+	var stats FirmwareVerifyStats
+
+	mods := []qm.QueryMod{qm.OrderBy(models.ComponentFirmwareVersionColumns.ID)}
+
+	if !opts.VerifiedBefore.IsZero() {
+		mods = append(mods, qm.Expr(
+			models.ComponentFirmwareVersionWhere.VerifiedAt.IsNull(),
+			qm.Or2(models.ComponentFirmwareVersionWhere.VerifiedAt.LT(null.TimeFrom(opts.VerifiedBefore))),
+		))
+	}
+
+	firmware, err := models.ComponentFirmwareVersions(mods...).All(ctx, db)
+	if err != nil {
+		return stats, err
+	}
+
+	for _, f := range firmware {
+		if err := ctx.Err(); err != nil {
+			return stats, err
+		}
+
+		// the firmware as it was listed, an edit changes its updated_at
+		unchanged := []qm.QueryMod{
+			models.ComponentFirmwareVersionWhere.ID.EQ(f.ID),
+			models.ComponentFirmwareVersionWhere.Checksum.EQ(f.Checksum),
+			models.ComponentFirmwareVersionWhere.RepositoryURL.EQ(f.RepositoryURL),
+			models.ComponentFirmwareVersionWhere.UpdatedAt.EQ(f.UpdatedAt),
+		}
+
+		claimed, err := models.ComponentFirmwareVersions(
+			append(unchanged, models.ComponentFirmwareVersionWhere.VerifiedAt.EQ(f.VerifiedAt))...,
+		).UpdateAll(ctx, db, models.M{models.ComponentFirmwareVersionColumns.VerifiedAt: time.Now()})
+		if err != nil {
+			return stats, err
+		}
+
+		// another run verified the firmware, or it changed, since it was listed
+		if claimed == 0 {
+			stats.Skipped++
+			continue
+		}
+
+		status, verifyErr := v.Verify(ctx, f.Checksum, f.RepositoryURL)
+
+		f.VerificationStatus = null.StringFrom(status)
+		f.VerifiedAt = null.TimeFrom(time.Now())
+
+		recorded, err := models.ComponentFirmwareVersions(unchanged...).UpdateAll(ctx, db, models.M{
+			models.ComponentFirmwareVersionColumns.VerificationStatus: f.VerificationStatus,
+			models.ComponentFirmwareVersionColumns.VerifiedAt:         f.VerifiedAt,
+		})
+		if err != nil {
+			return stats, err
+		}
+
+		// the edit reset the verification, the result is for the previous artifact
+		if recorded == 0 {
+			stats.Skipped++
+			continue
+		}
+
+		switch status {
+		case fwverify.StatusVerified:
+			stats.Verified++
+		case fwverify.StatusMismatch:
+			stats.Mismatched++
+		default:
+			stats.Failed++
+		}
+
+		if opts.Report != nil {
+			opts.Report(f, status, verifyErr)
+		}
+	}
+
+	return stats, nil
+}
+
+// ResetFirmwareVerification clears the verification of firmware whose checksum or
+// repository URL changed, the previous result no longer applies
+func ResetFirmwareVerification(f *models.ComponentFirmwareVersion) {
+	f.VerificationStatus = null.String{}
+	f.VerifiedAt = null.Time{}
+}
@@ -0,0 +1,491 @@
+package dbtools
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/types"
+
+	"go.hollow.sh/serverservice/internal/models"
+)
+
+const (
+	// FirmwareSetCreated is the import status of a firmware set that didn't exist
+	FirmwareSetCreated = "created"
+	// FirmwareSetUpdated is the import status of a firmware set that was changed
+	FirmwareSetUpdated = "updated"
+	// FirmwareSetUnchanged is the import status of a firmware set already matching the document
+	FirmwareSetUnchanged = "unchanged"
+)
+
+var (
+	// ErrFirmwareSetDocument is returned when a firmware set document is invalid or
+	// references firmware that doesn't exist
+	ErrFirmwareSetDocument = errors.New("invalid firmware set document")
+	// ErrFirmwareSetConflict is returned when a firmware set has two firmware for the
+	// same vendor, component and model
+	ErrFirmwareSetConflict = errors.New("conflicting firmware in firmware set")
+)
+
+// FirmwareSetDocument is the content of a firmware set, with the firmware referenced by
+// vendor, component, version and filename. It's the document stored with each revision.
+type FirmwareSetDocument struct {
+	Name       string                         `json:"name"`
+	Attributes []FirmwareSetDocumentAttribute `json:"attributes,omitempty"`
+	Firmware   []FirmwareReference            `json:"firmware"`
+}
+
+// FirmwareSetDocumentAttribute is the data of a firmware set attributes namespace
+type FirmwareSetDocumentAttribute struct {
+	Namespace string      `json:"namespace"`
+	Data      interface{} `json:"data"`
+}
+
+// FirmwareReference identifies a firmware by the fields that are unique to it. Revisions
+// also record the id and checksum of the firmware, so a revision still tells which
+// artifact it had after the firmware is edited. They are ignored when a document is
+// imported, firmware is resolved by its unique fields.
+type FirmwareReference struct {
+	Vendor    string `json:"vendor"`
+	Component string `json:"component"`
+	Version   string `json:"version"`
+	Filename  string `json:"filename"`
+	UUID      string `json:"uuid,omitempty"`
+	Checksum  string `json:"checksum,omitempty"`
+}
+
+func (f FirmwareReference) String() string {
+	return strings.Join([]string{f.Vendor, f.Component, f.Version, f.Filename}, "/")
+}
+
+// FirmwareSetImportResult is the outcome of importing a firmware set document
+type FirmwareSetImportResult struct {
+	ID     string
+	Name   string
+	Status string
+}
+
+// FirmwareSetImportOptions controls how a firmware set is imported
+type FirmwareSetImportOptions struct {
+	// Update is called in the transaction before a firmware set that already exists is
+	// changed, an error stops the import
+	Update func(existing *models.ComponentFirmwareSet) error
+	// Revisions records a revision of the firmware set when it's created or changed
+	Revisions bool
+}
+
+// ImportFirmwareSet creates the firmware set described by the document, or updates the
+// firmware set with the same name so its attributes and firmware are the ones in the
+// document. Nothing is written when the firmware set already matches.
+func ImportFirmwareSet(ctx context.Context, db *sqlx.DB, doc *FirmwareSetDocument, opts FirmwareSetImportOptions) (*FirmwareSetImportResult, error) {
+	if err := doc.validate(); err != nil {
+		return nil, err
+	}
+
+	attrs, err := doc.attributesJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// nolint:errcheck // rollback is a no-op once the transaction is committed
+	defer tx.Rollback()
+
+	firmwares, err := resolveFirmwareReferences(ctx, tx, doc.Firmware)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := FirmwareSetConflict(firmwares); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrFirmwareSetDocument, err)
+	}
+
+	firmwareIDs := make([]string, 0, len(firmwares))
+	for _, f := range firmwares {
+		firmwareIDs = append(firmwareIDs, f.ID)
+	}
+
+	dbFirmwareSet, err := models.ComponentFirmwareSets(
+		models.ComponentFirmwareSetWhere.Name.EQ(doc.Name),
+		qm.Load(models.ComponentFirmwareSetRels.FirmwareSetAttributesFirmwareSets),
+		qm.Load(models.ComponentFirmwareSetRels.FirmwareSetComponentFirmwareSetMaps),
+	).One(ctx, tx)
+
+	result := &FirmwareSetImportResult{Name: doc.Name}
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		dbFirmwareSet = &models.ComponentFirmwareSet{Name: doc.Name}
+		if err := dbFirmwareSet.Insert(ctx, tx, boil.Infer()); err != nil {
+			return nil, err
+		}
+
+		result.Status = FirmwareSetCreated
+	case err != nil:
+		return nil, err
+	case firmwareSetMatches(dbFirmwareSet, attrs, firmwareIDs):
+		result.ID = dbFirmwareSet.ID
+		result.Status = FirmwareSetUnchanged
+
+		return result, nil
+	default:
+		if opts.Update != nil {
+			if err := opts.Update(dbFirmwareSet); err != nil {
+				return nil, err
+			}
+		}
+
+		if _, err := dbFirmwareSet.R.FirmwareSetAttributesFirmwareSets.DeleteAll(ctx, tx); err != nil {
+			return nil, err
+		}
+
+		if _, err := dbFirmwareSet.R.FirmwareSetComponentFirmwareSetMaps.DeleteAll(ctx, tx); err != nil {
+			return nil, err
+		}
+
+		if _, err := dbFirmwareSet.Update(ctx, tx, boil.Infer()); err != nil {
+			return nil, err
+		}
+
+		result.Status = FirmwareSetUpdated
+	}
+
+	for _, a := range doc.Attributes {
+		dbAttributes := &models.AttributesFirmwareSet{
+			FirmwareSetID: null.StringFrom(dbFirmwareSet.ID),
+			Namespace:     a.Namespace,
+			Data:          types.JSON(attrs[a.Namespace]),
+		}
+
+		if err := dbAttributes.Insert(ctx, tx, boil.Infer()); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, id := range firmwareIDs {
+		m := models.ComponentFirmwareSetMap{FirmwareSetID: dbFirmwareSet.ID, FirmwareID: id}
+		if err := m.Insert(ctx, tx, boil.Infer()); err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.Revisions {
+		if err := RecordFirmwareSetRevision(ctx, tx, dbFirmwareSet.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	result.ID = dbFirmwareSet.ID
+
+	return result, nil
+}
+
+// FirmwareSetFirmware returns the firmware of the firmware set
+func FirmwareSetFirmware(ctx context.Context, exec boil.ContextExecutor, firmwareSetID string) ([]*models.ComponentFirmwareVersion, error) {
+	mapMods := []qm.QueryMod{
+		qm.Where("firmware_set_id=?", firmwareSetID),
+		qm.Load(models.ComponentFirmwareSetMapRels.Firmware),
+	}
+
+	// query firmware set references
+	dbFirmwareSetMap, err := models.ComponentFirmwareSetMaps(mapMods...).All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+
+	firmwares := []*models.ComponentFirmwareVersion{}
+
+	for _, m := range dbFirmwareSetMap {
+		if m.R != nil && m.R.Firmware != nil {
+			firmwares = append(firmwares, m.R.Firmware)
+		}
+	}
+
+	return firmwares, nil
+}
+
+// FirmwareSetConflict returns an error when two of the firmware are for the same vendor,
+// component and model
+func FirmwareSetConflict(firmwares []*models.ComponentFirmwareVersion) error {
+	seen := map[string]*models.ComponentFirmwareVersion{}
+
+	for _, f := range firmwares {
+		for _, model := range f.Model {
+			key := strings.ToLower(f.Vendor) + "/" + strings.ToLower(f.Component) + "/" + strings.ToLower(model)
+
+			other, exists := seen[key]
+			if exists && other.ID != f.ID {
+				return fmt.Errorf("%w: firmware '%s' (%s) and '%s' (%s) are both for the %s %s of model %s",
+					ErrFirmwareSetConflict, other.ID, other.Version, f.ID, f.Version, f.Vendor, f.Component, model)
+			}
+
+			seen[key] = f
+		}
+	}
+
+	return nil
+}
+
+// NewFirmwareSetDocument returns the document of the firmware set with the id and
+// checksum of each firmware, the attributes and firmware are sorted so documents of
+// the same set are identical
+func NewFirmwareSetDocument(dbFS *models.ComponentFirmwareSet, firmwares []*models.ComponentFirmwareVersion) (*FirmwareSetDocument, error) {
+	doc := &FirmwareSetDocument{
+		Name:     dbFS.Name,
+		Firmware: []FirmwareReference{},
+	}
+
+	if dbFS.R != nil {
+		for _, a := range dbFS.R.FirmwareSetAttributesFirmwareSets {
+			attr := FirmwareSetDocumentAttribute{Namespace: a.Namespace}
+
+			if err := json.Unmarshal(a.Data, &attr.Data); err != nil {
+				return nil, err
+			}
+
+			doc.Attributes = append(doc.Attributes, attr)
+		}
+	}
+
+	for _, f := range firmwares {
+		doc.Firmware = append(doc.Firmware, FirmwareReference{
+			Vendor:    f.Vendor,
+			Component: f.Component,
+			Version:   f.Version,
+			Filename:  f.Filename,
+			UUID:      f.ID,
+			Checksum:  f.Checksum,
+		})
+	}
+
+	doc.sort()
+
+	return doc, nil
+}
+
+// RecordFirmwareSetRevision stores the current document of the firmware set as a new
+// revision, nothing is stored when it's the same as the latest revision
+func RecordFirmwareSetRevision(ctx context.Context, exec boil.ContextExecutor, firmwareSetID string) error {
+	dbFirmwareSet, err := models.ComponentFirmwareSets(
+		models.ComponentFirmwareSetWhere.ID.EQ(firmwareSetID),
+		qm.Load(models.ComponentFirmwareSetRels.FirmwareSetAttributesFirmwareSets),
+	).One(ctx, exec)
+	if err != nil {
+		return err
+	}
+
+	firmwares, err := FirmwareSetFirmware(ctx, exec, firmwareSetID)
+	if err != nil {
+		return err
+	}
+
+	doc, err := NewFirmwareSetDocument(dbFirmwareSet, firmwares)
+	if err != nil {
+		return err
+	}
+
+	var next int64 = 1
+
+	latest, err := models.ComponentFirmwareSetRevisions(
+		models.ComponentFirmwareSetRevisionWhere.FirmwareSetID.EQ(firmwareSetID),
+		qm.OrderBy(models.ComponentFirmwareSetRevisionColumns.Revision+" DESC"),
+	).One(ctx, exec)
+
+	switch {
+	case err == nil:
+		latestDoc := &FirmwareSetDocument{}
+		if err := json.Unmarshal(latest.Document, latestDoc); err != nil {
+			return err
+		}
+
+		if latestDoc.equal(doc) {
+			return nil
+		}
+
+		next = latest.Revision + 1
+	case !errors.Is(err, sql.ErrNoRows):
+		return err
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+
+	v := models.ComponentFirmwareSetRevision{
+		FirmwareSetID: firmwareSetID,
+		Revision:      next,
+		Document:      types.JSON(data),
+	}
+
+	return v.Insert(ctx, exec, boil.Infer())
+}
+
+// validate checks the document has a name and firmware, and that the attribute
+// namespaces and firmware aren't repeated
+func (d *FirmwareSetDocument) validate() error {
+	if d.Name == "" {
+		return fmt.Errorf("%w: a name is required", ErrFirmwareSetDocument)
+	}
+
+	if len(d.Firmware) == 0 {
+		return fmt.Errorf("%w: one or more firmware are required", ErrFirmwareSetDocument)
+	}
+
+	namespaces := map[string]bool{}
+
+	for _, a := range d.Attributes {
+		if a.Namespace == "" {
+			return fmt.Errorf("%w: attributes require a namespace", ErrFirmwareSetDocument)
+		}
+
+		if namespaces[a.Namespace] {
+			return fmt.Errorf("%w: duplicate attributes namespace %q", ErrFirmwareSetDocument, a.Namespace)
+		}
+
+		namespaces[a.Namespace] = true
+	}
+
+	// firmware sets can only reference unique firmware versions based on the vendor, version, component attributes
+	unique := map[string]bool{}
+
+	for _, f := range d.Firmware {
+		key := strings.ToLower(f.Vendor) + strings.ToLower(f.Version) + strings.ToLower(f.Component)
+		if unique[key] {
+			return fmt.Errorf("%w: firmware %s repeats the vendor, version and component of another firmware", ErrFirmwareSetDocument, f)
+		}
+
+		unique[key] = true
+	}
+
+	return nil
+}
+
+// attributesJSON returns the data of each attributes namespace encoded as JSON
+func (d *FirmwareSetDocument) attributesJSON() (map[string][]byte, error) {
+	attrs := map[string][]byte{}
+
+	for _, a := range d.Attributes {
+		data, err := json.Marshal(a.Data)
+		if err != nil {
+			return nil, fmt.Errorf("%w: attributes namespace %q: %s", ErrFirmwareSetDocument, a.Namespace, err)
+		}
+
+		attrs[a.Namespace] = data
+	}
+
+	return attrs, nil
+}
+
+// sort orders the attributes by namespace and the firmware by reference
+func (d *FirmwareSetDocument) sort() {
+	sort.Slice(d.Attributes, func(i, j int) bool {
+		return d.Attributes[i].Namespace < d.Attributes[j].Namespace
+	})
+
+	sort.Slice(d.Firmware, func(i, j int) bool {
+		return d.Firmware[i].String() < d.Firmware[j].String()
+	})
+}
+
+// equal returns if both documents have the same name, attributes and firmware
+func (d *FirmwareSetDocument) equal(other *FirmwareSetDocument) bool {
+	d.sort()
+	other.sort()
+
+	a, errA := json.Marshal(d)
+	b, errB := json.Marshal(other)
+
+	return errA == nil && errB == nil && bytes.Equal(a, b)
+}
+
+// resolveFirmwareReferences returns the referenced firmware, an error lists the firmware
+// that doesn't exist
+func resolveFirmwareReferences(ctx context.Context, exec boil.ContextExecutor, refs []FirmwareReference) ([]*models.ComponentFirmwareVersion, error) {
+	firmwares := make([]*models.ComponentFirmwareVersion, 0, len(refs))
+	missing := []string{}
+
+	for _, ref := range refs {
+		fw, err := models.ComponentFirmwareVersions(
+			models.ComponentFirmwareVersionWhere.Vendor.EQ(ref.Vendor),
+			models.ComponentFirmwareVersionWhere.Component.EQ(ref.Component),
+			models.ComponentFirmwareVersionWhere.Version.EQ(ref.Version),
+			models.ComponentFirmwareVersionWhere.Filename.EQ(ref.Filename),
+		).One(ctx, exec)
+
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			missing = append(missing, ref.String())
+		case err != nil:
+			return nil, err
+		default:
+			firmwares = append(firmwares, fw)
+		}
+	}
+
+	if len(missing) != 0 {
+		return nil, fmt.Errorf("%w: firmware not found: %v", ErrFirmwareSetDocument, missing)
+	}
+
+	return firmwares, nil
+}
+
+// firmwareSetMatches returns if the firmware set has exactly the attributes and firmware
+func firmwareSetMatches(dbFirmwareSet *models.ComponentFirmwareSet, attrs map[string][]byte, firmwareIDs []string) bool {
+	if len(dbFirmwareSet.R.FirmwareSetAttributesFirmwareSets) != len(attrs) ||
+		len(dbFirmwareSet.R.FirmwareSetComponentFirmwareSetMaps) != len(firmwareIDs) {
+		return false
+	}
+
+	for _, a := range dbFirmwareSet.R.FirmwareSetAttributesFirmwareSets {
+		data, ok := attrs[a.Namespace]
+		if !ok || !SameJSON(a.Data, data) {
+			return false
+		}
+	}
+
+	ids := map[string]bool{}
+	for _, id := range firmwareIDs {
+		ids[id] = true
+	}
+
+	for _, m := range dbFirmwareSet.R.FirmwareSetComponentFirmwareSetMaps {
+		if !ids[m.FirmwareID] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// SameJSON returns if both values encode the same JSON document, ignoring formatting and key order
+func SameJSON(a, b []byte) bool {
+	var va, vb interface{}
+
+	if json.Unmarshal(a, &va) != nil || json.Unmarshal(b, &vb) != nil {
+		return bytes.Equal(a, b)
+	}
+
+	ca, errA := json.Marshal(va)
+	cb, errB := json.Marshal(vb)
+
+	return errA == nil && errB == nil && bytes.Equal(ca, cb)
+}
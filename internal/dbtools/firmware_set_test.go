@@ -0,0 +1,164 @@
+package dbtools_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.hollow.sh/serverservice/internal/dbtools"
+	"go.hollow.sh/serverservice/internal/models"
+)
+
+func TestImportFirmwareSetValidate(t *testing.T) {
+	bmc := dbtools.FirmwareReference{Vendor: "Dell", Component: "bmc", Version: "5.10.00.00", Filename: "bmc.EXE"}
+
+	testCases := []struct {
+		name     string
+		doc      dbtools.FirmwareSetDocument
+		errorMsg string
+	}{
+		{
+			"missing name",
+			dbtools.FirmwareSetDocument{Firmware: []dbtools.FirmwareReference{bmc}},
+			"a name is required",
+		},
+		{
+			"missing firmware",
+			dbtools.FirmwareSetDocument{Name: "r640"},
+			"one or more firmware are required",
+		},
+		{
+			"duplicate namespace",
+			dbtools.FirmwareSetDocument{
+				Name:       "r640",
+				Attributes: []dbtools.FirmwareSetDocumentAttribute{{Namespace: "labels"}, {Namespace: "labels"}},
+				Firmware:   []dbtools.FirmwareReference{bmc},
+			},
+			`duplicate attributes namespace "labels"`,
+		},
+		{
+			"duplicate firmware version",
+			dbtools.FirmwareSetDocument{
+				Name: "r640",
+				Firmware: []dbtools.FirmwareReference{
+					bmc,
+					{Vendor: "dell", Component: "BMC", Version: "5.10.00.00", Filename: "other.EXE"},
+				},
+			},
+			"repeats the vendor, version and component",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			// the document is validated before the database is used
+			_, err := dbtools.ImportFirmwareSet(context.TODO(), nil, &tt.doc, dbtools.FirmwareSetImportOptions{})
+			assert.ErrorIs(t, err, dbtools.ErrFirmwareSetDocument)
+			assert.ErrorContains(t, err, tt.errorMsg)
+		})
+	}
+}
+
+func TestNewFirmwareSetDocument(t *testing.T) {
+	bios := &models.ComponentFirmwareVersion{Vendor: "Dell", Component: "bios", Version: "2.4.4", Filename: "BIOS_2.4.4.EXE"}
+	bmc := &models.ComponentFirmwareVersion{Vendor: "Dell", Component: "bmc", Version: "5.10.00.00", Filename: "iDRAC_5.10.EXE"}
+	set := &models.ComponentFirmwareSet{Name: "r640"}
+
+	a, err := dbtools.NewFirmwareSetDocument(set, []*models.ComponentFirmwareVersion{bios, bmc})
+	require.NoError(t, err)
+
+	b, err := dbtools.NewFirmwareSetDocument(set, []*models.ComponentFirmwareVersion{bmc, bios})
+	require.NoError(t, err)
+
+	assert.Equal(t, a, b, "the order of the firmware doesn't matter")
+	assert.Equal(t, "Dell/bios/2.4.4/BIOS_2.4.4.EXE", a.Firmware[0].String())
+}
+
+func TestSameJSON(t *testing.T) {
+	assert.True(t, dbtools.SameJSON([]byte(`{"vendor": "dell", "model": "r640"}`), []byte(`{"model":"r640","vendor":"dell"}`)))
+	assert.False(t, dbtools.SameJSON([]byte(`{"vendor": "dell"}`), []byte(`{"vendor": "Dell"}`)))
+}
+
+func TestImportFirmwareSet(t *testing.T) {
+	ctx := context.TODO()
+	db := dbtools.DatabaseTest(t)
+
+	errDenied := errors.New("denied")
+	updates := 0
+
+	opts := dbtools.FirmwareSetImportOptions{
+		Update: func(existing *models.ComponentFirmwareSet) error {
+			updates++
+			return errDenied
+		},
+		Revisions: true,
+	}
+
+	doc := func() *dbtools.FirmwareSetDocument {
+		return &dbtools.FirmwareSetDocument{
+			Name: dbtools.FixtureFirmwareSetR640.Name,
+			Attributes: []dbtools.FirmwareSetDocumentAttribute{
+				{Namespace: dbtools.FixtureFirmwareSetR640Attribute.Namespace, Data: map[string]interface{}{"vendor": "dell", "model": "r640"}},
+			},
+			Firmware: []dbtools.FirmwareReference{
+				{
+					Vendor:    dbtools.FixtureDellR640BMC.Vendor,
+					Component: dbtools.FixtureDellR640BMC.Component,
+					Version:   dbtools.FixtureDellR640BMC.Version,
+					Filename:  dbtools.FixtureDellR640BMC.Filename,
+				},
+				{
+					Vendor:    dbtools.FixtureDellR640BIOS.Vendor,
+					Component: dbtools.FixtureDellR640BIOS.Component,
+					Version:   dbtools.FixtureDellR640BIOS.Version,
+					Filename:  dbtools.FixtureDellR640BIOS.Filename,
+				},
+			},
+		}
+	}
+
+	result, err := dbtools.ImportFirmwareSet(ctx, db, doc(), opts)
+	require.NoError(t, err)
+	assert.Equal(t, dbtools.FirmwareSetUnchanged, result.Status)
+	assert.Equal(t, 0, updates, "a set already matching the document isn't changed")
+
+	changed := doc()
+	changed.Firmware = changed.Firmware[:1]
+
+	_, err = dbtools.ImportFirmwareSet(ctx, db, changed, opts)
+	assert.ErrorIs(t, err, errDenied)
+	assert.Equal(t, 1, updates)
+
+	firmwares, err := dbtools.FirmwareSetFirmware(ctx, db, dbtools.FixtureFirmwareSetR640.ID)
+	require.NoError(t, err)
+	assert.Len(t, firmwares, 2, "the set isn't changed when the update is refused")
+
+	created := doc()
+	created.Name = "r640-imported"
+
+	result, err = dbtools.ImportFirmwareSet(ctx, db, created, opts)
+	require.NoError(t, err)
+	assert.Equal(t, dbtools.FirmwareSetCreated, result.Status)
+	assert.Equal(t, 1, updates, "creating a set isn't an update")
+
+	revisions, err := models.ComponentFirmwareSetRevisions(
+		models.ComponentFirmwareSetRevisionWhere.FirmwareSetID.EQ(result.ID),
+	).Count(ctx, db)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), revisions)
+
+	opts.Revisions = false
+	created.Name = "r640-without-revisions"
+
+	result, err = dbtools.ImportFirmwareSet(ctx, db, created, opts)
+	require.NoError(t, err)
+
+	revisions, err = models.ComponentFirmwareSetRevisions(
+		models.ComponentFirmwareSetRevisionWhere.FirmwareSetID.EQ(result.ID),
+	).Count(ctx, db)
+	require.NoError(t, err)
+	assert.Zero(t, revisions, "no revision is recorded when revisions are off")
+}
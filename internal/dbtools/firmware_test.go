@@ -0,0 +1,276 @@
+package dbtools_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/types"
+
+	"go.hollow.sh/serverservice/internal/dbtools"
+	"go.hollow.sh/serverservice/internal/fwverify"
+	"go.hollow.sh/serverservice/internal/fwversion"
+	"go.hollow.sh/serverservice/internal/models"
+)
+
+func TestReindexFirmwareVersions(t *testing.T) {
+	ctx := context.TODO()
+	db := dbtools.DatabaseTest(t)
+
+	_, err := models.ComponentFirmwareVersions().UpdateAll(ctx, db, models.M{"version_sort_key": nil})
+	require.NoError(t, err)
+
+	updated, err := dbtools.ReindexFirmwareVersions(ctx, db)
+	require.NoError(t, err)
+	assert.Equal(t, 6, updated)
+
+	fw, err := models.FindComponentFirmwareVersion(ctx, db, dbtools.FixtureDellR6515BIOS.ID)
+	require.NoError(t, err)
+	assert.Equal(t, null.StringFrom(fwversion.SortKey("2.6.6")), fw.VersionSortKey)
+
+	updated, err = dbtools.ReindexFirmwareVersions(ctx, db)
+	require.NoError(t, err)
+	assert.Equal(t, 0, updated)
+}
+
+func TestIndexMissingFirmwareVersions(t *testing.T) {
+	ctx := context.TODO()
+	db := dbtools.DatabaseTest(t)
+
+	_, err := models.ComponentFirmwareVersions(
+		models.ComponentFirmwareVersionWhere.ID.EQ(dbtools.FixtureDellR6515BIOS.ID),
+	).UpdateAll(ctx, db, models.M{"version_sort_key": nil})
+	require.NoError(t, err)
+
+	_, err = models.ComponentFirmwareVersions(
+		models.ComponentFirmwareVersionWhere.ID.EQ(dbtools.FixtureDellR640BIOS.ID),
+	).UpdateAll(ctx, db, models.M{"version_sort_key": "outdated"})
+	require.NoError(t, err)
+
+	updated, err := dbtools.IndexMissingFirmwareVersions(ctx, db)
+	require.NoError(t, err)
+	assert.Equal(t, 1, updated, "only the missing key is computed")
+
+	fw, err := models.FindComponentFirmwareVersion(ctx, db, dbtools.FixtureDellR6515BIOS.ID)
+	require.NoError(t, err)
+	assert.Equal(t, null.StringFrom(fwversion.SortKey("2.6.6")), fw.VersionSortKey)
+
+	fw, err = models.FindComponentFirmwareVersion(ctx, db, dbtools.FixtureDellR640BIOS.ID)
+	require.NoError(t, err)
+	assert.Equal(t, null.StringFrom("outdated"), fw.VersionSortKey)
+}
+
+func TestImportFirmware(t *testing.T) {
+	ctx := context.TODO()
+	db := dbtools.DatabaseTest(t)
+
+	catalog := func() []*models.ComponentFirmwareVersion {
+		return []*models.ComponentFirmwareVersion{
+			{
+				Vendor:    dbtools.FixtureDellR6515BIOS.Vendor,
+				Model:     types.StringArray{"R6515", "R7515"},
+				Component: dbtools.FixtureDellR6515BIOS.Component,
+				Filename:  dbtools.FixtureDellR6515BIOS.Filename,
+				Version:   dbtools.FixtureDellR6515BIOS.Version,
+				Checksum:  dbtools.FixtureDellR6515BIOS.Checksum,
+			},
+			{
+				// the vendor is matched ignoring case
+				Vendor:    strings.ToLower(dbtools.FixtureDellR640BMC.Vendor),
+				Model:     dbtools.FixtureDellR640BMC.Model,
+				Component: dbtools.FixtureDellR640BMC.Component,
+				Filename:  dbtools.FixtureDellR640BMC.Filename,
+				Version:   dbtools.FixtureDellR640BMC.Version,
+			},
+			{
+				Vendor:        "Dell",
+				Model:         types.StringArray{"R6515"},
+				Component:     "bios",
+				Filename:      "BIOS_C4FT0_WN64_2.14.1.EXE",
+				Version:       "2.14.1",
+				Checksum:      "abc",
+				UpstreamURL:   "https://vendor.com/BIOS_C4FT0_WN64_2.14.1.EXE",
+				RepositoryURL: "https://mirror.example.com/BIOS_C4FT0_WN64_2.14.1.EXE",
+			},
+		}
+	}
+
+	t.Run("dry run doesn't change anything", func(t *testing.T) {
+		stats, err := dbtools.ImportFirmware(ctx, db, catalog(), dbtools.FirmwareImportOptions{DryRun: true})
+		require.NoError(t, err)
+		assert.Equal(t, dbtools.FirmwareImportStats{Added: 1, Changed: 1, Skipped: 1}, stats)
+
+		count, err := models.ComponentFirmwareVersions().Count(ctx, db)
+		require.NoError(t, err)
+		assert.EqualValues(t, 6, count)
+	})
+
+	t.Run("adds and changes firmware", func(t *testing.T) {
+		statuses := map[string]string{}
+
+		stats, err := dbtools.ImportFirmware(ctx, db, catalog(), dbtools.FirmwareImportOptions{
+			Report: func(f *models.ComponentFirmwareVersion, status string) { statuses[f.Version] = status },
+		})
+		require.NoError(t, err)
+		assert.Equal(t, dbtools.FirmwareImportStats{Added: 1, Changed: 1, Skipped: 1}, stats)
+		assert.Equal(t, map[string]string{
+			"2.6.6":      dbtools.FirmwareChanged,
+			"5.10.00.00": dbtools.FirmwareSkipped,
+			"2.14.1":     dbtools.FirmwareAdded,
+		}, statuses)
+
+		bios, err := models.FindComponentFirmwareVersion(ctx, db, dbtools.FixtureDellR6515BIOS.ID)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"R6515", "R7515"}, bios.Model)
+		assert.Equal(t, dbtools.FixtureDellR6515BIOS.RepositoryURL, bios.RepositoryURL)
+
+		added, err := models.ComponentFirmwareVersions(
+			models.ComponentFirmwareVersionWhere.Version.EQ("2.14.1"),
+			qm.Select(models.ComponentFirmwareVersionColumns.VersionSortKey),
+		).One(ctx, db)
+		require.NoError(t, err)
+		assert.Equal(t, null.StringFrom(fwversion.SortKey("2.14.1")), added.VersionSortKey)
+	})
+
+	t.Run("skips firmware that is already imported", func(t *testing.T) {
+		firmware := catalog()
+
+		stats, err := dbtools.ImportFirmware(ctx, db, firmware, dbtools.FirmwareImportOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, dbtools.FirmwareImportStats{Skipped: 3}, stats)
+		assert.Equal(t, strings.ToLower(dbtools.FixtureDellR640BMC.Vendor), firmware[1].Vendor, "the firmware passed in isn't changed")
+		assert.Empty(t, firmware[1].ID)
+	})
+
+	t.Run("new firmware needs a checksum and URLs", func(t *testing.T) {
+		_, err := dbtools.ImportFirmware(ctx, db, []*models.ComponentFirmwareVersion{
+			{Vendor: "Dell", Component: "bios", Filename: "BIOS_C4FT0_WN64_2.15.0.EXE", Version: "2.15.0", Checksum: "abc"},
+		}, dbtools.FirmwareImportOptions{})
+		assert.ErrorIs(t, err, dbtools.ErrFirmwareIncomplete)
+	})
+
+	t.Run("vendors differing by case are ambiguous", func(t *testing.T) {
+		dup := *dbtools.FixtureDellR640BMC
+		dup.ID = ""
+		dup.Vendor = strings.ToUpper(dup.Vendor)
+		require.NoError(t, dup.Insert(ctx, db, boil.Infer()))
+
+		_, err := dbtools.ImportFirmware(ctx, db, []*models.ComponentFirmwareVersion{
+			{
+				Vendor:    dbtools.FixtureDellR640BMC.Vendor,
+				Component: dbtools.FixtureDellR640BMC.Component,
+				Filename:  dbtools.FixtureDellR640BMC.Filename,
+				Version:   dbtools.FixtureDellR640BMC.Version,
+			},
+		}, dbtools.FirmwareImportOptions{})
+		assert.ErrorIs(t, err, dbtools.ErrFirmwareAmbiguousVendor)
+	})
+}
+
+func TestVerifyFirmware(t *testing.T) {
+	ctx := context.TODO()
+	db := dbtools.DatabaseTest(t)
+
+	artifact := []byte("bios 2.4.4")
+	sum := sha256.Sum256(artifact)
+
+	dir := t.TempDir()
+	biosPath := filepath.Join(dir, "firmware", "dell", "r640", "bios", "bios-2.4.4.EXE")
+	require.NoError(t, os.MkdirAll(filepath.Dir(biosPath), 0o755))
+	require.NoError(t, os.WriteFile(biosPath, artifact, 0o600))
+
+	bios, err := models.FindComponentFirmwareVersion(ctx, db, dbtools.FixtureDellR640BIOS.ID)
+	require.NoError(t, err)
+
+	bios.Checksum = "sha256:" + hex.EncodeToString(sum[:])
+	_, err = bios.Update(ctx, db, boil.Infer())
+	require.NoError(t, err)
+
+	verifier, err := fwverify.New("file://"+filepath.ToSlash(dir), nil)
+	require.NoError(t, err)
+
+	started := time.Now()
+
+	stats, err := dbtools.VerifyFirmware(ctx, db, verifier, dbtools.FirmwareVerifyOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, dbtools.FirmwareVerifyStats{Verified: 1, Failed: 5}, stats)
+
+	bios, err = models.FindComponentFirmwareVersion(ctx, db, dbtools.FixtureDellR640BIOS.ID)
+	require.NoError(t, err)
+	assert.Equal(t, null.StringFrom(fwverify.StatusVerified), bios.VerificationStatus)
+	assert.True(t, bios.VerifiedAt.Valid)
+
+	bmc, err := models.FindComponentFirmwareVersion(ctx, db, dbtools.FixtureDellR640BMC.ID)
+	require.NoError(t, err)
+	assert.Equal(t, null.StringFrom(fwverify.StatusFailed), bmc.VerificationStatus)
+
+	// the artifact changed in the mirror
+	require.NoError(t, os.WriteFile(biosPath, []byte("tampered"), 0o600))
+
+	stats, err = dbtools.VerifyFirmware(ctx, db, verifier, dbtools.FirmwareVerifyOptions{VerifiedBefore: started})
+	require.NoError(t, err)
+	assert.Equal(t, dbtools.FirmwareVerifyStats{}, stats, "firmware verified since is skipped")
+
+	stats, err = dbtools.VerifyFirmware(ctx, db, verifier, dbtools.FirmwareVerifyOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.Mismatched)
+}
+
+func TestVerifyFirmwareChanged(t *testing.T) {
+	ctx := context.TODO()
+	db := dbtools.DatabaseTest(t)
+
+	artifact := []byte("bios 2.4.4")
+	sum := sha256.Sum256(artifact)
+
+	bios, err := models.FindComponentFirmwareVersion(ctx, db, dbtools.FixtureDellR640BIOS.ID)
+	require.NoError(t, err)
+
+	bios.Checksum = "sha256:" + hex.EncodeToString(sum[:])
+	_, err = bios.Update(ctx, db, boil.Infer())
+	require.NoError(t, err)
+
+	// only the bios is due for verification
+	_, err = models.ComponentFirmwareVersions(
+		models.ComponentFirmwareVersionWhere.ID.NEQ(bios.ID),
+	).UpdateAll(ctx, db, models.M{models.ComponentFirmwareVersionColumns.VerifiedAt: time.Now()})
+	require.NoError(t, err)
+
+	// the firmware is edited while its artifact is read
+	mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := models.ComponentFirmwareVersions(
+			models.ComponentFirmwareVersionWhere.ID.EQ(bios.ID),
+		).UpdateAll(ctx, db, models.M{
+			models.ComponentFirmwareVersionColumns.UpdatedAt:          time.Now(),
+			models.ComponentFirmwareVersionColumns.VerificationStatus: nil,
+			models.ComponentFirmwareVersionColumns.VerifiedAt:         nil,
+		})
+		assert.NoError(t, err)
+
+		_, _ = w.Write(artifact)
+	}))
+	defer mirror.Close()
+
+	verifier, err := fwverify.New(mirror.URL, nil)
+	require.NoError(t, err)
+
+	stats, err := dbtools.VerifyFirmware(ctx, db, verifier, dbtools.FirmwareVerifyOptions{VerifiedBefore: time.Now().Add(-time.Minute)})
+	require.NoError(t, err)
+	assert.Equal(t, dbtools.FirmwareVerifyStats{Skipped: 1}, stats)
+
+	bios, err = models.FindComponentFirmwareVersion(ctx, db, dbtools.FixtureDellR640BIOS.ID)
+	require.NoError(t, err)
+	assert.False(t, bios.VerificationStatus.Valid, "the reset by the edit is kept")
+}
@@ -164,9 +164,7 @@ func setupNemo(ctx context.Context, db *sqlx.DB, t *testing.T) error {
 		return err
 	}
 
-	keeper := TestSecretKeeper(t)
-
-	value, err := Encrypt(ctx, keeper, "super-secret-bmc-password")
+	value, err := Encrypt(ctx, TestKeyring(t), "super-secret-bmc-password")
 	if err != nil {
 		return err
 	}
@@ -180,6 +178,14 @@ func setupNemo(ctx context.Context, db *sqlx.DB, t *testing.T) error {
 		return err
 	}
 
+	if err := FixtureNemoBMCSecret.AddServerCredentialVersions(ctx, db, true, &models.ServerCredentialVersion{
+		Version:   1,
+		Password:  value,
+		CreatedAt: FixtureNemoBMCSecret.UpdatedAt,
+	}); err != nil {
+		return err
+	}
+
 	FixtureNemoMetadata = &models.Attribute{
 		Namespace: FixtureNamespaceMetadata,
 		Data:      types.JSON([]byte(`{"age":6,"location":"Fishbowl"}`)),
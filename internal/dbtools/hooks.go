@@ -4,15 +4,22 @@ import (
 	"context"
 
 	"github.com/gosimple/slug"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 
+	"go.hollow.sh/serverservice/internal/fwversion"
 	"go.hollow.sh/serverservice/internal/models"
 )
 
 // RegisterHooks adds any hooks that are configured to the models library
 func RegisterHooks() {
 	models.AddServerComponentTypeHook(boil.BeforeInsertHook, setServerComponentTypeSlug)
+	models.AddServerComponentTypeHook(boil.BeforeUpdateHook, setServerComponentTypeSlug)
 	models.AddServerCredentialTypeHook(boil.BeforeInsertHook, setServerCredentialTypeSlug)
+	models.AddServerCredentialTypeHook(boil.BeforeUpdateHook, setServerCredentialTypeSlug)
+	models.AddComponentFirmwareVersionHook(boil.BeforeInsertHook, setFirmwareVersionSortKey)
+	models.AddComponentFirmwareVersionHook(boil.BeforeUpdateHook, setFirmwareVersionSortKey)
+	models.AddComponentFirmwareVersionHook(boil.BeforeUpsertHook, setFirmwareVersionSortKey)
 }
 
 func setServerComponentTypeSlug(_ context.Context, _ boil.ContextExecutor, t *models.ServerComponentType) error {
@@ -30,3 +37,11 @@ func setServerCredentialTypeSlug(_ context.Context, _ boil.ContextExecutor, t *m
 
 	return nil
 }
+
+// setFirmwareVersionSortKey keeps the sort key used to compare firmware versions in
+// queries in step with the version
+func setFirmwareVersionSortKey(_ context.Context, _ boil.ContextExecutor, f *models.ComponentFirmwareVersion) error {
+	f.VersionSortKey = null.StringFrom(fwversion.SortKey(f.Version))
+
+	return nil
+}
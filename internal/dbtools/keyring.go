@@ -0,0 +1,151 @@
+package dbtools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gocloud.dev/secrets"
+)
+
+// keyIDSeparator separates the key id from the base64 encoded ciphertext
+const keyIDSeparator = ":"
+
+// keyringCheckValue is the value encrypted and decrypted to check the keyring works
+const keyringCheckValue = "serverservice-keyring-check"
+
+var (
+	// ErrUnknownKeyID is returned when a value was encrypted with a key that isn't in the keyring
+	ErrUnknownKeyID = errors.New("no decryption key found for key id")
+	// ErrInvalidKeyID is returned when a key id can't be used to tag ciphertexts
+	ErrInvalidKeyID = errors.New("invalid key id")
+	// ErrDuplicateKeyID is returned when a key id is added to the keyring more than once
+	ErrDuplicateKeyID = errors.New("duplicate key id")
+	// ErrKeyringCheck is returned when a value doesn't decrypt to the value that was encrypted
+	ErrKeyringCheck = errors.New("keyring check value mismatch")
+)
+
+// Keyring holds the keeper used to encrypt new values along with any keepers
+// that are still needed to decrypt values encrypted with a previous key.
+type Keyring struct {
+	primaryID string
+	keepers   map[string]*secrets.Keeper
+}
+
+// NewKeyring returns a keyring that encrypts with the given keeper. Values
+// encrypted by the keyring are tagged with primaryID. An empty primaryID leaves
+// the values untagged, which is how values were stored before keys had ids.
+func NewKeyring(primaryID string, primary *secrets.Keeper) (*Keyring, error) {
+	k := &Keyring{keepers: map[string]*secrets.Keeper{}}
+
+	if err := k.AddDecryptionKey(primaryID, primary); err != nil {
+		return nil, err
+	}
+
+	k.primaryID = primaryID
+
+	return k, nil
+}
+
+// OpenKeyring opens the primary keeper from primaryURL and a keeper for each of
+// the decryption keys. Decryption keys are given as "id=url", a url without an id
+// is used for values that aren't tagged with a key id.
+func OpenKeyring(ctx context.Context, primaryID, primaryURL string, decryptionKeys []string) (*Keyring, error) {
+	primary, err := secrets.OpenKeeper(ctx, primaryURL)
+	if err != nil {
+		return nil, err
+	}
+
+	k, err := NewKeyring(primaryID, primary)
+	if err != nil {
+		primary.Close()
+		return nil, err
+	}
+
+	for _, key := range decryptionKeys {
+		id, url := "", key
+
+		// ids can't contain ':' so an '=' before the scheme separates the id
+		if i := strings.Index(key, "="); i != -1 && !strings.Contains(key[:i], keyIDSeparator) {
+			id, url = key[:i], key[i+1:]
+		}
+
+		keeper, err := secrets.OpenKeeper(ctx, url)
+		if err != nil {
+			k.Close()
+			return nil, err
+		}
+
+		if err := k.AddDecryptionKey(id, keeper); err != nil {
+			keeper.Close()
+			k.Close()
+
+			return nil, err
+		}
+	}
+
+	return k, nil
+}
+
+// AddDecryptionKey adds a keeper that is used to decrypt values tagged with id
+func (k *Keyring) AddDecryptionKey(id string, keeper *secrets.Keeper) error {
+	if strings.Contains(id, keyIDSeparator) {
+		return fmt.Errorf("%w: %q can't contain %q", ErrInvalidKeyID, id, keyIDSeparator)
+	}
+
+	if _, ok := k.keepers[id]; ok {
+		return fmt.Errorf("%w: %q", ErrDuplicateKeyID, id)
+	}
+
+	k.keepers[id] = keeper
+
+	return nil
+}
+
+// PrimaryKeyID returns the id of the key new values are encrypted with
+func (k *Keyring) PrimaryKeyID() string {
+	return k.primaryID
+}
+
+// NeedsRekey returns true when the value wasn't encrypted with the primary key
+func (k *Keyring) NeedsRekey(value string) bool {
+	return KeyID(value) != k.primaryID
+}
+
+// Check encrypts a test value with the primary key and ensures it decrypts to the
+// same value, this ensures the keeper backing the primary key is reachable and usable
+func (k *Keyring) Check(ctx context.Context) error {
+	value, err := Encrypt(ctx, k, keyringCheckValue)
+	if err != nil {
+		return err
+	}
+
+	plain, err := Decrypt(ctx, k, value)
+	if err != nil {
+		return err
+	}
+
+	if plain != keyringCheckValue {
+		return ErrKeyringCheck
+	}
+
+	return nil
+}
+
+// Close closes all the keepers in the keyring
+func (k *Keyring) Close() error {
+	var err error
+
+	for _, keeper := range k.keepers {
+		if cerr := keeper.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}
+
+	return err
+}
+
+func (k *Keyring) primary() *secrets.Keeper {
+	return k.keepers[k.primaryID]
+}
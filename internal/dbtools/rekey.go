@@ -0,0 +1,241 @@
+package dbtools
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"go.hollow.sh/serverservice/internal/models"
+)
+
+// DefaultRekeyBatchSize is the number of rows re-encrypted per transaction by default
+const DefaultRekeyBatchSize = 100
+
+// RekeyOptions controls how stored credentials are re-encrypted
+type RekeyOptions struct {
+	// BatchSize is the number of rows loaded and updated per transaction
+	BatchSize int
+	// DryRun decrypts every value that needs a new key without writing anything
+	DryRun bool
+	// Progress is called after each batch with the totals so far
+	Progress func(table string, stats RekeyStats)
+}
+
+// RekeyStats counts the rows processed by Rekey
+type RekeyStats struct {
+	Scanned int
+	Rekeyed int
+	Skipped int
+}
+
+// Rekey re-encrypts every stored credential, including the credential history,
+// with the primary key of the keyring. Values already encrypted with the primary
+// key are skipped so an interrupted run can be restarted.
+func Rekey(ctx context.Context, db *sqlx.DB, keyring *Keyring, opts RekeyOptions) (RekeyStats, error) {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultRekeyBatchSize
+	}
+
+	var total RekeyStats
+
+	credStats, err := rekeyServerCredentials(ctx, db, keyring, opts)
+	total.add(credStats)
+
+	if err != nil {
+		return total, err
+	}
+
+	versionStats, err := rekeyServerCredentialVersions(ctx, db, keyring, opts)
+	total.add(versionStats)
+
+	return total, err
+}
+
+func rekeyServerCredentials(ctx context.Context, db *sqlx.DB, keyring *Keyring, opts RekeyOptions) (RekeyStats, error) {
+	var (
+		stats  RekeyStats
+		lastID string
+	)
+
+	for {
+		n, err := rekeyServerCredentialsBatch(ctx, db, keyring, opts, &lastID, &stats)
+		if err != nil || n == 0 {
+			return stats, err
+		}
+
+		if opts.Progress != nil {
+			opts.Progress(models.TableNames.ServerCredentials, stats)
+		}
+	}
+}
+
+// rekeyServerCredentialsBatch re-encrypts the credentials after lastID in one transaction.
+// The batch is locked as it's read so a credential set meanwhile isn't overwritten with the
+// previous value. It returns the number of credentials read.
+func rekeyServerCredentialsBatch(ctx context.Context, db *sqlx.DB, keyring *Keyring, opts RekeyOptions, lastID *string, stats *RekeyStats) (int, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	// nolint:errcheck // rollback is a no-op once the transaction is committed
+	defer tx.Rollback()
+
+	mods := []qm.QueryMod{
+		qm.OrderBy(models.ServerCredentialColumns.ID),
+		qm.Limit(opts.BatchSize),
+		qm.For("UPDATE"),
+	}
+
+	if *lastID != "" {
+		mods = append(mods, models.ServerCredentialWhere.ID.GT(*lastID))
+	}
+
+	batch, err := models.ServerCredentials(mods...).All(ctx, tx)
+	if err != nil || len(batch) == 0 {
+		return 0, err
+	}
+
+	for _, cred := range batch {
+		stats.Scanned++
+
+		value, rekeyed, err := rekeyValue(ctx, keyring, cred.Password)
+		if err != nil {
+			return 0, err
+		}
+
+		if !rekeyed {
+			stats.Skipped++
+			continue
+		}
+
+		stats.Rekeyed++
+
+		if opts.DryRun {
+			continue
+		}
+
+		cred.Password = value
+
+		// only the ciphertext changes, keep updated_at as the time the credential was set
+		if _, err := cred.Update(ctx, tx, boil.Whitelist(models.ServerCredentialColumns.Password)); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	*lastID = batch[len(batch)-1].ID
+
+	return len(batch), nil
+}
+
+func rekeyServerCredentialVersions(ctx context.Context, db *sqlx.DB, keyring *Keyring, opts RekeyOptions) (RekeyStats, error) {
+	var (
+		stats  RekeyStats
+		lastID string
+	)
+
+	for {
+		n, err := rekeyServerCredentialVersionsBatch(ctx, db, keyring, opts, &lastID, &stats)
+		if err != nil || n == 0 {
+			return stats, err
+		}
+
+		if opts.Progress != nil {
+			opts.Progress(models.TableNames.ServerCredentialVersions, stats)
+		}
+	}
+}
+
+// rekeyServerCredentialVersionsBatch re-encrypts the credential versions after lastID in
+// one transaction, locking them as they're read. It returns the number of versions read.
+func rekeyServerCredentialVersionsBatch(ctx context.Context, db *sqlx.DB, keyring *Keyring, opts RekeyOptions, lastID *string, stats *RekeyStats) (int, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	// nolint:errcheck // rollback is a no-op once the transaction is committed
+	defer tx.Rollback()
+
+	mods := []qm.QueryMod{
+		qm.OrderBy(models.ServerCredentialVersionColumns.ID),
+		qm.Limit(opts.BatchSize),
+		qm.For("UPDATE"),
+	}
+
+	if *lastID != "" {
+		mods = append(mods, models.ServerCredentialVersionWhere.ID.GT(*lastID))
+	}
+
+	batch, err := models.ServerCredentialVersions(mods...).All(ctx, tx)
+	if err != nil || len(batch) == 0 {
+		return 0, err
+	}
+
+	for _, version := range batch {
+		stats.Scanned++
+
+		value, rekeyed, err := rekeyValue(ctx, keyring, version.Password)
+		if err != nil {
+			return 0, err
+		}
+
+		if !rekeyed {
+			stats.Skipped++
+			continue
+		}
+
+		stats.Rekeyed++
+
+		if opts.DryRun {
+			continue
+		}
+
+		version.Password = value
+
+		if _, err := version.Update(ctx, tx, boil.Whitelist(models.ServerCredentialVersionColumns.Password)); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	*lastID = batch[len(batch)-1].ID
+
+	return len(batch), nil
+}
+
+// rekeyValue returns the value encrypted with the primary key and true if it was
+// encrypted with another key. Values that need a new key are always decrypted so
+// a dry run catches values the keyring can't decrypt.
+func rekeyValue(ctx context.Context, keyring *Keyring, value string) (string, bool, error) {
+	if !keyring.NeedsRekey(value) {
+		return value, false, nil
+	}
+
+	plain, err := Decrypt(ctx, keyring, value)
+	if err != nil {
+		return "", false, err
+	}
+
+	encrypted, err := Encrypt(ctx, keyring, plain)
+	if err != nil {
+		return "", false, err
+	}
+
+	return encrypted, true, nil
+}
+
+func (s *RekeyStats) add(o RekeyStats) {
+	s.Scanned += o.Scanned
+	s.Rekeyed += o.Rekeyed
+	s.Skipped += o.Skipped
+}
@@ -0,0 +1,62 @@
+package dbtools_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gocloud.dev/secrets"
+
+	"go.hollow.sh/serverservice/internal/dbtools"
+	"go.hollow.sh/serverservice/internal/models"
+)
+
+func TestRekey(t *testing.T) {
+	ctx := context.TODO()
+	db := dbtools.DatabaseTest(t)
+
+	newKeeper, err := secrets.OpenKeeper(ctx, "base64key://")
+	require.NoError(t, err)
+
+	keyring, err := dbtools.NewKeyring("new-key", newKeeper)
+	require.NoError(t, err)
+	require.NoError(t, keyring.AddDecryptionKey("", dbtools.TestSecretKeeper(t)))
+
+	t.Run("dry run doesn't change anything", func(t *testing.T) {
+		stats, err := dbtools.Rekey(ctx, db, keyring, dbtools.RekeyOptions{DryRun: true})
+		require.NoError(t, err)
+		assert.Equal(t, 2, stats.Rekeyed)
+
+		cred, err := models.FindServerCredential(ctx, db, dbtools.FixtureNemoBMCSecret.ID)
+		require.NoError(t, err)
+		assert.Equal(t, dbtools.FixtureNemoBMCSecret.Password, cred.Password)
+	})
+
+	t.Run("re-encrypts with the primary key", func(t *testing.T) {
+		var batches int
+
+		stats, err := dbtools.Rekey(ctx, db, keyring, dbtools.RekeyOptions{
+			BatchSize: 1,
+			Progress:  func(string, dbtools.RekeyStats) { batches++ },
+		})
+		require.NoError(t, err)
+		assert.Equal(t, dbtools.RekeyStats{Scanned: 2, Rekeyed: 2}, stats)
+		assert.Equal(t, 2, batches)
+
+		cred, err := models.FindServerCredential(ctx, db, dbtools.FixtureNemoBMCSecret.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "new-key", dbtools.KeyID(cred.Password))
+		assert.Equal(t, dbtools.FixtureNemoBMCSecret.UpdatedAt.UTC(), cred.UpdatedAt.UTC())
+
+		decrypted, err := dbtools.Decrypt(ctx, keyring, cred.Password)
+		require.NoError(t, err)
+		assert.Equal(t, "super-secret-bmc-password", decrypted)
+	})
+
+	t.Run("skips values already using the primary key", func(t *testing.T) {
+		stats, err := dbtools.Rekey(ctx, db, keyring, dbtools.RekeyOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, dbtools.RekeyStats{Scanned: 2, Skipped: 2}, stats)
+	})
+}
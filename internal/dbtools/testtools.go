@@ -26,6 +26,7 @@ import (
 var TestDBURI = os.Getenv("SERVERSERVICE_CRDB_URI")
 var testDB *sqlx.DB
 var testKeeper *secrets.Keeper
+var testKeyring *Keyring
 
 func testDatastore(t *testing.T) error {
 	// don't setup the datastore if we already have one
@@ -65,6 +66,20 @@ func TestSecretKeeper(t *testing.T) *secrets.Keeper {
 	return keeper
 }
 
+// TestKeyring will return a keyring using the TestSecretKeeper as the primary key
+func TestKeyring(t *testing.T) *Keyring {
+	if testKeyring != nil {
+		return testKeyring
+	}
+
+	keyring, err := NewKeyring("", TestSecretKeeper(t))
+	require.NoError(t, err)
+
+	testKeyring = keyring
+
+	return keyring
+}
+
 // DatabaseTest allows you to run tests that interact with the database
 func DatabaseTest(t *testing.T) *sqlx.DB {
 	RegisterHooks()
@@ -94,6 +109,8 @@ func cleanDB() {
 	models.VersionedAttributes().DeleteAll(ctx, testDB)
 	models.ServerComponents().DeleteAll(ctx, testDB)
 	models.ServerComponentTypes().DeleteAll(ctx, testDB)
+	models.CredentialAccessEvents().DeleteAll(ctx, testDB)
+	models.ServerCredentialVersions().DeleteAll(ctx, testDB)
 	models.ServerCredentials().DeleteAll(ctx, testDB)
 	models.Servers(qm.WithDeleted()).DeleteAll(ctx, testDB, true)
 	models.ComponentFirmwareVersions().DeleteAll(ctx, testDB)
@@ -0,0 +1,195 @@
+// Package filekeyring provides a secrets keeper that reads a versioned set of keys
+// from a local file. It is registered for the "filekeyring" URL scheme.
+//
+// The keyring file is JSON and lists the keys by version along with the version
+// used to encrypt new values:
+//
+//	{
+//	  "primary": 2,
+//	  "keys": [
+//	    {"version": 1, "key": "<32 byte base64 encoded key>"},
+//	    {"version": 2, "key": "<32 byte base64 encoded key>"}
+//	  ]
+//	}
+//
+// Ciphertexts are prefixed with the version of the key that encrypted them so values
+// encrypted with an older key can still be decrypted while it is listed in the file.
+package filekeyring
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"gocloud.dev/gcerrors"
+	"gocloud.dev/secrets"
+	"gocloud.dev/secrets/localsecrets"
+)
+
+// Scheme is the URL scheme filekeyring registers its URLOpener under on secrets.DefaultMux
+const Scheme = "filekeyring"
+
+// versionSize is the number of bytes used to store the key version in front of a ciphertext
+const versionSize = 4
+
+var (
+	// ErrInvalidKeyring is returned when the keyring file can't be used
+	ErrInvalidKeyring = errors.New("invalid keyring file")
+	// ErrUnknownVersion is returned when a value was encrypted with a key that isn't in the keyring
+	ErrUnknownVersion = errors.New("no key found for version")
+	// ErrInvalidCiphertext is returned when a value is too short to contain a key version
+	ErrInvalidCiphertext = errors.New("invalid ciphertext")
+)
+
+func init() {
+	secrets.DefaultURLMux().RegisterKeeper(Scheme, &URLOpener{})
+}
+
+// File is the format of a keyring file
+type File struct {
+	Primary uint32 `json:"primary"`
+	Keys    []Key  `json:"keys"`
+}
+
+// Key is a version of a key in the keyring file
+type Key struct {
+	Version uint32 `json:"version"`
+	// Key is the 32 byte key, base64 encoded
+	Key string `json:"key"`
+}
+
+// URLOpener opens filekeyring URLs like "filekeyring:///etc/serverservice/keyring.json".
+// Relative paths are given as "filekeyring://keyring.json". No query parameters are supported.
+type URLOpener struct{}
+
+// OpenKeeperURL opens a keeper for the keyring file in the URL
+func (o *URLOpener) OpenKeeperURL(ctx context.Context, u *url.URL) (*secrets.Keeper, error) {
+	for param := range u.Query() {
+		return nil, fmt.Errorf("open keeper %v: invalid query parameter %q", u, param)
+	}
+
+	path := filepath.FromSlash(u.Host + u.Path)
+
+	k, err := OpenKeeper(path)
+	if err != nil {
+		return nil, fmt.Errorf("open keeper %v: %w", u, err)
+	}
+
+	return k, nil
+}
+
+// OpenKeeper returns a keeper for the keyring file at path
+func OpenKeeper(path string) (*secrets.Keeper, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var f File
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidKeyring, err)
+	}
+
+	return NewKeeper(f)
+}
+
+// NewKeeper returns a keeper that encrypts with the primary key of the keyring and
+// decrypts with any of its keys
+func NewKeeper(f File) (*secrets.Keeper, error) {
+	k := &keeper{
+		primary: f.Primary,
+		keys:    map[uint32]*secrets.Keeper{},
+	}
+
+	for _, key := range f.Keys {
+		if _, ok := k.keys[key.Version]; ok {
+			return nil, fmt.Errorf("%w: duplicate key version %d", ErrInvalidKeyring, key.Version)
+		}
+
+		sk, err := localsecrets.Base64KeyStd(key.Key)
+		if err != nil {
+			return nil, fmt.Errorf("%w: key version %d: %s", ErrInvalidKeyring, key.Version, err)
+		}
+
+		k.keys[key.Version] = localsecrets.NewKeeper(sk)
+	}
+
+	if _, ok := k.keys[f.Primary]; !ok {
+		return nil, fmt.Errorf("%w: primary key version %d not found", ErrInvalidKeyring, f.Primary)
+	}
+
+	return secrets.NewKeeper(k), nil
+}
+
+// keeper implements driver.Keeper
+type keeper struct {
+	primary uint32
+	keys    map[uint32]*secrets.Keeper
+}
+
+// Encrypt encrypts the plaintext with the primary key and prefixes it with the key version
+func (k *keeper) Encrypt(ctx context.Context, plaintext []byte) ([]byte, error) {
+	cipher, err := k.keys[k.primary].Encrypt(ctx, plaintext)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, versionSize, versionSize+len(cipher))
+	binary.BigEndian.PutUint32(out, k.primary)
+
+	return append(out, cipher...), nil
+}
+
+// Decrypt decrypts the ciphertext with the key version it was encrypted with
+func (k *keeper) Decrypt(ctx context.Context, ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) < versionSize {
+		return nil, ErrInvalidCiphertext
+	}
+
+	version := binary.BigEndian.Uint32(ciphertext[:versionSize])
+
+	key, ok := k.keys[version]
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownVersion, version)
+	}
+
+	return key.Decrypt(ctx, ciphertext[versionSize:])
+}
+
+// Close closes the keepers for each key version
+func (k *keeper) Close() error {
+	var err error
+
+	for _, key := range k.keys {
+		if cerr := key.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}
+
+	return err
+}
+
+// ErrorAs implements driver.Keeper.ErrorAs
+func (k *keeper) ErrorAs(err error, i interface{}) bool {
+	return false
+}
+
+// ErrorCode implements driver.Keeper.ErrorCode
+func (k *keeper) ErrorCode(err error) gcerrors.ErrorCode {
+	if errors.Is(err, ErrUnknownVersion) || errors.Is(err, ErrInvalidCiphertext) {
+		return gcerrors.InvalidArgument
+	}
+
+	return gcerrors.Unknown
+}
+
+// EncodeKey returns the base64 encoding of a key as it is stored in the keyring file
+func EncodeKey(key [32]byte) string {
+	return base64.StdEncoding.EncodeToString(key[:])
+}
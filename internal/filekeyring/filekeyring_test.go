@@ -0,0 +1,109 @@
+package filekeyring_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gocloud.dev/secrets"
+	"gocloud.dev/secrets/localsecrets"
+
+	"go.hollow.sh/serverservice/internal/filekeyring"
+)
+
+func newKey(t *testing.T) string {
+	sk, err := localsecrets.NewRandomKey()
+	require.NoError(t, err)
+
+	return filekeyring.EncodeKey(sk)
+}
+
+func writeKeyring(t *testing.T, f filekeyring.File) string {
+	data, err := json.Marshal(f)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "keyring.json")
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+
+	return path
+}
+
+func TestKeeperRotation(t *testing.T) {
+	ctx := context.Background()
+	v1, v2 := newKey(t), newKey(t)
+
+	old, err := secrets.OpenKeeper(ctx, "filekeyring://"+writeKeyring(t, filekeyring.File{
+		Primary: 1,
+		Keys:    []filekeyring.Key{{Version: 1, Key: v1}},
+	}))
+	require.NoError(t, err)
+
+	defer old.Close()
+
+	cipher, err := old.Encrypt(ctx, []byte("super-secret"))
+	require.NoError(t, err)
+
+	rotated, err := secrets.OpenKeeper(ctx, "filekeyring://"+writeKeyring(t, filekeyring.File{
+		Primary: 2,
+		Keys:    []filekeyring.Key{{Version: 1, Key: v1}, {Version: 2, Key: v2}},
+	}))
+	require.NoError(t, err)
+
+	defer rotated.Close()
+
+	plain, err := rotated.Decrypt(ctx, cipher)
+	require.NoError(t, err)
+	assert.Equal(t, "super-secret", string(plain))
+
+	cipher, err = rotated.Encrypt(ctx, []byte("new-secret"))
+	require.NoError(t, err)
+
+	_, err = old.Decrypt(ctx, cipher)
+	assert.ErrorIs(t, err, filekeyring.ErrUnknownVersion)
+
+	_, err = rotated.Decrypt(ctx, []byte{0, 1})
+	assert.ErrorIs(t, err, filekeyring.ErrInvalidCiphertext)
+}
+
+func TestNewKeeperInvalid(t *testing.T) {
+	key := newKey(t)
+
+	var testCases = []struct {
+		testName string
+		file     filekeyring.File
+	}{
+		{
+			"missing primary key",
+			filekeyring.File{Primary: 2, Keys: []filekeyring.Key{{Version: 1, Key: key}}},
+		},
+		{
+			"duplicate key version",
+			filekeyring.File{Primary: 1, Keys: []filekeyring.Key{{Version: 1, Key: key}, {Version: 1, Key: key}}},
+		},
+		{
+			"invalid key",
+			filekeyring.File{Primary: 1, Keys: []filekeyring.Key{{Version: 1, Key: "bm90LWEta2V5"}}},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.testName, func(t *testing.T) {
+			_, err := filekeyring.NewKeeper(tt.file)
+			assert.ErrorIs(t, err, filekeyring.ErrInvalidKeyring)
+		})
+	}
+}
+
+func TestOpenKeeperURLInvalid(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := secrets.OpenKeeper(ctx, "filekeyring:///does/not/exist.json")
+	assert.Error(t, err)
+
+	_, err = secrets.OpenKeeper(ctx, "filekeyring://"+writeKeyring(t, filekeyring.File{})+"?version=1")
+	assert.Error(t, err)
+}
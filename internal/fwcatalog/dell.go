@@ -0,0 +1,151 @@
+package fwcatalog
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"unicode/utf16"
+
+	"go.hollow.sh/serverservice/internal/models"
+)
+
+// dellVendor is the vendor of the Dell firmware, the API only takes lowercase vendors
+const dellVendor = "dell"
+
+// dellComponents maps the categories of the Dell catalog to the component names used
+// by the firmware in serverservice. Other categories use their display name.
+var dellComponents = map[string]string{
+	"BI": "bios",
+	"LC": "bmc",
+	"NI": "nic",
+	"SF": "storage",
+}
+
+// dellComponentTypes are the Dell catalog component types that are firmware, drivers
+// and applications are skipped
+var dellComponentTypes = map[string]bool{
+	"BIOS": true,
+	"FRMW": true,
+}
+
+type dellManifest struct {
+	BaseLocation string                  `xml:"baseLocation,attr"`
+	Components   []dellSoftwareComponent `xml:"SoftwareComponent"`
+}
+
+type dellSoftwareComponent struct {
+	Path          string          `xml:"path,attr"`
+	VendorVersion string          `xml:"vendorVersion,attr"`
+	HashMD5       string          `xml:"hashMD5,attr"`
+	ComponentType dellValue       `xml:"ComponentType"`
+	Category      dellValue       `xml:"Category"`
+	Hashes        []dellHash      `xml:"Cryptography>Hash"`
+	Models        []dellModelName `xml:"SupportedSystems>Brand>Model"`
+}
+
+type dellValue struct {
+	Value   string `xml:"value,attr"`
+	Display string `xml:"Display"`
+}
+
+type dellHash struct {
+	Algorithm string `xml:"algorithm,attr"`
+	Value     string `xml:",chardata"`
+}
+
+type dellModelName struct {
+	Display string `xml:"Display"`
+}
+
+func parseDellCatalog(r io.Reader, opts Options) ([]*models.ComponentFirmwareVersion, error) {
+	var manifest dellManifest
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := xml.NewDecoder(bytes.NewReader(decodeUTF16(data)))
+	// the catalog was converted to UTF-8 above, whatever encoding it declares
+	dec.CharsetReader = func(_ string, input io.Reader) (io.Reader, error) { return input, nil }
+
+	if err := dec.Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCatalog, err)
+	}
+
+	firmware := []*models.ComponentFirmwareVersion{}
+
+	for _, sc := range manifest.Components {
+		if !dellComponentTypes[sc.ComponentType.Value] {
+			continue
+		}
+
+		f := &models.ComponentFirmwareVersion{
+			Vendor:        dellVendor,
+			Component:     dellComponent(sc.Category),
+			Filename:      path.Base(sc.Path),
+			Version:       strings.TrimSpace(sc.VendorVersion),
+			Checksum:      sc.checksum(),
+			RepositoryURL: opts.repositoryURL(sc.Path),
+		}
+
+		if manifest.BaseLocation != "" && sc.Path != "" {
+			f.UpstreamURL = "https://" + strings.TrimSuffix(manifest.BaseLocation, "/") + "/" + strings.TrimPrefix(sc.Path, "/")
+		}
+
+		for _, m := range sc.Models {
+			f.Model = append(f.Model, strings.TrimSpace(m.Display))
+		}
+
+		firmware = append(firmware, f)
+	}
+
+	return firmware, nil
+}
+
+func dellComponent(category dellValue) string {
+	if c, ok := dellComponents[category.Value]; ok {
+		return c
+	}
+
+	return strings.ToLower(strings.TrimSpace(category.Display))
+}
+
+// checksum returns the SHA256 hash of the file when the catalog has one, and the MD5 hash otherwise
+func (sc *dellSoftwareComponent) checksum() string {
+	for _, h := range sc.Hashes {
+		if strings.EqualFold(h.Algorithm, "SHA256") {
+			return strings.ToLower(strings.TrimSpace(h.Value))
+		}
+	}
+
+	return strings.ToLower(sc.HashMD5)
+}
+
+// decodeUTF16 converts a catalog starting with a UTF-16 byte order mark to UTF-8, as
+// Dell publishes Catalog.xml in UTF-16. Other data is returned as it is.
+func decodeUTF16(data []byte) []byte {
+	var order binary.ByteOrder
+
+	switch {
+	case bytes.HasPrefix(data, []byte{0xff, 0xfe}):
+		order = binary.LittleEndian
+	case bytes.HasPrefix(data, []byte{0xfe, 0xff}):
+		order = binary.BigEndian
+	default:
+		return data
+	}
+
+	data = data[2:]
+	units := make([]uint16, len(data)/2)
+
+	for i := range units {
+		units[i] = order.Uint16(data[2*i:])
+	}
+
+	return []byte(string(utf16.Decode(units)))
+}
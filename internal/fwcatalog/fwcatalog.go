@@ -0,0 +1,154 @@
+// Package fwcatalog reads the firmware listed in vendor catalogs and manifests so it
+// can be imported into the database.
+package fwcatalog
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/volatiletech/sqlboiler/v4/types"
+
+	"go.hollow.sh/serverservice/internal/models"
+)
+
+// Format is the format of a firmware catalog file
+type Format string
+
+const (
+	// FormatDellCatalog is the Dell Catalog.xml format used by Dell repositories
+	FormatDellCatalog Format = "dell-catalog"
+	// FormatManifest is a YAML or JSON list of firmware
+	FormatManifest Format = "manifest"
+)
+
+var (
+	// ErrUnknownFormat is returned when the format of a catalog isn't supported
+	ErrUnknownFormat = errors.New("unknown firmware catalog format")
+	// ErrInvalidCatalog is returned when a catalog can't be parsed or has an invalid entry
+	ErrInvalidCatalog = errors.New("invalid firmware catalog")
+)
+
+// FormatFromFilename returns the format of a catalog from the file extension
+func FormatFromFilename(name string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".xml":
+		return FormatDellCatalog, nil
+	case ".yaml", ".yml", ".json":
+		return FormatManifest, nil
+	default:
+		return "", fmt.Errorf("%w: can't tell the format of %q", ErrUnknownFormat, name)
+	}
+}
+
+// Options controls how a catalog is read
+type Options struct {
+	// RepositoryBase is the URL of the mirror the firmware files are copied to. Firmware
+	// without a repository URL gets one below it, at the path of the file in a Dell
+	// catalog or at the filename in a manifest. Dell catalogs don't have repository URLs.
+	RepositoryBase string
+}
+
+// Parse returns the firmware listed in the catalog. Entries with the same vendor,
+// component, version and filename are merged into one firmware supporting the
+// models of all of them. The vendor, component and checksum are lowercased, as the
+// API requires.
+func Parse(r io.Reader, format Format, opts Options) ([]*models.ComponentFirmwareVersion, error) {
+	var (
+		firmware []*models.ComponentFirmwareVersion
+		err      error
+	)
+
+	switch format {
+	case FormatDellCatalog:
+		firmware, err = parseDellCatalog(r, opts)
+	case FormatManifest:
+		firmware, err = parseManifest(r, opts)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return merge(firmware)
+}
+
+// Key identifies a firmware the same way as the vendor_component_version_filename_unique
+// constraint of the database
+func Key(f *models.ComponentFirmwareVersion) string {
+	return strings.Join([]string{f.Vendor, f.Component, f.Version, f.Filename}, "/")
+}
+
+// repositoryURL returns the URL of the file at the path below the repository base, or
+// an empty string when there's no base
+func (o Options) repositoryURL(filePath string) string {
+	if o.RepositoryBase == "" || filePath == "" {
+		return ""
+	}
+
+	return strings.TrimSuffix(o.RepositoryBase, "/") + "/" + strings.TrimPrefix(filePath, "/")
+}
+
+func merge(firmware []*models.ComponentFirmwareVersion) ([]*models.ComponentFirmwareVersion, error) {
+	merged := []*models.ComponentFirmwareVersion{}
+	seen := map[string]*models.ComponentFirmwareVersion{}
+
+	for i, f := range firmware {
+		normalize(f)
+
+		if f.Vendor == "" || f.Component == "" || f.Version == "" || f.Filename == "" {
+			return nil, fmt.Errorf("%w: entry %d requires a vendor, component, version and filename", ErrInvalidCatalog, i)
+		}
+
+		prev, ok := seen[Key(f)]
+		if !ok {
+			f.Model = normalizeModels(f.Model)
+			seen[Key(f)] = f
+			merged = append(merged, f)
+
+			continue
+		}
+
+		if prev.Checksum != f.Checksum {
+			return nil, fmt.Errorf("%w: entry %d: %s is listed with different checksums", ErrInvalidCatalog, i, Key(f))
+		}
+
+		prev.Model = normalizeModels(append(prev.Model, f.Model...))
+	}
+
+	return merged, nil
+}
+
+// normalize lowercases the fields the API only takes in lowercase, so the firmware can
+// be updated through the API and is found by the lookups comparing them exactly
+func normalize(f *models.ComponentFirmwareVersion) {
+	f.Vendor = strings.ToLower(strings.TrimSpace(f.Vendor))
+	f.Component = strings.ToLower(strings.TrimSpace(f.Component))
+	f.Checksum = strings.ToLower(strings.TrimSpace(f.Checksum))
+}
+
+// normalizeModels sorts the models and removes the duplicates so the models of a
+// firmware can be compared
+func normalizeModels(names []string) types.StringArray {
+	seen := map[string]bool{}
+	normalized := types.StringArray{}
+
+	for _, m := range names {
+		m = strings.TrimSpace(m)
+		if m == "" || seen[m] {
+			continue
+		}
+
+		seen[m] = true
+		normalized = append(normalized, m)
+	}
+
+	sort.Strings(normalized)
+
+	return normalized
+}
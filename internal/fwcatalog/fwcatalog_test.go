@@ -0,0 +1,187 @@
+package fwcatalog_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/sqlboiler/v4/types"
+
+	"go.hollow.sh/serverservice/internal/fwcatalog"
+)
+
+const dellCatalog = `<?xml version="1.0" encoding="utf-16"?>
+<Manifest baseLocation="downloads.dell.com" version="23.06.00">
+  <SoftwareComponent path="FOLDER08/BIOS_C4FT0_WN64_2.6.6.EXE" vendorVersion="2.6.6" hashMD5="ABCDEF">
+    <ComponentType value="BIOS"><Display lang="en">BIOS</Display></ComponentType>
+    <Category value="BI"><Display lang="en">BIOS</Display></Category>
+    <Cryptography><Hash algorithm="SHA256">0123ABCD</Hash></Cryptography>
+    <SupportedSystems>
+      <Brand key="3" prefix="PE"><Display lang="en">PowerEdge</Display>
+        <Model systemID="08FF"><Display lang="en"><![CDATA[R6515]]></Display></Model>
+      </Brand>
+    </SupportedSystems>
+  </SoftwareComponent>
+  <SoftwareComponent path="FOLDER08/BIOS_C4FT0_WN64_2.6.6.EXE" vendorVersion="2.6.6" hashMD5="ABCDEF">
+    <ComponentType value="BIOS"><Display lang="en">BIOS</Display></ComponentType>
+    <Category value="BI"><Display lang="en">BIOS</Display></Category>
+    <Cryptography><Hash algorithm="SHA256">0123ABCD</Hash></Cryptography>
+    <SupportedSystems>
+      <Brand key="3" prefix="PE"><Display lang="en">PowerEdge</Display>
+        <Model systemID="0900"><Display lang="en"><![CDATA[R7515]]></Display></Model>
+        <Model systemID="08FF"><Display lang="en"><![CDATA[R6515]]></Display></Model>
+      </Brand>
+    </SupportedSystems>
+  </SoftwareComponent>
+  <SoftwareComponent path="FOLDER09/iDRAC_5.10.00.00_A00.EXE" vendorVersion="5.10.00.00" hashMD5="ABCDEF">
+    <ComponentType value="FRMW"><Display lang="en">Firmware</Display></ComponentType>
+    <Category value="LC"><Display lang="en">iDRAC with Lifecycle Controller</Display></Category>
+    <SupportedSystems>
+      <Brand key="3" prefix="PE"><Display lang="en">PowerEdge</Display>
+        <Model systemID="0716"><Display lang="en"><![CDATA[R640]]></Display></Model>
+      </Brand>
+    </SupportedSystems>
+  </SoftwareComponent>
+  <SoftwareComponent path="FOLDER10/Chipset_Driver.EXE" vendorVersion="1.0" hashMD5="ABCDEF">
+    <ComponentType value="DRVR"><Display lang="en">Driver</Display></ComponentType>
+    <Category value="CS"><Display lang="en">Chipset</Display></Category>
+  </SoftwareComponent>
+</Manifest>`
+
+func utf16LE(s string) []byte {
+	buf := bytes.NewBuffer([]byte{0xff, 0xfe})
+
+	for _, u := range utf16.Encode([]rune(s)) {
+		_ = binary.Write(buf, binary.LittleEndian, u)
+	}
+
+	return buf.Bytes()
+}
+
+func TestParseDellCatalog(t *testing.T) {
+	for name, data := range map[string][]byte{
+		"utf-8":  []byte(dellCatalog),
+		"utf-16": utf16LE(dellCatalog),
+	} {
+		t.Run(name, func(t *testing.T) {
+			firmware, err := fwcatalog.Parse(bytes.NewReader(data), fwcatalog.FormatDellCatalog, fwcatalog.Options{RepositoryBase: "https://mirror.example.com/dell/"})
+			require.NoError(t, err)
+			require.Len(t, firmware, 2)
+
+			bios := firmware[0]
+			assert.Equal(t, "dell", bios.Vendor)
+			assert.Equal(t, "bios", bios.Component)
+			assert.Equal(t, "2.6.6", bios.Version)
+			assert.Equal(t, "BIOS_C4FT0_WN64_2.6.6.EXE", bios.Filename)
+			assert.Equal(t, "0123abcd", bios.Checksum)
+			assert.Equal(t, "https://downloads.dell.com/FOLDER08/BIOS_C4FT0_WN64_2.6.6.EXE", bios.UpstreamURL)
+			assert.Equal(t, "https://mirror.example.com/dell/FOLDER08/BIOS_C4FT0_WN64_2.6.6.EXE", bios.RepositoryURL)
+			assert.Equal(t, types.StringArray{"R6515", "R7515"}, bios.Model)
+
+			bmc := firmware[1]
+			assert.Equal(t, "bmc", bmc.Component)
+			assert.Equal(t, "abcdef", bmc.Checksum)
+			assert.Equal(t, types.StringArray{"R640"}, bmc.Model)
+		})
+	}
+}
+
+func TestParseManifest(t *testing.T) {
+	testCases := []struct {
+		name     string
+		manifest string
+		errorMsg string
+	}{
+		{
+			"yaml",
+			`
+firmware:
+  - vendor: supermicro
+    model: [X11DPH-T]
+    component: bmc
+    filename: BMC_X11DPH-T.bin
+    version: "1.73.11"
+    checksum: abc
+    upstream_url: https://vendor.com/BMC_X11DPH-T.bin
+`,
+			"",
+		},
+		{
+			"json",
+			`{"firmware": [{"vendor": "supermicro", "model": ["X11DPH-T"], "component": "bmc", "filename": "BMC_X11DPH-T.bin", "version": "1.73.11", "checksum": "abc", "upstream_url": "https://vendor.com/BMC_X11DPH-T.bin"}]}`,
+			"",
+		},
+		{
+			"mixed case",
+			`
+firmware:
+  - vendor: SuperMicro
+    model: [X11DPH-T]
+    component: BMC
+    filename: BMC_X11DPH-T.bin
+    version: "1.73.11"
+    checksum: ABC
+    upstream_url: https://vendor.com/BMC_X11DPH-T.bin
+`,
+			"",
+		},
+		{
+			"unknown field",
+			`{"firmware": [{"vendor": "supermicro", "models": ["X11DPH-T"]}]}`,
+			"field models not found",
+		},
+		{
+			"missing version",
+			`{"firmware": [{"vendor": "supermicro", "component": "bmc", "filename": "BMC_X11DPH-T.bin"}]}`,
+			"entry 0 requires a vendor, component, version and filename",
+		},
+		{
+			"conflicting checksums",
+			`
+firmware:
+  - {vendor: supermicro, component: bmc, filename: BMC.bin, version: "1.0", checksum: abc}
+  - {vendor: supermicro, component: bmc, filename: BMC.bin, version: "1.0", checksum: def}
+`,
+			"listed with different checksums",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			firmware, err := fwcatalog.Parse(strings.NewReader(tt.manifest), fwcatalog.FormatManifest, fwcatalog.Options{RepositoryBase: "https://mirror.example.com"})
+			if tt.errorMsg != "" {
+				assert.ErrorIs(t, err, fwcatalog.ErrInvalidCatalog)
+				assert.ErrorContains(t, err, tt.errorMsg)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, firmware, 1)
+			assert.Equal(t, "supermicro", firmware[0].Vendor)
+			assert.Equal(t, "bmc", firmware[0].Component)
+			assert.Equal(t, "abc", firmware[0].Checksum)
+			assert.Equal(t, "1.73.11", firmware[0].Version)
+			assert.Equal(t, types.StringArray{"X11DPH-T"}, firmware[0].Model)
+			assert.Equal(t, "https://vendor.com/BMC_X11DPH-T.bin", firmware[0].UpstreamURL)
+			assert.Equal(t, "https://mirror.example.com/BMC_X11DPH-T.bin", firmware[0].RepositoryURL)
+		})
+	}
+}
+
+func TestFormatFromFilename(t *testing.T) {
+	format, err := fwcatalog.FormatFromFilename("Catalog.XML")
+	require.NoError(t, err)
+	assert.Equal(t, fwcatalog.FormatDellCatalog, format)
+
+	format, err = fwcatalog.FormatFromFilename("firmware.yml")
+	require.NoError(t, err)
+	assert.Equal(t, fwcatalog.FormatManifest, format)
+
+	_, err = fwcatalog.FormatFromFilename("firmware.txt")
+	assert.ErrorIs(t, err, fwcatalog.ErrUnknownFormat)
+}
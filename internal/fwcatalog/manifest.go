@@ -0,0 +1,58 @@
+package fwcatalog
+
+import (
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+
+	"go.hollow.sh/serverservice/internal/models"
+)
+
+// manifest is a list of firmware in YAML or JSON, JSON being read as YAML
+type manifest struct {
+	Firmware []manifestFirmware `yaml:"firmware"`
+}
+
+type manifestFirmware struct {
+	Vendor        string   `yaml:"vendor"`
+	Model         []string `yaml:"model"`
+	Component     string   `yaml:"component"`
+	Filename      string   `yaml:"filename"`
+	Version       string   `yaml:"version"`
+	Checksum      string   `yaml:"checksum"`
+	UpstreamURL   string   `yaml:"upstream_url"`
+	RepositoryURL string   `yaml:"repository_url"`
+}
+
+func parseManifest(r io.Reader, opts Options) ([]*models.ComponentFirmwareVersion, error) {
+	var m manifest
+
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+
+	if err := dec.Decode(&m); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCatalog, err)
+	}
+
+	firmware := make([]*models.ComponentFirmwareVersion, 0, len(m.Firmware))
+
+	for _, f := range m.Firmware {
+		if f.RepositoryURL == "" {
+			f.RepositoryURL = opts.repositoryURL(f.Filename)
+		}
+
+		firmware = append(firmware, &models.ComponentFirmwareVersion{
+			Vendor:        f.Vendor,
+			Model:         f.Model,
+			Component:     f.Component,
+			Filename:      f.Filename,
+			Version:       f.Version,
+			Checksum:      f.Checksum,
+			UpstreamURL:   f.UpstreamURL,
+			RepositoryURL: f.RepositoryURL,
+		})
+	}
+
+	return firmware, nil
+}
@@ -0,0 +1,232 @@
+// Package fwverify checks the firmware artifacts in a repository have the checksum
+// the firmware is stored with.
+package fwverify
+
+import (
+	"context"
+	"crypto/md5" // nolint:gosec // md5 is only used to compare with the checksums vendors publish
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// StatusVerified is the status of firmware whose artifact has the stored checksum
+	StatusVerified = "verified"
+	// StatusMismatch is the status of firmware whose artifact has another checksum
+	StatusMismatch = "mismatch"
+	// StatusFailed is the status of firmware whose artifact couldn't be read, or
+	// whose checksum isn't in a supported format
+	StatusFailed = "failed"
+
+	// DefaultTimeout is how long reading an artifact can take with the default client
+	DefaultTimeout = 10 * time.Minute
+	// DefaultMaxSize is the largest artifact read when the verifier has no MaxSize
+	DefaultMaxSize int64 = 4 << 30
+)
+
+var (
+	// ErrUnsupportedChecksum is returned when the algorithm of a checksum isn't known
+	ErrUnsupportedChecksum = errors.New("unsupported checksum")
+	// ErrUnsupportedURL is returned when the repository root or an artifact URL isn't an http, https or file URL
+	ErrUnsupportedURL = errors.New("unsupported repository URL")
+	// ErrFetch is returned when an artifact can't be read from the repository
+	ErrFetch = errors.New("failed fetching firmware artifact")
+	// ErrMismatch is returned when the checksum of an artifact isn't the stored checksum
+	ErrMismatch = errors.New("firmware checksum mismatch")
+	// ErrTooLarge is returned when an artifact is larger than the verifier reads
+	ErrTooLarge = errors.New("firmware artifact too large")
+)
+
+// Verifier reads firmware artifacts from a repository and computes their checksum
+type Verifier struct {
+	// Root is the http, https or file URL of the repository mirror the path of a repository
+	// URL is read from. The repository URL itself is read when it's nil, and it can only
+	// be an http or https URL then.
+	Root   *url.URL
+	Client *http.Client
+	// MaxSize is the largest artifact read, DefaultMaxSize is used when it's zero
+	MaxSize int64
+}
+
+// New returns a verifier that reads artifacts from the repository root, the root is
+// optional. Without a client, artifacts are read with a client that times out after
+// DefaultTimeout.
+func New(root string, client *http.Client) (*Verifier, error) {
+	v := &Verifier{Client: client}
+
+	if v.Client == nil {
+		v.Client = &http.Client{Timeout: DefaultTimeout}
+	}
+
+	if root == "" {
+		return v, nil
+	}
+
+	u, err := parseURL(root)
+	if err != nil {
+		return nil, err
+	}
+
+	v.Root = u
+
+	return v, nil
+}
+
+// ParseChecksum returns the hash for the checksum and the expected hex digest. A checksum
+// is prefixed with its algorithm, as in sha256:<digest>, sha512:<digest> or md5:<digest>.
+// Without a prefix the algorithm is taken from the length of the digest.
+func ParseChecksum(checksum string) (hash.Hash, string, error) {
+	algorithm, digest, found := strings.Cut(strings.ToLower(strings.TrimSpace(checksum)), ":")
+	if !found {
+		digest = algorithm
+
+		switch len(digest) {
+		case md5.Size * 2:
+			algorithm = "md5"
+		case sha256.Size * 2:
+			algorithm = "sha256"
+		case sha512.Size * 2:
+			algorithm = "sha512"
+		default:
+			return nil, "", fmt.Errorf("%w: can't tell the algorithm of %q", ErrUnsupportedChecksum, checksum)
+		}
+	}
+
+	if _, err := hex.DecodeString(digest); err != nil {
+		return nil, "", fmt.Errorf("%w: %q isn't a hex digest", ErrUnsupportedChecksum, checksum)
+	}
+
+	switch algorithm {
+	case "md5":
+		return md5.New(), digest, nil // nolint:gosec // see the import
+	case "sha256":
+		return sha256.New(), digest, nil
+	case "sha512":
+		return sha512.New(), digest, nil
+	default:
+		return nil, "", fmt.Errorf("%w: algorithm %q", ErrUnsupportedChecksum, algorithm)
+	}
+}
+
+// ArtifactURL returns the URL the artifact at the repository URL is read from
+func (v *Verifier) ArtifactURL(repositoryURL string) (*url.URL, error) {
+	u, err := parseURL(repositoryURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if v.Root == nil {
+		// artifacts are only read from the local filesystem below a file root
+		if u.Scheme == "file" {
+			return nil, fmt.Errorf("%w: file URLs are only read below a file repository root: %q", ErrUnsupportedURL, repositoryURL)
+		}
+
+		return u, nil
+	}
+
+	artifact := *v.Root
+	// the artifact path is cleaned as an absolute path so it stays below the root
+	artifact.Path = path.Join(v.Root.Path, path.Clean("/"+u.Path))
+	artifact.RawPath = ""
+
+	return &artifact, nil
+}
+
+// Verify reads the artifact at the repository URL and returns the verification status.
+// The error explains a mismatch or failed status.
+func (v *Verifier) Verify(ctx context.Context, checksum, repositoryURL string) (string, error) {
+	h, expected, err := ParseChecksum(checksum)
+	if err != nil {
+		return StatusFailed, err
+	}
+
+	u, err := v.ArtifactURL(repositoryURL)
+	if err != nil {
+		return StatusFailed, err
+	}
+
+	if err := v.fetch(ctx, u, h); err != nil {
+		return StatusFailed, fmt.Errorf("%w: %s: %s", ErrFetch, u, err)
+	}
+
+	if actual := hex.EncodeToString(h.Sum(nil)); actual != expected {
+		return StatusMismatch, fmt.Errorf("%w: %s has checksum %s, expected %s", ErrMismatch, u, actual, expected)
+	}
+
+	return StatusVerified, nil
+}
+
+// fetch writes the content of the artifact to w
+func (v *Verifier) fetch(ctx context.Context, u *url.URL, w io.Writer) error {
+	if u.Scheme == "file" {
+		f, err := os.Open(filepath.FromSlash(u.Path))
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		return v.copy(w, f)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := v.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	return v.copy(w, resp.Body)
+}
+
+// copy writes the artifact to w, it fails once more than the max size is read
+func (v *Verifier) copy(w io.Writer, r io.Reader) error {
+	maxSize := v.MaxSize
+	if maxSize <= 0 {
+		maxSize = DefaultMaxSize
+	}
+
+	n, err := io.Copy(w, io.LimitReader(r, maxSize+1))
+	if err != nil {
+		return err
+	}
+
+	if n > maxSize {
+		return fmt.Errorf("%w: more than %d bytes", ErrTooLarge, maxSize)
+	}
+
+	return nil
+}
+
+func parseURL(s string) (*url.URL, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedURL, err)
+	}
+
+	switch u.Scheme {
+	case "http", "https", "file":
+		return u, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedURL, s)
+	}
+}
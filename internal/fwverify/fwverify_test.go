@@ -0,0 +1,149 @@
+package fwverify_test
+
+import (
+	"context"
+	"crypto/md5" // nolint:gosec // checksums in the md5 format are supported
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.hollow.sh/serverservice/internal/fwverify"
+)
+
+var artifact = []byte("firmware artifact")
+
+func sha256Hex(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestParseChecksum(t *testing.T) {
+	md5Sum := md5.Sum(artifact) // nolint:gosec // see the import
+	sha512Sum := sha512.Sum512(artifact)
+
+	testCases := []struct {
+		name     string
+		checksum string
+		digest   string
+		size     int
+		err      error
+	}{
+		{"sha256 prefix", "sha256:" + sha256Hex(artifact), sha256Hex(artifact), sha256.Size, nil},
+		{"sha512 prefix", "SHA512:" + hex.EncodeToString(sha512Sum[:]), hex.EncodeToString(sha512Sum[:]), sha512.Size, nil},
+		{"md5 prefix", "md5:" + hex.EncodeToString(md5Sum[:]), hex.EncodeToString(md5Sum[:]), md5.Size, nil},
+		{"sha256 from length", sha256Hex(artifact), sha256Hex(artifact), sha256.Size, nil},
+		{"md5 from length", hex.EncodeToString(md5Sum[:]), hex.EncodeToString(md5Sum[:]), md5.Size, nil},
+		{"unknown length", "foobar", "", 0, fwverify.ErrUnsupportedChecksum},
+		{"unknown algorithm", "sha1:abcdef", "", 0, fwverify.ErrUnsupportedChecksum},
+		{"not hex", "sha256:xyz", "", 0, fwverify.ErrUnsupportedChecksum},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			h, digest, err := fwverify.ParseChecksum(tt.checksum)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.digest, digest)
+			assert.Equal(t, tt.size, h.Size())
+		})
+	}
+}
+
+func TestArtifactURL(t *testing.T) {
+	v, err := fwverify.New("file:///srv/mirror", nil)
+	require.NoError(t, err)
+
+	u, err := v.ArtifactURL("https://example-firmware-bucket.s3.amazonaws.com/firmware/dell/r640/bios/bios-2.4.4.EXE")
+	require.NoError(t, err)
+	assert.Equal(t, "file:///srv/mirror/firmware/dell/r640/bios/bios-2.4.4.EXE", u.String())
+
+	u, err = v.ArtifactURL("https://example.com/../../etc/passwd")
+	require.NoError(t, err)
+	assert.Equal(t, "file:///srv/mirror/etc/passwd", u.String(), "the artifact stays below the root")
+
+	_, err = fwverify.New("s3://bucket", nil)
+	assert.ErrorIs(t, err, fwverify.ErrUnsupportedURL)
+
+	v, err = fwverify.New("", nil)
+	require.NoError(t, err)
+
+	u, err = v.ArtifactURL("https://example.com/firmware/bios.EXE")
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/firmware/bios.EXE", u.String(), "the repository URL is read without a root")
+
+	_, err = v.ArtifactURL("file:///etc/passwd")
+	assert.ErrorIs(t, err, fwverify.ErrUnsupportedURL, "files are only read below a file root")
+
+	v, err = fwverify.New("https://mirror.example.com/repo", nil)
+	require.NoError(t, err)
+
+	u, err = v.ArtifactURL("file:///firmware/bios.EXE")
+	require.NoError(t, err)
+	assert.Equal(t, "https://mirror.example.com/repo/firmware/bios.EXE", u.String(), "only the path is read below an http root")
+	assert.Equal(t, fwverify.DefaultTimeout, v.Client.Timeout)
+}
+
+func TestVerify(t *testing.T) {
+	ctx := context.TODO()
+
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "firmware"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "firmware", "bios.EXE"), artifact, 0o600))
+
+	srv := httptest.NewServer(http.FileServer(http.Dir(dir)))
+	defer srv.Close()
+
+	for _, root := range []string{"file://" + filepath.ToSlash(dir), srv.URL} {
+		v, err := fwverify.New(root, srv.Client())
+		require.NoError(t, err)
+
+		status, err := v.Verify(ctx, "sha256:"+sha256Hex(artifact), "https://example.com/firmware/bios.EXE")
+		require.NoError(t, err, root)
+		assert.Equal(t, fwverify.StatusVerified, status, root)
+
+		status, err = v.Verify(ctx, sha256Hex([]byte("other")), "https://example.com/firmware/bios.EXE")
+		assert.ErrorIs(t, err, fwverify.ErrMismatch, root)
+		assert.Equal(t, fwverify.StatusMismatch, status, root)
+
+		status, err = v.Verify(ctx, sha256Hex(artifact), "https://example.com/firmware/missing.EXE")
+		assert.ErrorIs(t, err, fwverify.ErrFetch, root)
+		assert.Equal(t, fwverify.StatusFailed, status, root)
+
+		status, err = v.Verify(ctx, "foobar", "https://example.com/firmware/bios.EXE")
+		assert.ErrorIs(t, err, fwverify.ErrUnsupportedChecksum, root)
+		assert.Equal(t, fwverify.StatusFailed, status, root)
+	}
+}
+
+func TestVerifyMaxSize(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "bios.EXE"), artifact, 0o600))
+
+	v, err := fwverify.New("file://"+filepath.ToSlash(dir), nil)
+	require.NoError(t, err)
+
+	v.MaxSize = int64(len(artifact))
+
+	status, err := v.Verify(context.TODO(), sha256Hex(artifact), "https://example.com/bios.EXE")
+	require.NoError(t, err)
+	assert.Equal(t, fwverify.StatusVerified, status)
+
+	v.MaxSize = int64(len(artifact)) - 1
+
+	status, err = v.Verify(context.TODO(), sha256Hex(artifact), "https://example.com/bios.EXE")
+	assert.ErrorIs(t, err, fwverify.ErrFetch)
+	assert.ErrorContains(t, err, fwverify.ErrTooLarge.Error())
+	assert.Equal(t, fwverify.StatusFailed, status)
+}
@@ -0,0 +1,119 @@
+// Package fwversion compares firmware versions. Vendors use a mix of formats, like
+// semantic versions (1.2.3-rc.1), dotted numbers (2.14.1), and letters with numbers
+// (A22, 1.3.8a), so versions are split into numeric and alphabetic segments that are
+// compared in order.
+//
+// SortKey encodes a version so that comparing keys as strings orders the versions.
+// This lets the database filter and sort firmware by version.
+package fwversion
+
+import (
+	"strings"
+	"unicode"
+)
+
+// The key of a version is a sequence of tokens. Each token starts with a marker
+// that orders the kinds of token against each other at the same position.
+const (
+	// markPrerelease starts a semver pre-release, which sorts before the release
+	markPrerelease = '1'
+	// markEnd ends the version, so a version sorts before the same version with more segments
+	markEnd = '2'
+	// markAlpha starts an alphabetic segment
+	markAlpha = '3'
+	// markNumber starts a numeric segment
+	markNumber = '4'
+
+	// maxDigits is the most significant digits kept for a numeric segment
+	maxDigits = 99
+)
+
+// SortKey returns a key for the version that sorts like the version when compared as
+// a string. Letters are compared case insensitively, a leading "v" and semver build
+// metadata are ignored, and leading zeros don't change a number.
+func SortKey(version string) string {
+	v := strings.ToLower(strings.TrimSpace(version))
+
+	// build metadata doesn't change the precedence of a semver
+	if i := strings.IndexByte(v, '+'); i >= 0 {
+		v = v[:i]
+	}
+
+	if len(v) > 1 && v[0] == 'v' && isDigit(rune(v[1])) {
+		v = v[1:]
+	}
+
+	var key strings.Builder
+
+	seenNumber := false
+	prerelease := false
+	runes := []rune(v)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+
+		switch {
+		case isDigit(r):
+			j := i
+			for j < len(runes) && isDigit(runes[j]) {
+				j++
+			}
+
+			writeNumber(&key, string(runes[i:j]))
+
+			seenNumber = true
+			i = j
+		case unicode.IsLetter(r):
+			j := i
+			for j < len(runes) && unicode.IsLetter(runes[j]) {
+				j++
+			}
+
+			key.WriteByte(markAlpha)
+			key.WriteString(string(runes[i:j]))
+
+			i = j
+		default:
+			// a dash followed by a letter after the numbers is a semver pre-release
+			if r == '-' && seenNumber && !prerelease && i+1 < len(runes) && unicode.IsLetter(runes[i+1]) {
+				key.WriteByte(markPrerelease)
+
+				prerelease = true
+			}
+
+			i++
+		}
+	}
+
+	key.WriteByte(markEnd)
+
+	return key.String()
+}
+
+// Compare returns -1 when a is lower than b, 1 when a is higher than b and 0 when
+// they are the same version
+func Compare(a, b string) int {
+	return strings.Compare(SortKey(a), SortKey(b))
+}
+
+// writeNumber writes a numeric segment prefixed by its number of digits, so longer
+// numbers sort after shorter ones
+func writeNumber(key *strings.Builder, digits string) {
+	digits = strings.TrimLeft(digits, "0")
+	if digits == "" {
+		digits = "0"
+	}
+
+	if len(digits) > maxDigits {
+		digits = digits[:maxDigits]
+	}
+
+	key.WriteByte(markNumber)
+	key.WriteByte(byte('0' + len(digits)/10))
+	key.WriteByte(byte('0' + len(digits)%10))
+	key.WriteString(digits)
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
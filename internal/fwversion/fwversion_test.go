@@ -0,0 +1,52 @@
+package fwversion_test
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.hollow.sh/serverservice/internal/fwversion"
+)
+
+func TestCompare(t *testing.T) {
+	testCases := []struct {
+		a, b     string
+		expected int
+	}{
+		{"2.14.1", "2.14.1", 0},
+		{"2.14.1", "2.9.1", 1},
+		{"2.14", "2.14.1", -1},
+		{"1.3.8", "1.3.8a", -1},
+		{"1.3.8a", "1.3.8b", -1},
+		{"1.3.8a", "1.3.9", -1},
+		{"A22", "A9", 1},
+		{"A22", "a22", 0},
+		{"A09", "A9", 0},
+		{"v1.2.3", "1.2.3", 0},
+		{"1.2.3+build.5", "1.2.3", 0},
+		{"1.2.3-rc.1", "1.2.3", -1},
+		{"1.2.3-alpha", "1.2.3-beta", -1},
+		{"1.2.3-rc.2", "1.2.3-rc.10", -1},
+		{"5.10.00.00", "5.10.00.10", -1},
+		{"5.10.00.00", "6.00.00.00", -1},
+		{"2.6.4", "2.10.1", -1},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.a+" "+tt.b, func(t *testing.T) {
+			assert.Equal(t, tt.expected, fwversion.Compare(tt.a, tt.b))
+			assert.Equal(t, -tt.expected, fwversion.Compare(tt.b, tt.a))
+		})
+	}
+}
+
+func TestSortKeyOrder(t *testing.T) {
+	versions := []string{"1.10.0", "1.2.0", "1.2.0-rc.1", "1.2.0a", "0.9", "1.2"}
+
+	sort.Slice(versions, func(i, j int) bool {
+		return fwversion.SortKey(versions[i]) < fwversion.SortKey(versions[j])
+	})
+
+	assert.Equal(t, []string{"0.9", "1.2", "1.2.0-rc.1", "1.2.0", "1.2.0a", "1.10.0"}, versions)
+}
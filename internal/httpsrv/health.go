@@ -0,0 +1,250 @@
+package httpsrv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"go.hollow.sh/serverservice/internal/dbtools"
+)
+
+// Names of the builtin health checks
+const (
+	HealthCheckDB         = "db"
+	HealthCheckKeeper     = "keeper"
+	HealthCheckStream     = "stream"
+	HealthCheckMigrations = "migrations"
+)
+
+const (
+	healthStatusUp   = "UP"
+	healthStatusDown = "DOWN"
+)
+
+var (
+	// healthCheckTimeout is how long each health check has to complete
+	healthCheckTimeout = 5 * time.Second
+
+	// ErrStreamNotConnected is returned by the stream health check when the event stream failed to open
+	ErrStreamNotConnected = errors.New("event stream not connected")
+	// ErrStreamPublish is returned by the stream health check when the last publish failed
+	ErrStreamPublish = errors.New("event stream publish failed")
+	// ErrStreamDisconnected is returned by the stream health check when the connection to NATS is down
+	ErrStreamDisconnected = errors.New("event stream disconnected")
+	// ErrPendingMigrations is returned by the migrations health check when the database schema is behind
+	ErrPendingMigrations = errors.New("database has pending migrations")
+)
+
+// HealthChecker checks that a dependency of the server is available
+type HealthChecker interface {
+	Name() string
+	Check(ctx context.Context) error
+}
+
+type healthCheckFunc struct {
+	name  string
+	check func(context.Context) error
+}
+
+func (h healthCheckFunc) Name() string { return h.name }
+
+func (h healthCheckFunc) Check(ctx context.Context) error { return h.check(ctx) }
+
+// NewHealthChecker returns a HealthChecker with the given name that runs check
+func NewHealthChecker(name string, check func(context.Context) error) HealthChecker {
+	return healthCheckFunc{name: name, check: check}
+}
+
+// NewDBChecker returns a HealthChecker that pings the database
+func NewDBChecker(db *sqlx.DB) HealthChecker {
+	return NewHealthChecker(HealthCheckDB, db.PingContext)
+}
+
+// NewKeeperChecker returns a HealthChecker that encrypts and decrypts a value with
+// the primary key of the keyring
+func NewKeeperChecker(keyring *dbtools.Keyring) HealthChecker {
+	return NewHealthChecker(HealthCheckKeeper, keyring.Check)
+}
+
+// NewStreamChecker returns a HealthChecker that fails when the event stream couldn't be
+// opened, has been closed, or when its last publish failed within the error window of
+// the stream. It recovers once a publish succeeds or the window is over. When the stream
+// has a connection, the check also fails while the connection is down or doesn't answer
+// a ping.
+func NewStreamChecker(stream *MonitoredStream) HealthChecker {
+	return NewHealthChecker(HealthCheckStream, func(ctx context.Context) error {
+		if stream == nil {
+			return ErrStreamNotConnected
+		}
+
+		if err := stream.Err(); err != nil {
+			return fmt.Errorf("%w: %s", ErrStreamPublish, err)
+		}
+
+		conn := stream.conn()
+		if conn == nil {
+			return nil
+		}
+
+		if !conn.IsConnected() {
+			return ErrStreamDisconnected
+		}
+
+		// the flush waits for the server to answer a ping
+		if err := conn.FlushWithContext(ctx); err != nil {
+			return fmt.Errorf("%w: %s", ErrStreamDisconnected, err)
+		}
+
+		return nil
+	})
+}
+
+// NewMigrationsChecker returns a HealthChecker that compares the goose sql migrations
+// at the root of migrations with the version recorded in the goose version table
+func NewMigrationsChecker(db *sqlx.DB, migrations fs.FS) HealthChecker {
+	return NewHealthChecker(HealthCheckMigrations, func(ctx context.Context) error {
+		versions, err := migrationVersions(migrations)
+		if err != nil {
+			return err
+		}
+
+		var current int64
+
+		// a version is applied when the latest row recorded for it is applied
+		err = db.GetContext(ctx, &current, `SELECT COALESCE(max(g.version_id), 0) FROM goose_db_version AS g
+			WHERE g.is_applied AND g.id = (SELECT max(id) FROM goose_db_version WHERE version_id = g.version_id)`)
+		if err != nil {
+			return err
+		}
+
+		pending := 0
+
+		for _, v := range versions {
+			if v > current {
+				pending++
+			}
+		}
+
+		if pending > 0 {
+			return fmt.Errorf("%w: %d migrations after version %d", ErrPendingMigrations, pending, current)
+		}
+
+		return nil
+	})
+}
+
+// migrationVersions returns the versions of the goose sql migrations in the filesystem
+func migrationVersions(migrations fs.FS) ([]int64, error) {
+	files, err := fs.Glob(migrations, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]int64, 0, len(files))
+
+	for _, f := range files {
+		num, _, _ := strings.Cut(path.Base(f), "_")
+
+		v, err := strconv.ParseInt(num, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration file name %q: %w", f, err)
+		}
+
+		versions = append(versions, v)
+	}
+
+	return versions, nil
+}
+
+// healthCheckResult is the outcome of a health check in the verbose readiness report
+type healthCheckResult struct {
+	Status   string `json:"status"`
+	Required bool   `json:"required"`
+	Latency  string `json:"latency"`
+	Error    string `json:"error,omitempty"`
+}
+
+// healthCheckers returns the builtin checkers for the dependencies the server was
+// given followed by any additional checkers
+func (s *Server) healthCheckers() []HealthChecker {
+	checkers := []HealthChecker{}
+
+	if s.DB != nil {
+		checkers = append(checkers, NewDBChecker(s.DB))
+
+		if s.Migrations != nil {
+			checkers = append(checkers, NewMigrationsChecker(s.DB, s.Migrations))
+		}
+	}
+
+	if s.Keyring != nil {
+		checkers = append(checkers, NewKeeperChecker(s.Keyring))
+	}
+
+	return append(checkers, s.HealthCheckers...)
+}
+
+// runHealthChecks runs the health checks concurrently and returns if all the required
+// checks passed along with the result of each check
+func (s *Server) runHealthChecks(ctx context.Context) (bool, map[string]healthCheckResult) {
+	optional := map[string]bool{}
+	for _, name := range s.OptionalHealthChecks {
+		optional[name] = true
+	}
+
+	checkers := s.healthCheckers()
+	results := make(map[string]healthCheckResult, len(checkers))
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+
+	healthy := true
+
+	for _, checker := range checkers {
+		wg.Add(1)
+
+		go func(checker HealthChecker) {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+			defer cancel()
+
+			start := time.Now()
+			err := checker.Check(checkCtx)
+
+			result := healthCheckResult{
+				Status:   healthStatusUp,
+				Required: !optional[checker.Name()],
+				Latency:  time.Since(start).String(),
+			}
+
+			if err != nil {
+				result.Status = healthStatusDown
+				result.Error = err.Error()
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			results[checker.Name()] = result
+
+			if err != nil && result.Required {
+				healthy = false
+			}
+		}(checker)
+	}
+
+	wg.Wait()
+
+	return healthy, results
+}
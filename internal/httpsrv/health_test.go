@@ -0,0 +1,253 @@
+package httpsrv_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.hollow.sh/toolbox/events"
+	"go.uber.org/zap"
+
+	dbm "go.hollow.sh/serverservice/db"
+	"go.hollow.sh/serverservice/internal/dbtools"
+	"go.hollow.sh/serverservice/internal/httpsrv"
+)
+
+var errCheckFailed = errors.New("check failed")
+
+type readinessReport struct {
+	Status string `json:"status"`
+	Checks map[string]struct {
+		Status   string `json:"status"`
+		Required bool   `json:"required"`
+		Latency  string `json:"latency"`
+		Error    string `json:"error"`
+	} `json:"checks"`
+}
+
+func readiness(t *testing.T, hs *httpsrv.Server, path string) (int, readinessReport) {
+	router := hs.NewServer().Handler
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequestWithContext(context.TODO(), "GET", path, nil)
+	router.ServeHTTP(w, req)
+
+	var report readinessReport
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+
+	return w.Code, report
+}
+
+func TestReadinessOptionalCheck(t *testing.T) {
+	hs := &httpsrv.Server{
+		Logger:     zap.NewNop(),
+		AuthConfig: serverAuthConfig,
+		HealthCheckers: []httpsrv.HealthChecker{
+			httpsrv.NewHealthChecker("ok", func(context.Context) error { return nil }),
+			httpsrv.NewHealthChecker("flaky", func(context.Context) error { return errCheckFailed }),
+		},
+		OptionalHealthChecks: []string{"flaky"},
+	}
+
+	code, report := readiness(t, hs, "/healthz/readiness")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "UP", report.Status)
+	assert.Empty(t, report.Checks, "checks are only reported in verbose mode")
+
+	code, report = readiness(t, hs, "/healthz/readiness?verbose=1")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "UP", report.Status)
+	require.Len(t, report.Checks, 2)
+
+	assert.Equal(t, "UP", report.Checks["ok"].Status)
+	assert.True(t, report.Checks["ok"].Required)
+	assert.NotEmpty(t, report.Checks["ok"].Latency)
+
+	assert.Equal(t, "DOWN", report.Checks["flaky"].Status)
+	assert.False(t, report.Checks["flaky"].Required)
+	assert.Equal(t, errCheckFailed.Error(), report.Checks["flaky"].Error)
+}
+
+func TestReadinessRequiredCheckDown(t *testing.T) {
+	hs := &httpsrv.Server{
+		Logger:     zap.NewNop(),
+		AuthConfig: serverAuthConfig,
+		HealthCheckers: []httpsrv.HealthChecker{
+			httpsrv.NewHealthChecker("ok", func(context.Context) error { return nil }),
+			httpsrv.NewStreamChecker(nil),
+		},
+	}
+
+	code, report := readiness(t, hs, "/healthz/readiness?verbose=true")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "DOWN", report.Status)
+	assert.Equal(t, "UP", report.Checks["ok"].Status)
+	assert.Equal(t, "DOWN", report.Checks[httpsrv.HealthCheckStream].Status)
+	assert.Equal(t, httpsrv.ErrStreamNotConnected.Error(), report.Checks[httpsrv.HealthCheckStream].Error)
+}
+
+// failingStream is an event stream whose publishes fail while err is set
+type failingStream struct {
+	events.Stream
+	err error
+}
+
+func (s *failingStream) Publish(context.Context, string, []byte) error { return s.err }
+
+func (s *failingStream) Close() error { return nil }
+
+func TestStreamChecker(t *testing.T) {
+	ctx := context.TODO()
+	stream := &failingStream{}
+	monitored := httpsrv.NewMonitoredStream(stream)
+	checker := httpsrv.NewStreamChecker(monitored)
+
+	assert.NoError(t, checker.Check(ctx), "the stream is up until a publish fails")
+
+	stream.err = errCheckFailed
+	assert.Error(t, monitored.Publish(ctx, "server.create", nil))
+	assert.ErrorIs(t, checker.Check(ctx), httpsrv.ErrStreamPublish)
+
+	stream.err = nil
+	assert.NoError(t, monitored.Publish(ctx, "server.create", nil))
+	assert.NoError(t, checker.Check(ctx), "a successful publish recovers the stream")
+
+	monitored.ErrorWindow = 10 * time.Millisecond
+	stream.err = errCheckFailed
+	assert.Error(t, monitored.Publish(ctx, "server.create", nil))
+	assert.ErrorIs(t, checker.Check(ctx), httpsrv.ErrStreamPublish)
+
+	time.Sleep(20 * time.Millisecond)
+	assert.NoError(t, checker.Check(ctx), "the stream recovers once the failed publish is out of the window")
+
+	require.NoError(t, monitored.Close())
+	assert.ErrorIs(t, checker.Check(ctx), httpsrv.ErrStreamPublish)
+	assert.ErrorIs(t, monitored.Err(), httpsrv.ErrStreamClosed)
+}
+
+// fakeConn is a NATS connection that is up while err is nil
+type fakeConn struct {
+	err error
+}
+
+func (c *fakeConn) IsConnected() bool { return c.err == nil }
+
+func (c *fakeConn) FlushWithContext(context.Context) error { return c.err }
+
+func TestStreamCheckerConn(t *testing.T) {
+	ctx := context.TODO()
+	conn := &fakeConn{}
+	monitored := httpsrv.NewMonitoredStream(&failingStream{})
+	monitored.Conn = conn
+	checker := httpsrv.NewStreamChecker(monitored)
+
+	assert.NoError(t, checker.Check(ctx))
+
+	conn.err = errCheckFailed
+	assert.ErrorIs(t, checker.Check(ctx), httpsrv.ErrStreamDisconnected, "a lost connection is reported without a publish")
+
+	conn.err = nil
+	assert.NoError(t, checker.Check(ctx), "the stream recovers once the connection is back")
+}
+
+// blockingStream is an event stream whose publishes return once release is closed
+type blockingStream struct {
+	events.Stream
+	started chan struct{}
+	release chan struct{}
+}
+
+func (s *blockingStream) Publish(context.Context, string, []byte) error {
+	close(s.started)
+	<-s.release
+
+	return nil
+}
+
+func (s *blockingStream) Close() error { return nil }
+
+// drainingConn is a NATS connection that is closed once it's drained
+type drainingConn struct {
+	fakeConn
+	mu      sync.Mutex
+	drained bool
+}
+
+func (c *drainingConn) Drain() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.drained = true
+
+	return nil
+}
+
+func (c *drainingConn) IsClosed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.drained
+}
+
+func TestMonitoredStreamDrain(t *testing.T) {
+	stream := &blockingStream{started: make(chan struct{}), release: make(chan struct{})}
+	conn := &drainingConn{}
+	monitored := httpsrv.NewMonitoredStream(stream)
+	monitored.Conn = conn
+
+	published := make(chan error)
+
+	go func() { published <- monitored.Publish(context.TODO(), "server.create", nil) }()
+
+	<-stream.started
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 20*time.Millisecond)
+	defer cancel()
+
+	assert.ErrorIs(t, monitored.Drain(ctx), httpsrv.ErrStreamDrain, "the drain waits for the publish in flight")
+	assert.False(t, conn.IsClosed(), "the connection isn't drained before the publishes")
+	assert.ErrorIs(t, monitored.Publish(context.TODO(), "server.create", nil), httpsrv.ErrStreamClosed, "a draining stream takes no publishes")
+
+	close(stream.release)
+	assert.NoError(t, <-published)
+
+	assert.NoError(t, monitored.Drain(context.TODO()))
+	assert.True(t, conn.IsClosed())
+	assert.NoError(t, monitored.Close())
+}
+
+func TestStreamCheckerNilConn(t *testing.T) {
+	var conn *nats.Conn
+
+	monitored := httpsrv.NewMonitoredStream(&failingStream{})
+	monitored.Conn = conn
+
+	assert.NoError(t, httpsrv.NewStreamChecker(monitored).Check(context.TODO()), "a connection that failed to connect isn't checked")
+}
+
+func TestMigrationsChecker(t *testing.T) {
+	db := dbtools.DatabaseTest(t)
+
+	migrations, err := fs.Sub(dbm.Migrations, "migrations")
+	require.NoError(t, err)
+
+	assert.NoError(t, httpsrv.NewMigrationsChecker(db, migrations).Check(context.TODO()))
+
+	pending := fstest.MapFS{
+		"00001_init.sql":            &fstest.MapFile{},
+		"99999_not_yet_applied.sql": &fstest.MapFile{},
+	}
+
+	err = httpsrv.NewMigrationsChecker(db, pending).Check(context.TODO())
+	assert.ErrorIs(t, err, httpsrv.ErrPendingMigrations)
+}
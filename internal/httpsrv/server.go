@@ -1,8 +1,15 @@
 package httpsrv
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
 	"net/http"
 	"os"
+	"strconv"
+	"sync/atomic"
 	"time"
 
 	"github.com/gin-contrib/cors"
@@ -16,28 +23,73 @@ import (
 	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 	"go.opentelemetry.io/otel"
 	"go.uber.org/zap"
-	"gocloud.dev/secrets"
 
+	"go.hollow.sh/serverservice/internal/dbtools"
+	"go.hollow.sh/serverservice/internal/fwverify"
+	"go.hollow.sh/serverservice/internal/metrics"
+	"go.hollow.sh/serverservice/internal/models"
 	v1api "go.hollow.sh/serverservice/pkg/api/v1"
 )
 
 // Server implements the HTTP Server
 type Server struct {
-	Logger        *zap.Logger
-	Listen        string
-	Debug         bool
-	DB            *sqlx.DB
-	AuthConfig    ginjwt.AuthConfig
-	SecretsKeeper *secrets.Keeper
-	EventStream   events.Stream
+	Logger      *zap.Logger
+	Listen      string
+	Debug       bool
+	DB          *sqlx.DB
+	AuthConfig  ginjwt.AuthConfig
+	Keyring     *dbtools.Keyring
+	EventStream events.Stream
+	// MetricsCollectInterval is how often the inventory metrics are collected from
+	// the database, zero disables the collector
+	MetricsCollectInterval time.Duration
+	// CredentialExpiryInterval is how often expired credentials are published to the
+	// event stream, zero disables the notifications
+	CredentialExpiryInterval time.Duration
+	// CredentialVersionsRetained is the number of previous values kept for each credential
+	CredentialVersionsRetained int
+	// FirmwareSetRevisions records a revision of a firmware set each time it changes
+	FirmwareSetRevisions bool
+	// FirmwareVerifyInterval is how often the firmware not verified within the interval
+	// has its artifact checksum verified with the FirmwareVerifier, zero disables it
+	FirmwareVerifyInterval time.Duration
+	FirmwareVerifier       *fwverify.Verifier
+	// ShutdownGracePeriod is how long in-flight requests are given to finish once
+	// the server is shutting down
+	ShutdownGracePeriod time.Duration
+	// ShutdownDelay is how long the server keeps serving requests while it reports that it
+	// isn't ready, so load balancers stop sending requests before the listener is closed
+	ShutdownDelay time.Duration
+	// TLS is used to serve TLS, and mTLS when a client CA is set, instead of plain HTTP
+	TLS TLSConfig
+	// Migrations are the goose sql migrations the database is expected to have applied,
+	// the readiness check fails while any are pending
+	Migrations fs.FS
+	// HealthCheckers are checked by the readiness check along with the builtin checks
+	// for the DB, keyring and migrations
+	HealthCheckers []HealthChecker
+	// OptionalHealthChecks are the names of checks that are reported but don't fail
+	// the readiness check
+	OptionalHealthChecks []string
+	// TrustedProxies are the addresses and CIDRs of the proxies whose X-Forwarded-For and
+	// X-Real-IP headers give the client address, the headers are ignored when it's empty
+	TrustedProxies []string
+
+	shuttingDown atomic.Bool
 }
 
 var (
 	readTimeout  = 10 * time.Second
 	writeTimeout = 20 * time.Second
 	corsMaxAge   = 12 * time.Hour
+
+	defaultShutdownGracePeriod = 30 * time.Second
 )
 
+// ErrShutdown is returned by Run when the in-flight requests didn't finish within the
+// shutdown grace period
+var ErrShutdown = errors.New("failed shutting down server")
+
 func (s *Server) setup() *gin.Engine {
 	var (
 		authMW *ginjwt.Middleware
@@ -52,6 +104,12 @@ func (s *Server) setup() *gin.Engine {
 	// Setup default gin router
 	r := gin.New()
 
+	// the client address is logged and recorded in the credential audit log, so it's only
+	// taken from the forwarded headers when they're set by a trusted proxy
+	if err := r.SetTrustedProxies(s.TrustedProxies); err != nil {
+		s.Logger.Sugar().Fatal("failed to set trusted proxies: ", "error", err)
+	}
+
 	r.Use(cors.New(cors.Config{
 		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD"},
 		AllowHeaders:     []string{"Origin", "Content-Length", "Content-Type", "Authorization"},
@@ -63,11 +121,17 @@ func (s *Server) setup() *gin.Engine {
 	p := ginprometheus.NewPrometheus("gin")
 
 	v1Rtr := v1api.Router{
-		DB:            s.DB,
-		AuthMW:        authMW,
-		SecretsKeeper: s.SecretsKeeper,
-		Logger:        s.Logger,
-		EventStream:   s.EventStream,
+		DB:          s.DB,
+		AuthMW:      authMW,
+		Keyring:     s.Keyring,
+		Logger:      s.Logger,
+		EventStream: s.EventStream,
+		// the client certificates are verified against the client CAs by the tls config
+		ClientCertificateScopes:    s.TLS.ClientScopes,
+		ExtendWriteDeadline:        extendWriteDeadline,
+		ExtendReadDeadline:         extendReadDeadline,
+		CredentialVersionsRetained: s.CredentialVersionsRetained,
+		FirmwareSetRevisions:       s.FirmwareSetRevisions,
 	}
 
 	// Remove any params from the URL string to keep the number of labels down
@@ -82,6 +146,9 @@ func (s *Server) setup() *gin.Engine {
 		ginzap.WithCustomFields(
 			func(c *gin.Context) zap.Field { return zap.String("jwt_subject", ginjwt.GetSubject(c)) },
 			func(c *gin.Context) zap.Field { return zap.String("jwt_user", ginjwt.GetUser(c)) },
+			func(c *gin.Context) zap.Field {
+				return zap.String("tls_client_subject", v1api.ClientCertificateSubject(c))
+			},
 		),
 	))
 	r.Use(ginzap.RecoveryWithZap(s.Logger.With(zap.String("component", "httpsrv")), true))
@@ -127,16 +194,182 @@ func (s *Server) NewServer() *http.Server {
 		Addr:         s.Listen,
 		ReadTimeout:  readTimeout,
 		WriteTimeout: writeTimeout,
+		// lets streamed responses and large requests extend the timeouts
+		ConnContext: connContext,
 	}
 }
 
-// Run will start the server listening on the specified address
-func (s *Server) Run() error {
-	if !s.Debug {
-		gin.SetMode(gin.ReleaseMode)
+type connContextKey struct{}
+
+// connContext stores the connection in the request context so long running responses
+// can extend the write deadline of the server
+func connContext(ctx context.Context, conn net.Conn) context.Context {
+	return context.WithValue(ctx, connContextKey{}, conn)
+}
+
+// extendWriteDeadline pushes back the write deadline of the connection of the request,
+// it's a no-op when the connection isn't known
+func extendWriteDeadline(req *http.Request, d time.Duration) {
+	if conn, ok := req.Context().Value(connContextKey{}).(net.Conn); ok {
+		_ = conn.SetWriteDeadline(time.Now().Add(d))
 	}
+}
 
-	return s.setup().Run(s.Listen)
+// extendReadDeadline pushes back the read deadline of the connection of the request,
+// it's a no-op when the connection isn't known
+func extendReadDeadline(req *http.Request, d time.Duration) {
+	if conn, ok := req.Context().Value(connContextKey{}).(net.Conn); ok {
+		_ = conn.SetReadDeadline(time.Now().Add(d))
+	}
+}
+
+// Run will start the server listening on the specified address and serve requests
+// until the context is canceled. The server then reports that it isn't ready, keeps
+// serving for the shutdown delay, and waits up to the shutdown grace period for
+// in-flight requests to finish. An error from
+// the shutdown wraps ErrShutdown, any other error means the server couldn't listen.
+func (s *Server) Run(ctx context.Context) error {
+	srv := s.NewServer()
+
+	if s.TLS.Enabled() {
+		reloader, err := newCertReloader(s.TLS, s.Logger)
+		if err != nil {
+			return err
+		}
+
+		srv.TLSConfig = reloader.tlsConfig()
+
+		go func() {
+			if err := reloader.watch(ctx); err != nil {
+				s.Logger.Error("failed to watch tls certificates, changes won't be reloaded", zap.Error(err))
+			}
+		}()
+	} else if len(s.TLS.ClientScopes) != 0 {
+		return fmt.Errorf("%w: client certificate scopes require TLS with a client CA", ErrTLSConfig)
+	}
+
+	if s.CredentialExpiryInterval > 0 && s.EventStream != nil {
+		go s.notifyExpiredCredentials(ctx)
+	}
+
+	if s.FirmwareVerifyInterval > 0 && s.FirmwareVerifier != nil && s.DB != nil {
+		go s.verifyFirmware(ctx)
+	}
+
+	if s.MetricsCollectInterval > 0 && s.DB != nil {
+		collector := &metrics.Collector{DB: s.DB, Logger: s.Logger}
+		go collector.Run(ctx, s.MetricsCollectInterval)
+	}
+
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(errCh)
+
+		if err := s.listenAndServe(srv); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errCh <- err
+		}
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	s.shuttingDown.Store(true)
+
+	if s.ShutdownDelay > 0 {
+		s.Logger.Info("waiting before shutting down server", zap.Duration("delay", s.ShutdownDelay))
+		time.Sleep(s.ShutdownDelay)
+	}
+
+	gracePeriod := s.ShutdownGracePeriod
+	if gracePeriod <= 0 {
+		gracePeriod = defaultShutdownGracePeriod
+	}
+
+	s.Logger.Info("shutting down server", zap.Duration("grace_period", gracePeriod))
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), gracePeriod)
+	defer cancel()
+
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("%w: %s", ErrShutdown, err)
+	}
+
+	return <-errCh
+}
+
+func (s *Server) listenAndServe(srv *http.Server) error {
+	if srv.TLSConfig != nil {
+		// the certificates are provided by the tls config
+		return srv.ListenAndServeTLS("", "")
+	}
+
+	return srv.ListenAndServe()
+}
+
+// notifyExpiredCredentials periodically publishes the credentials that are due for rotation
+func (s *Server) notifyExpiredCredentials(ctx context.Context) {
+	rtr := v1api.Router{
+		DB:          s.DB,
+		Logger:      s.Logger,
+		EventStream: s.EventStream,
+	}
+
+	ticker := time.NewTicker(s.CredentialExpiryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			notified, err := rtr.NotifyExpiredCredentials(ctx)
+			if err != nil {
+				s.Logger.Error("failed to notify expired credentials", zap.Error(err))
+			}
+
+			if notified > 0 {
+				s.Logger.Info("notified expired credentials", zap.Int("count", notified))
+			}
+		}
+	}
+}
+
+// verifyFirmware periodically verifies the checksum of the firmware artifacts that
+// haven't been verified within the interval
+func (s *Server) verifyFirmware(ctx context.Context) {
+	ticker := time.NewTicker(s.FirmwareVerifyInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			stats, err := dbtools.VerifyFirmware(ctx, s.DB, s.FirmwareVerifier, dbtools.FirmwareVerifyOptions{
+				VerifiedBefore: time.Now().Add(-s.FirmwareVerifyInterval),
+				Report: func(f *models.ComponentFirmwareVersion, status string, err error) {
+					if err != nil {
+						s.Logger.Warn("firmware verification "+status, zap.String("id", f.ID), zap.Error(err))
+					}
+				},
+			})
+			if err != nil {
+				s.Logger.Error("failed to verify firmware", zap.Error(err))
+			}
+
+			if stats.Mismatched > 0 || stats.Failed > 0 {
+				s.Logger.Warn("firmware verification found artifacts that don't match",
+					zap.Int("verified", stats.Verified),
+					zap.Int("mismatched", stats.Mismatched),
+					zap.Int("failed", stats.Failed),
+				)
+			}
+		}
+	}
 }
 
 // livenessCheck ensures that the server is up and responding
@@ -146,21 +379,43 @@ func (s *Server) livenessCheck(c *gin.Context) {
 	})
 }
 
-// readinessCheck ensures that the server is up and that we are able to process
-// requests. Currently our only dependency is the DB so we just ensure that is
-// responding.
+// readinessCheck ensures that the server is up, isn't shutting down and that the
+// required health checks pass. With the verbose param the result of each check
+// is included in the response.
 func (s *Server) readinessCheck(c *gin.Context) {
-	if err := s.DB.PingContext(c.Request.Context()); err != nil {
-		s.Logger.Sugar().Errorf("readiness check db ping failed", "err", err)
+	if s.shuttingDown.Load() {
 		c.JSON(http.StatusServiceUnavailable, gin.H{
-			"status": "DOWN",
+			"status": healthStatusDown,
 		})
 
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"status": "UP",
+	healthy, results := s.runHealthChecks(c.Request.Context())
+
+	status, code := healthStatusUp, http.StatusOK
+
+	if !healthy {
+		status, code = healthStatusDown, http.StatusServiceUnavailable
+
+		for name, result := range results {
+			if result.Status == healthStatusDown {
+				s.Logger.Error("readiness check failed", zap.String("check", name), zap.String("error", result.Error))
+			}
+		}
+	}
+
+	if verbose, _ := strconv.ParseBool(c.Query("verbose")); verbose {
+		c.JSON(code, gin.H{
+			"status": status,
+			"checks": results,
+		})
+
+		return
+	}
+
+	c.JSON(code, gin.H{
+		"status": status,
 	})
 }
 
@@ -2,14 +2,18 @@ package httpsrv_test
 
 import (
 	"context"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.hollow.sh/toolbox/ginjwt"
 	"go.uber.org/zap"
+	"gocloud.dev/secrets"
 
 	"go.hollow.sh/serverservice/internal/dbtools"
 	"go.hollow.sh/serverservice/internal/httpsrv"
@@ -76,7 +80,7 @@ func TestReadinessRouteDown(t *testing.T) {
 func TestReadinessRouteUp(t *testing.T) {
 	db := dbtools.DatabaseTest(t)
 
-	hs := httpsrv.Server{Logger: zap.NewNop(), AuthConfig: serverAuthConfig, DB: db}
+	hs := httpsrv.Server{Logger: zap.NewNop(), AuthConfig: serverAuthConfig, DB: db, Keyring: dbtools.TestKeyring(t)}
 	s := hs.NewServer()
 	router := s.Handler
 
@@ -87,3 +91,130 @@ func TestReadinessRouteUp(t *testing.T) {
 	assert.Equal(t, 200, w.Code)
 	assert.Equal(t, `{"status":"UP"}`, w.Body.String())
 }
+
+func TestReadinessRouteKeyringDown(t *testing.T) {
+	db := dbtools.DatabaseTest(t)
+
+	keeper, err := secrets.OpenKeeper(context.TODO(), "base64key://")
+	require.NoError(t, err)
+
+	keyring, err := dbtools.NewKeyring("", keeper)
+	require.NoError(t, err)
+
+	// a closed keyring fails to encrypt the check value
+	keyring.Close()
+
+	hs := httpsrv.Server{Logger: zap.NewNop(), AuthConfig: serverAuthConfig, DB: db, Keyring: keyring}
+	s := hs.NewServer()
+	router := s.Handler
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequestWithContext(context.TODO(), "GET", "/healthz/readiness", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, 503, w.Code)
+	assert.Equal(t, `{"status":"DOWN"}`, w.Body.String())
+}
+
+func TestRunShutdown(t *testing.T) {
+	hs := httpsrv.Server{
+		Logger:              zap.NewNop(),
+		AuthConfig:          serverAuthConfig,
+		Listen:              "127.0.0.1:0",
+		ShutdownGracePeriod: time.Second,
+	}
+
+	ctx, cancel := context.WithCancel(context.TODO())
+
+	errCh := make(chan error, 1)
+
+	go func() {
+		errCh <- hs.Run(ctx)
+	}()
+
+	cancel()
+
+	select {
+	case err := <-errCh:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("server didn't shut down")
+	}
+}
+
+func TestRunShutdownDelay(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	addr := l.Addr().String()
+	require.NoError(t, l.Close())
+
+	hs := httpsrv.Server{
+		Logger:              zap.NewNop(),
+		AuthConfig:          serverAuthConfig,
+		Listen:              addr,
+		ShutdownGracePeriod: time.Second,
+		ShutdownDelay:       time.Second,
+	}
+
+	ctx, cancel := context.WithCancel(context.TODO())
+
+	errCh := make(chan error, 1)
+
+	go func() {
+		errCh <- hs.Run(ctx)
+	}()
+
+	get := func(path string) (int, error) {
+		req, _ := http.NewRequestWithContext(context.TODO(), "GET", "http://"+addr+path, nil)
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return 0, err
+		}
+
+		resp.Body.Close()
+
+		return resp.StatusCode, nil
+	}
+
+	require.Eventually(t, func() bool {
+		_, err := get("/healthz/liveness")
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+
+	// requests are still served during the delay, but the server isn't ready
+	require.Eventually(t, func() bool {
+		code, err := get("/healthz/readiness")
+		return err == nil && code == http.StatusServiceUnavailable
+	}, 500*time.Millisecond, 10*time.Millisecond)
+
+	select {
+	case err := <-errCh:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("server didn't shut down")
+	}
+}
+
+func TestRunClientScopesWithoutTLS(t *testing.T) {
+	hs := httpsrv.Server{
+		Logger:     zap.NewNop(),
+		AuthConfig: serverAuthConfig,
+		Listen:     "127.0.0.1:0",
+		TLS:        httpsrv.TLSConfig{ClientScopes: map[string][]string{"CN=client": {"read:server"}}},
+	}
+
+	err := hs.Run(context.TODO())
+	assert.ErrorIs(t, err, httpsrv.ErrTLSConfig)
+}
+
+func TestRunListenError(t *testing.T) {
+	hs := httpsrv.Server{Logger: zap.NewNop(), AuthConfig: serverAuthConfig, Listen: "invalid-address"}
+
+	err := hs.Run(context.TODO())
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, httpsrv.ErrShutdown, "a listen error isn't a shutdown error")
+}
@@ -0,0 +1,179 @@
+package httpsrv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"go.hollow.sh/toolbox/events"
+)
+
+var (
+	// ErrStreamClosed is returned by a monitored stream once it's closed or draining
+	ErrStreamClosed = errors.New("event stream closed")
+	// ErrStreamDrain is returned when the stream doesn't finish draining in time
+	ErrStreamDrain = errors.New("event stream didn't drain")
+)
+
+// streamDrainPoll is how often a draining connection is checked for being closed
+const streamDrainPoll = 10 * time.Millisecond
+
+// DefaultStreamErrorWindow is how long a failed publish is reported when the
+// stream doesn't set an ErrorWindow
+const DefaultStreamErrorWindow = time.Minute
+
+// StreamConn is a NATS connection to the servers the stream publishes to, *nats.Conn
+// implements it
+type StreamConn interface {
+	IsConnected() bool
+	FlushWithContext(ctx context.Context) error
+}
+
+// drainConn is a connection that can be drained, *nats.Conn implements it
+type drainConn interface {
+	Drain() error
+	IsClosed() bool
+}
+
+// MonitoredStream is an event stream that keeps the result of its last publish, so the
+// stream health check reports the state of the stream rather than of the NATS servers
+type MonitoredStream struct {
+	events.Stream
+	// ErrorWindow is how long a failed publish is reported when no publish succeeds
+	// after it. An unready replica gets no requests, so it may not publish again for
+	// a long time, and it shouldn't stay unready because of an old failure.
+	ErrorWindow time.Duration
+	// Conn is checked by the stream health check when it's set, so a lost connection
+	// is reported while nothing is published
+	Conn StreamConn
+
+	mu          sync.RWMutex
+	err         error
+	publishedAt time.Time
+	closed      bool
+	inflight    sync.WaitGroup
+}
+
+// NewMonitoredStream returns a MonitoredStream that publishes to stream
+func NewMonitoredStream(stream events.Stream) *MonitoredStream {
+	return &MonitoredStream{Stream: stream}
+}
+
+// Publish publishes the message and records if it failed. It returns ErrStreamClosed
+// once the stream is closed or draining.
+func (s *MonitoredStream) Publish(ctx context.Context, subject string, msg []byte) error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return ErrStreamClosed
+	}
+
+	// added under the lock, so Drain doesn't wait while a publish is being started
+	s.inflight.Add(1)
+	s.mu.Unlock()
+
+	defer s.inflight.Done()
+
+	err := s.Stream.Publish(ctx, subject, msg)
+
+	s.mu.Lock()
+	s.err = err
+	s.publishedAt = time.Now()
+	s.mu.Unlock()
+
+	return err
+}
+
+// Drain stops taking publishes and waits for the publishes in flight to return. Then it
+// drains the connection of the stream, when it has one, and waits for it to close. It
+// returns ErrStreamDrain when that isn't done before the context is. The toolbox stream
+// doesn't expose its NATS connection, so the publishes are drained here rather than by
+// draining that connection. The stream still has to be closed afterwards.
+func (s *MonitoredStream) Drain(ctx context.Context) error {
+	s.mu.Lock()
+	s.closed = true
+	s.mu.Unlock()
+
+	published := make(chan struct{})
+
+	go func() {
+		s.inflight.Wait()
+		close(published)
+	}()
+
+	select {
+	case <-published:
+	case <-ctx.Done():
+		return fmt.Errorf("%w: waiting for publishes: %s", ErrStreamDrain, ctx.Err())
+	}
+
+	conn, ok := s.conn().(drainConn)
+	if !ok {
+		return nil
+	}
+
+	if err := conn.Drain(); err != nil {
+		return fmt.Errorf("%w: %s", ErrStreamDrain, err)
+	}
+
+	ticker := time.NewTicker(streamDrainPoll)
+	defer ticker.Stop()
+
+	for !conn.IsClosed() {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return fmt.Errorf("%w: waiting for the connection: %s", ErrStreamDrain, ctx.Err())
+		}
+	}
+
+	return nil
+}
+
+// Close closes the stream, it's reported as down afterwards
+func (s *MonitoredStream) Close() error {
+	s.mu.Lock()
+	s.closed = true
+	s.mu.Unlock()
+
+	return s.Stream.Close()
+}
+
+// conn returns the connection of the stream, or nil when it has none. A nil pointer
+// stored in Conn, like the *nats.Conn of a failed connect, counts as no connection.
+func (s *MonitoredStream) conn() StreamConn {
+	if s.Conn == nil {
+		return nil
+	}
+
+	if v := reflect.ValueOf(s.Conn); v.Kind() == reflect.Ptr && v.IsNil() {
+		return nil
+	}
+
+	return s.Conn
+}
+
+// Err returns ErrStreamClosed once the stream is closed, otherwise the error of the last
+// publish when it failed within the error window. It's nil until a publish fails.
+func (s *MonitoredStream) Err() error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return ErrStreamClosed
+	}
+
+	window := s.ErrorWindow
+	if window <= 0 {
+		window = DefaultStreamErrorWindow
+	}
+
+	if s.err == nil || time.Since(s.publishedAt) > window {
+		return nil
+	}
+
+	return s.err
+}
@@ -0,0 +1,221 @@
+package httpsrv
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrTLSConfig is returned when the TLS settings are incomplete
+	ErrTLSConfig = errors.New("invalid tls config")
+	// ErrNoClientCAs is returned when the client CA file has no certificates
+	ErrNoClientCAs = errors.New("no certificates found in client ca file")
+	// ErrNoClientCertificate is returned when a client doesn't present a certificate in mTLS mode
+	ErrNoClientCertificate = errors.New("no client certificate provided")
+)
+
+// TLSConfig holds the files used to serve TLS. When ClientCAFile is set clients
+// must present a certificate signed by one of its CAs.
+type TLSConfig struct {
+	CertFile     string
+	KeyFile      string
+	ClientCAFile string
+	// ClientScopes authorizes requests made with a client certificate with the scopes
+	// of its subject, instead of a JWT
+	ClientScopes map[string][]string
+}
+
+// Enabled returns true when the server should serve TLS
+func (c TLSConfig) Enabled() bool {
+	return c.CertFile != "" || c.KeyFile != "" || c.ClientCAFile != ""
+}
+
+func (c TLSConfig) validate() error {
+	if c.CertFile == "" || c.KeyFile == "" {
+		return fmt.Errorf("%w: both a certificate and a key are required", ErrTLSConfig)
+	}
+
+	if len(c.ClientScopes) != 0 && c.ClientCAFile == "" {
+		return fmt.Errorf("%w: client certificate scopes require a client CA", ErrTLSConfig)
+	}
+
+	return nil
+}
+
+// certReloader keeps the certificate and client CAs loaded from the TLS files
+// and reloads them when the files change
+type certReloader struct {
+	cfg    TLSConfig
+	logger *zap.Logger
+
+	mu        sync.RWMutex
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+}
+
+func newCertReloader(cfg TLSConfig, logger *zap.Logger) (*certReloader, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
+	r := &certReloader{cfg: cfg, logger: logger}
+
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// reload reads the TLS files, the current certificate is kept if they can't be loaded
+func (r *certReloader) reload() error {
+	cert, err := tls.LoadX509KeyPair(r.cfg.CertFile, r.cfg.KeyFile)
+	if err != nil {
+		return err
+	}
+
+	var clientCAs *x509.CertPool
+
+	if r.cfg.ClientCAFile != "" {
+		pem, err := os.ReadFile(r.cfg.ClientCAFile)
+		if err != nil {
+			return err
+		}
+
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(pem) {
+			return ErrNoClientCAs
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cert = &cert
+	r.clientCAs = clientCAs
+
+	return nil
+}
+
+// tlsConfig returns a tls.Config that always uses the latest loaded certificate and client CAs
+func (r *certReloader) tlsConfig() *tls.Config {
+	cfg := &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: r.getCertificate,
+	}
+
+	// the client CAs can change, so the chain is verified against the current
+	// pool rather than a pool fixed in the config
+	if r.cfg.ClientCAFile != "" {
+		cfg.ClientAuth = tls.RequireAnyClientCert
+		cfg.VerifyPeerCertificate = r.verifyClientCertificate
+	}
+
+	return cfg
+}
+
+func (r *certReloader) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.cert, nil
+}
+
+func (r *certReloader) verifyClientCertificate(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+	certs := make([]*x509.Certificate, 0, len(rawCerts))
+
+	for _, raw := range rawCerts {
+		cert, err := x509.ParseCertificate(raw)
+		if err != nil {
+			return err
+		}
+
+		certs = append(certs, cert)
+	}
+
+	if len(certs) == 0 {
+		return ErrNoClientCertificate
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	r.mu.RLock()
+	roots := r.clientCAs
+	r.mu.RUnlock()
+
+	_, err := certs[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+
+	return err
+}
+
+// watch reloads the TLS files whenever they change until the context is canceled.
+// The directories are watched rather than the files so that files replaced by a
+// rename, like mounted kubernetes secrets, are picked up.
+func (r *certReloader) watch(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+
+	defer watcher.Close()
+
+	files := map[string]bool{}
+
+	for _, f := range []string{r.cfg.CertFile, r.cfg.KeyFile, r.cfg.ClientCAFile} {
+		if f == "" {
+			continue
+		}
+
+		files[filepath.Clean(f)] = true
+
+		if err := watcher.Add(filepath.Dir(f)); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+
+			// kubernetes swaps a ..data symlink in the directory, so reload on any
+			// event for a watched file or one that isn't a regular file name
+			if !files[filepath.Clean(event.Name)] && filepath.Base(event.Name)[0] != '.' {
+				continue
+			}
+
+			if err := r.reload(); err != nil {
+				r.logger.Error("failed to reload tls certificates", zap.Error(err))
+				continue
+			}
+
+			r.logger.Info("reloaded tls certificates", zap.String("event", event.String()))
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+
+			r.logger.Error("tls certificate watcher error", zap.Error(err))
+		}
+	}
+}
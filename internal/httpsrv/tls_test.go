@@ -0,0 +1,227 @@
+package httpsrv
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	v1api "go.hollow.sh/serverservice/pkg/api/v1"
+)
+
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCert(t *testing.T, cn string, parent *testCert, usage x509.ExtKeyUsage) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+
+	signer, signerKey := tmpl, key
+
+	if parent == nil {
+		tmpl.IsCA = true
+		tmpl.BasicConstraintsValid = true
+		tmpl.KeyUsage = x509.KeyUsageCertSign
+		tmpl.ExtKeyUsage = nil
+	} else {
+		signer, signerKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, signer, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return &testCert{cert: cert, key: key}
+}
+
+func (c *testCert) certPEM() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.cert.Raw})
+}
+
+func (c *testCert) write(t *testing.T, dir, name string) (string, string) {
+	keyDER, err := x509.MarshalECPrivateKey(c.key)
+	require.NoError(t, err)
+
+	certFile := filepath.Join(dir, name+".crt")
+	keyFile := filepath.Join(dir, name+".key")
+
+	require.NoError(t, os.WriteFile(certFile, c.certPEM(), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+
+	return certFile, keyFile
+}
+
+func (c *testCert) tlsCertificate() tls.Certificate {
+	return tls.Certificate{Certificate: [][]byte{c.cert.Raw}, PrivateKey: c.key}
+}
+
+func newTLSTestServer(t *testing.T, cfg TLSConfig) (*httptest.Server, *certReloader) {
+	reloader, err := newCertReloader(cfg, zap.NewNop())
+	require.NoError(t, err)
+
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.GET("/subject", func(c *gin.Context) {
+		c.String(http.StatusOK, v1api.ClientCertificateSubject(c))
+	})
+
+	// StartTLS would replace the certificate with its own, wrap the listener instead
+	ts := httptest.NewUnstartedServer(r)
+	ts.Listener = tls.NewListener(ts.Listener, reloader.tlsConfig())
+	ts.Start()
+	ts.URL = strings.Replace(ts.URL, "http://", "https://", 1)
+
+	t.Cleanup(ts.Close)
+
+	return ts, reloader
+}
+
+func tlsClient(ca *testCert, cert *testCert) *http.Client {
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+
+	cfg := &tls.Config{RootCAs: roots, MinVersion: tls.VersionTLS12}
+
+	if cert != nil {
+		cfg.Certificates = []tls.Certificate{cert.tlsCertificate()}
+	}
+
+	// don't reuse connections so each request makes a new handshake
+	return &http.Client{Transport: &http.Transport{TLSClientConfig: cfg, DisableKeepAlives: true}}
+}
+
+func get(t *testing.T, client *http.Client, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(context.TODO(), http.MethodGet, url+"/subject", nil)
+	require.NoError(t, err)
+
+	return client.Do(req)
+}
+
+func TestTLSConfigValidate(t *testing.T) {
+	assert.False(t, TLSConfig{}.Enabled())
+	assert.True(t, TLSConfig{ClientCAFile: "ca.pem"}.Enabled())
+
+	_, err := newCertReloader(TLSConfig{CertFile: "server.crt"}, zap.NewNop())
+	assert.ErrorIs(t, err, ErrTLSConfig)
+
+	err = TLSConfig{CertFile: "server.crt", KeyFile: "server.key", ClientScopes: map[string][]string{"CN=client": {"read"}}}.validate()
+	assert.ErrorIs(t, err, ErrTLSConfig, "client certificate scopes need mutual TLS")
+}
+
+func TestMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+
+	ca := newTestCert(t, "test-ca", nil, 0)
+	certFile, keyFile := newTestCert(t, "server", ca, x509.ExtKeyUsageServerAuth).write(t, dir, "server")
+
+	caFile := filepath.Join(dir, "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, ca.certPEM(), 0o600))
+
+	ts, _ := newTLSTestServer(t, TLSConfig{CertFile: certFile, KeyFile: keyFile, ClientCAFile: caFile})
+
+	t.Run("client certificate subject is exposed", func(t *testing.T) {
+		client := newTestCert(t, "test-client", ca, x509.ExtKeyUsageClientAuth)
+
+		resp, err := get(t, tlsClient(ca, client), ts.URL)
+		require.NoError(t, err)
+
+		defer resp.Body.Close()
+
+		body := make([]byte, 64)
+		n, _ := resp.Body.Read(body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "CN=test-client", string(body[:n]))
+	})
+
+	t.Run("client without a certificate is refused", func(t *testing.T) {
+		resp, err := get(t, tlsClient(ca, nil), ts.URL)
+		if err == nil {
+			resp.Body.Close()
+		}
+
+		assert.Error(t, err)
+	})
+
+	t.Run("client certificate from another CA is refused", func(t *testing.T) {
+		other := newTestCert(t, "other-ca", nil, 0)
+		client := newTestCert(t, "test-client", other, x509.ExtKeyUsageClientAuth)
+
+		resp, err := get(t, tlsClient(ca, client), ts.URL)
+		if err == nil {
+			resp.Body.Close()
+		}
+
+		assert.Error(t, err)
+	})
+}
+
+func TestCertReload(t *testing.T) {
+	dir := t.TempDir()
+
+	oldCA := newTestCert(t, "old-ca", nil, 0)
+	newCA := newTestCert(t, "new-ca", nil, 0)
+
+	certFile, keyFile := newTestCert(t, "server", oldCA, x509.ExtKeyUsageServerAuth).write(t, dir, "server")
+
+	ts, reloader := newTLSTestServer(t, TLSConfig{CertFile: certFile, KeyFile: keyFile})
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+
+	go func() {
+		_ = reloader.watch(ctx)
+	}()
+
+	resp, err := get(t, tlsClient(oldCA, nil), ts.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	// give the watcher time to start before the files change
+	time.Sleep(100 * time.Millisecond)
+
+	newTestCert(t, "server", newCA, x509.ExtKeyUsageServerAuth).write(t, dir, "server")
+
+	assert.Eventually(t, func() bool {
+		resp, err := get(t, tlsClient(newCA, nil), ts.URL)
+		if err != nil {
+			return false
+		}
+
+		resp.Body.Close()
+
+		return true
+	}, 5*time.Second, 50*time.Millisecond)
+}
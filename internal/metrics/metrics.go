@@ -0,0 +1,153 @@
+// Package metrics provides the prometheus metrics about the inventory stored
+// in the serverservice and the events it publishes.
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+)
+
+const namespace = "serverservice"
+
+var (
+	// EventsPublished counts the messages published to the event stream by subject
+	EventsPublished = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "events_published_total",
+		Help:      "Number of messages published to the event stream.",
+	}, []string{"subject"})
+
+	// EventsFailed counts the messages that failed to publish to the event stream by subject
+	EventsFailed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "events_failed_total",
+		Help:      "Number of messages that failed to publish to the event stream.",
+	}, []string{"subject"})
+
+	servers = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "servers",
+		Help:      "Number of servers by facility, excluding deleted servers.",
+	}, []string{"facility"})
+
+	serversDeleted = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "servers_deleted",
+		Help:      "Number of soft deleted servers.",
+	})
+
+	serverComponents = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "server_components",
+		Help:      "Number of server components by component type.",
+	}, []string{"type"})
+
+	firmwareVersions = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "firmware_versions",
+		Help:      "Number of component firmware versions by vendor.",
+	}, []string{"vendor"})
+
+	serverCredentials = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "server_credentials",
+		Help:      "Number of server credentials by credential type.",
+	}, []string{"type"})
+
+	versionedAttributes = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "versioned_attributes",
+		Help:      "Number of versioned attribute rows.",
+	})
+
+	collectErrors = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "metrics_collect_errors_total",
+		Help:      "Number of times collecting the inventory metrics failed.",
+	})
+)
+
+// labeledCount is a row of a count query grouped by a label
+type labeledCount struct {
+	Label string  `db:"label"`
+	Count float64 `db:"count"`
+}
+
+// gaugeQuery sets the gauge vector from a query returning label and count columns
+type gaugeQuery struct {
+	gauge *prometheus.GaugeVec
+	query string
+}
+
+var gaugeQueries = []gaugeQuery{
+	{servers, `SELECT COALESCE(facility_code, '') AS label, count(*) AS count FROM servers WHERE deleted_at IS NULL GROUP BY facility_code`},
+	{serverComponents, `SELECT t.slug AS label, count(*) AS count FROM server_components AS c
+		INNER JOIN server_component_types AS t ON t.id = c.server_component_type_id GROUP BY t.slug`},
+	{firmwareVersions, `SELECT vendor AS label, count(*) AS count FROM component_firmware_version GROUP BY vendor`},
+	{serverCredentials, `SELECT t.slug AS label, count(*) AS count FROM server_credentials AS c
+		INNER JOIN server_credential_types AS t ON t.id = c.server_credential_type_id GROUP BY t.slug`},
+}
+
+// Collector periodically counts the inventory in the database and exports the
+// counts as gauges
+type Collector struct {
+	DB     *sqlx.DB
+	Logger *zap.Logger
+}
+
+// Run collects the metrics every interval until the context is canceled
+func (c *Collector) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.Collect(ctx); err != nil {
+			collectErrors.Inc()
+			c.Logger.Error("failed to collect inventory metrics", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Collect counts the inventory in the database and updates the gauges
+func (c *Collector) Collect(ctx context.Context) error {
+	for _, q := range gaugeQueries {
+		rows := []labeledCount{}
+		if err := c.DB.SelectContext(ctx, &rows, q.query); err != nil {
+			return err
+		}
+
+		// labels that no longer have any rows are dropped
+		q.gauge.Reset()
+
+		for _, row := range rows {
+			q.gauge.WithLabelValues(row.Label).Set(row.Count)
+		}
+	}
+
+	var count float64
+
+	if err := c.DB.GetContext(ctx, &count, `SELECT count(*) FROM servers WHERE deleted_at IS NOT NULL`); err != nil {
+		return err
+	}
+
+	serversDeleted.Set(count)
+
+	if err := c.DB.GetContext(ctx, &count, `SELECT count(*) FROM versioned_attributes`); err != nil {
+		return err
+	}
+
+	versionedAttributes.Set(count)
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package metrics_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.hollow.sh/serverservice/internal/dbtools"
+	"go.hollow.sh/serverservice/internal/metrics"
+)
+
+func TestCollect(t *testing.T) {
+	c := &metrics.Collector{DB: dbtools.DatabaseTest(t), Logger: zap.NewNop()}
+
+	require.NoError(t, c.Collect(context.TODO()))
+
+	expected := `
+# HELP serverservice_servers Number of servers by facility, excluding deleted servers.
+# TYPE serverservice_servers gauge
+serverservice_servers{facility="Ocean"} 2
+serverservice_servers{facility="Sydney"} 1
+# HELP serverservice_servers_deleted Number of soft deleted servers.
+# TYPE serverservice_servers_deleted gauge
+serverservice_servers_deleted 1
+`
+
+	err := testutil.GatherAndCompare(prometheus.DefaultGatherer, strings.NewReader(expected), "serverservice_servers", "serverservice_servers_deleted")
+	assert.NoError(t, err)
+}
+
+func TestEventCounters(t *testing.T) {
+	before := testutil.ToFloat64(metrics.EventsPublished.WithLabelValues("test.subject"))
+
+	metrics.EventsPublished.WithLabelValues("test.subject").Inc()
+
+	assert.Equal(t, before+1, testutil.ToFloat64(metrics.EventsPublished.WithLabelValues("test.subject")))
+}
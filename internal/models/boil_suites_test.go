@@ -16,10 +16,13 @@ func TestParent(t *testing.T) {
 	t.Run("AttributesFirmwareSets", testAttributesFirmwareSets)
 	t.Run("ComponentFirmwareSets", testComponentFirmwareSets)
 	t.Run("ComponentFirmwareSetMaps", testComponentFirmwareSetMaps)
+	t.Run("ComponentFirmwareSetRevisions", testComponentFirmwareSetRevisions)
 	t.Run("ComponentFirmwareVersions", testComponentFirmwareVersions)
+	t.Run("CredentialAccessEvents", testCredentialAccessEvents)
 	t.Run("ServerComponentTypes", testServerComponentTypes)
 	t.Run("ServerComponents", testServerComponents)
 	t.Run("ServerCredentialTypes", testServerCredentialTypes)
+	t.Run("ServerCredentialVersions", testServerCredentialVersions)
 	t.Run("ServerCredentials", testServerCredentials)
 	t.Run("Servers", testServers)
 	t.Run("VersionedAttributes", testVersionedAttributes)
@@ -42,10 +45,13 @@ func TestDelete(t *testing.T) {
 	t.Run("AttributesFirmwareSets", testAttributesFirmwareSetsDelete)
 	t.Run("ComponentFirmwareSets", testComponentFirmwareSetsDelete)
 	t.Run("ComponentFirmwareSetMaps", testComponentFirmwareSetMapsDelete)
+	t.Run("ComponentFirmwareSetRevisions", testComponentFirmwareSetRevisionsDelete)
 	t.Run("ComponentFirmwareVersions", testComponentFirmwareVersionsDelete)
+	t.Run("CredentialAccessEvents", testCredentialAccessEventsDelete)
 	t.Run("ServerComponentTypes", testServerComponentTypesDelete)
 	t.Run("ServerComponents", testServerComponentsDelete)
 	t.Run("ServerCredentialTypes", testServerCredentialTypesDelete)
+	t.Run("ServerCredentialVersions", testServerCredentialVersionsDelete)
 	t.Run("ServerCredentials", testServerCredentialsDelete)
 	t.Run("Servers", testServersDelete)
 	t.Run("VersionedAttributes", testVersionedAttributesDelete)
@@ -56,10 +62,13 @@ func TestQueryDeleteAll(t *testing.T) {
 	t.Run("AttributesFirmwareSets", testAttributesFirmwareSetsQueryDeleteAll)
 	t.Run("ComponentFirmwareSets", testComponentFirmwareSetsQueryDeleteAll)
 	t.Run("ComponentFirmwareSetMaps", testComponentFirmwareSetMapsQueryDeleteAll)
+	t.Run("ComponentFirmwareSetRevisions", testComponentFirmwareSetRevisionsQueryDeleteAll)
 	t.Run("ComponentFirmwareVersions", testComponentFirmwareVersionsQueryDeleteAll)
+	t.Run("CredentialAccessEvents", testCredentialAccessEventsQueryDeleteAll)
 	t.Run("ServerComponentTypes", testServerComponentTypesQueryDeleteAll)
 	t.Run("ServerComponents", testServerComponentsQueryDeleteAll)
 	t.Run("ServerCredentialTypes", testServerCredentialTypesQueryDeleteAll)
+	t.Run("ServerCredentialVersions", testServerCredentialVersionsQueryDeleteAll)
 	t.Run("ServerCredentials", testServerCredentialsQueryDeleteAll)
 	t.Run("Servers", testServersQueryDeleteAll)
 	t.Run("VersionedAttributes", testVersionedAttributesQueryDeleteAll)
@@ -70,10 +79,13 @@ func TestSliceDeleteAll(t *testing.T) {
 	t.Run("AttributesFirmwareSets", testAttributesFirmwareSetsSliceDeleteAll)
 	t.Run("ComponentFirmwareSets", testComponentFirmwareSetsSliceDeleteAll)
 	t.Run("ComponentFirmwareSetMaps", testComponentFirmwareSetMapsSliceDeleteAll)
+	t.Run("ComponentFirmwareSetRevisions", testComponentFirmwareSetRevisionsSliceDeleteAll)
 	t.Run("ComponentFirmwareVersions", testComponentFirmwareVersionsSliceDeleteAll)
+	t.Run("CredentialAccessEvents", testCredentialAccessEventsSliceDeleteAll)
 	t.Run("ServerComponentTypes", testServerComponentTypesSliceDeleteAll)
 	t.Run("ServerComponents", testServerComponentsSliceDeleteAll)
 	t.Run("ServerCredentialTypes", testServerCredentialTypesSliceDeleteAll)
+	t.Run("ServerCredentialVersions", testServerCredentialVersionsSliceDeleteAll)
 	t.Run("ServerCredentials", testServerCredentialsSliceDeleteAll)
 	t.Run("Servers", testServersSliceDeleteAll)
 	t.Run("VersionedAttributes", testVersionedAttributesSliceDeleteAll)
@@ -84,10 +96,13 @@ func TestExists(t *testing.T) {
 	t.Run("AttributesFirmwareSets", testAttributesFirmwareSetsExists)
 	t.Run("ComponentFirmwareSets", testComponentFirmwareSetsExists)
 	t.Run("ComponentFirmwareSetMaps", testComponentFirmwareSetMapsExists)
+	t.Run("ComponentFirmwareSetRevisions", testComponentFirmwareSetRevisionsExists)
 	t.Run("ComponentFirmwareVersions", testComponentFirmwareVersionsExists)
+	t.Run("CredentialAccessEvents", testCredentialAccessEventsExists)
 	t.Run("ServerComponentTypes", testServerComponentTypesExists)
 	t.Run("ServerComponents", testServerComponentsExists)
 	t.Run("ServerCredentialTypes", testServerCredentialTypesExists)
+	t.Run("ServerCredentialVersions", testServerCredentialVersionsExists)
 	t.Run("ServerCredentials", testServerCredentialsExists)
 	t.Run("Servers", testServersExists)
 	t.Run("VersionedAttributes", testVersionedAttributesExists)
@@ -98,10 +113,13 @@ func TestFind(t *testing.T) {
 	t.Run("AttributesFirmwareSets", testAttributesFirmwareSetsFind)
 	t.Run("ComponentFirmwareSets", testComponentFirmwareSetsFind)
 	t.Run("ComponentFirmwareSetMaps", testComponentFirmwareSetMapsFind)
+	t.Run("ComponentFirmwareSetRevisions", testComponentFirmwareSetRevisionsFind)
 	t.Run("ComponentFirmwareVersions", testComponentFirmwareVersionsFind)
+	t.Run("CredentialAccessEvents", testCredentialAccessEventsFind)
 	t.Run("ServerComponentTypes", testServerComponentTypesFind)
 	t.Run("ServerComponents", testServerComponentsFind)
 	t.Run("ServerCredentialTypes", testServerCredentialTypesFind)
+	t.Run("ServerCredentialVersions", testServerCredentialVersionsFind)
 	t.Run("ServerCredentials", testServerCredentialsFind)
 	t.Run("Servers", testServersFind)
 	t.Run("VersionedAttributes", testVersionedAttributesFind)
@@ -112,10 +130,13 @@ func TestBind(t *testing.T) {
 	t.Run("AttributesFirmwareSets", testAttributesFirmwareSetsBind)
 	t.Run("ComponentFirmwareSets", testComponentFirmwareSetsBind)
 	t.Run("ComponentFirmwareSetMaps", testComponentFirmwareSetMapsBind)
+	t.Run("ComponentFirmwareSetRevisions", testComponentFirmwareSetRevisionsBind)
 	t.Run("ComponentFirmwareVersions", testComponentFirmwareVersionsBind)
+	t.Run("CredentialAccessEvents", testCredentialAccessEventsBind)
 	t.Run("ServerComponentTypes", testServerComponentTypesBind)
 	t.Run("ServerComponents", testServerComponentsBind)
 	t.Run("ServerCredentialTypes", testServerCredentialTypesBind)
+	t.Run("ServerCredentialVersions", testServerCredentialVersionsBind)
 	t.Run("ServerCredentials", testServerCredentialsBind)
 	t.Run("Servers", testServersBind)
 	t.Run("VersionedAttributes", testVersionedAttributesBind)
@@ -126,10 +147,13 @@ func TestOne(t *testing.T) {
 	t.Run("AttributesFirmwareSets", testAttributesFirmwareSetsOne)
 	t.Run("ComponentFirmwareSets", testComponentFirmwareSetsOne)
 	t.Run("ComponentFirmwareSetMaps", testComponentFirmwareSetMapsOne)
+	t.Run("ComponentFirmwareSetRevisions", testComponentFirmwareSetRevisionsOne)
 	t.Run("ComponentFirmwareVersions", testComponentFirmwareVersionsOne)
+	t.Run("CredentialAccessEvents", testCredentialAccessEventsOne)
 	t.Run("ServerComponentTypes", testServerComponentTypesOne)
 	t.Run("ServerComponents", testServerComponentsOne)
 	t.Run("ServerCredentialTypes", testServerCredentialTypesOne)
+	t.Run("ServerCredentialVersions", testServerCredentialVersionsOne)
 	t.Run("ServerCredentials", testServerCredentialsOne)
 	t.Run("Servers", testServersOne)
 	t.Run("VersionedAttributes", testVersionedAttributesOne)
@@ -140,10 +164,13 @@ func TestAll(t *testing.T) {
 	t.Run("AttributesFirmwareSets", testAttributesFirmwareSetsAll)
 	t.Run("ComponentFirmwareSets", testComponentFirmwareSetsAll)
 	t.Run("ComponentFirmwareSetMaps", testComponentFirmwareSetMapsAll)
+	t.Run("ComponentFirmwareSetRevisions", testComponentFirmwareSetRevisionsAll)
 	t.Run("ComponentFirmwareVersions", testComponentFirmwareVersionsAll)
+	t.Run("CredentialAccessEvents", testCredentialAccessEventsAll)
 	t.Run("ServerComponentTypes", testServerComponentTypesAll)
 	t.Run("ServerComponents", testServerComponentsAll)
 	t.Run("ServerCredentialTypes", testServerCredentialTypesAll)
+	t.Run("ServerCredentialVersions", testServerCredentialVersionsAll)
 	t.Run("ServerCredentials", testServerCredentialsAll)
 	t.Run("Servers", testServersAll)
 	t.Run("VersionedAttributes", testVersionedAttributesAll)
@@ -154,10 +181,13 @@ func TestCount(t *testing.T) {
 	t.Run("AttributesFirmwareSets", testAttributesFirmwareSetsCount)
 	t.Run("ComponentFirmwareSets", testComponentFirmwareSetsCount)
 	t.Run("ComponentFirmwareSetMaps", testComponentFirmwareSetMapsCount)
+	t.Run("ComponentFirmwareSetRevisions", testComponentFirmwareSetRevisionsCount)
 	t.Run("ComponentFirmwareVersions", testComponentFirmwareVersionsCount)
+	t.Run("CredentialAccessEvents", testCredentialAccessEventsCount)
 	t.Run("ServerComponentTypes", testServerComponentTypesCount)
 	t.Run("ServerComponents", testServerComponentsCount)
 	t.Run("ServerCredentialTypes", testServerCredentialTypesCount)
+	t.Run("ServerCredentialVersions", testServerCredentialVersionsCount)
 	t.Run("ServerCredentials", testServerCredentialsCount)
 	t.Run("Servers", testServersCount)
 	t.Run("VersionedAttributes", testVersionedAttributesCount)
@@ -168,10 +198,13 @@ func TestHooks(t *testing.T) {
 	t.Run("AttributesFirmwareSets", testAttributesFirmwareSetsHooks)
 	t.Run("ComponentFirmwareSets", testComponentFirmwareSetsHooks)
 	t.Run("ComponentFirmwareSetMaps", testComponentFirmwareSetMapsHooks)
+	t.Run("ComponentFirmwareSetRevisions", testComponentFirmwareSetRevisionsHooks)
 	t.Run("ComponentFirmwareVersions", testComponentFirmwareVersionsHooks)
+	t.Run("CredentialAccessEvents", testCredentialAccessEventsHooks)
 	t.Run("ServerComponentTypes", testServerComponentTypesHooks)
 	t.Run("ServerComponents", testServerComponentsHooks)
 	t.Run("ServerCredentialTypes", testServerCredentialTypesHooks)
+	t.Run("ServerCredentialVersions", testServerCredentialVersionsHooks)
 	t.Run("ServerCredentials", testServerCredentialsHooks)
 	t.Run("Servers", testServersHooks)
 	t.Run("VersionedAttributes", testVersionedAttributesHooks)
@@ -186,14 +219,20 @@ func TestInsert(t *testing.T) {
 	t.Run("ComponentFirmwareSets", testComponentFirmwareSetsInsertWhitelist)
 	t.Run("ComponentFirmwareSetMaps", testComponentFirmwareSetMapsInsert)
 	t.Run("ComponentFirmwareSetMaps", testComponentFirmwareSetMapsInsertWhitelist)
+	t.Run("ComponentFirmwareSetRevisions", testComponentFirmwareSetRevisionsInsert)
+	t.Run("ComponentFirmwareSetRevisions", testComponentFirmwareSetRevisionsInsertWhitelist)
 	t.Run("ComponentFirmwareVersions", testComponentFirmwareVersionsInsert)
 	t.Run("ComponentFirmwareVersions", testComponentFirmwareVersionsInsertWhitelist)
+	t.Run("CredentialAccessEvents", testCredentialAccessEventsInsert)
+	t.Run("CredentialAccessEvents", testCredentialAccessEventsInsertWhitelist)
 	t.Run("ServerComponentTypes", testServerComponentTypesInsert)
 	t.Run("ServerComponentTypes", testServerComponentTypesInsertWhitelist)
 	t.Run("ServerComponents", testServerComponentsInsert)
 	t.Run("ServerComponents", testServerComponentsInsertWhitelist)
 	t.Run("ServerCredentialTypes", testServerCredentialTypesInsert)
 	t.Run("ServerCredentialTypes", testServerCredentialTypesInsertWhitelist)
+	t.Run("ServerCredentialVersions", testServerCredentialVersionsInsert)
+	t.Run("ServerCredentialVersions", testServerCredentialVersionsInsertWhitelist)
 	t.Run("ServerCredentials", testServerCredentialsInsert)
 	t.Run("ServerCredentials", testServerCredentialsInsertWhitelist)
 	t.Run("Servers", testServersInsert)
@@ -210,8 +249,10 @@ func TestToOne(t *testing.T) {
 	t.Run("AttributesFirmwareSetToComponentFirmwareSetUsingFirmwareSet", testAttributesFirmwareSetToOneComponentFirmwareSetUsingFirmwareSet)
 	t.Run("ComponentFirmwareSetMapToComponentFirmwareSetUsingFirmwareSet", testComponentFirmwareSetMapToOneComponentFirmwareSetUsingFirmwareSet)
 	t.Run("ComponentFirmwareSetMapToComponentFirmwareVersionUsingFirmware", testComponentFirmwareSetMapToOneComponentFirmwareVersionUsingFirmware)
+	t.Run("ComponentFirmwareSetRevisionToComponentFirmwareSetUsingFirmwareSet", testComponentFirmwareSetRevisionToOneComponentFirmwareSetUsingFirmwareSet)
 	t.Run("ServerComponentToServerUsingServer", testServerComponentToOneServerUsingServer)
 	t.Run("ServerComponentToServerComponentTypeUsingServerComponentType", testServerComponentToOneServerComponentTypeUsingServerComponentType)
+	t.Run("ServerCredentialVersionToServerCredentialUsingServerCredential", testServerCredentialVersionToOneServerCredentialUsingServerCredential)
 	t.Run("ServerCredentialToServerCredentialTypeUsingServerCredentialType", testServerCredentialToOneServerCredentialTypeUsingServerCredentialType)
 	t.Run("ServerCredentialToServerUsingServer", testServerCredentialToOneServerUsingServer)
 	t.Run("VersionedAttributeToServerUsingServer", testVersionedAttributeToOneServerUsingServer)
@@ -227,11 +268,13 @@ func TestOneToOne(t *testing.T) {}
 func TestToMany(t *testing.T) {
 	t.Run("ComponentFirmwareSetToFirmwareSetAttributesFirmwareSets", testComponentFirmwareSetToManyFirmwareSetAttributesFirmwareSets)
 	t.Run("ComponentFirmwareSetToFirmwareSetComponentFirmwareSetMaps", testComponentFirmwareSetToManyFirmwareSetComponentFirmwareSetMaps)
+	t.Run("ComponentFirmwareSetToFirmwareSetComponentFirmwareSetRevisions", testComponentFirmwareSetToManyFirmwareSetComponentFirmwareSetRevisions)
 	t.Run("ComponentFirmwareVersionToFirmwareComponentFirmwareSetMaps", testComponentFirmwareVersionToManyFirmwareComponentFirmwareSetMaps)
 	t.Run("ServerComponentTypeToServerComponents", testServerComponentTypeToManyServerComponents)
 	t.Run("ServerComponentToAttributes", testServerComponentToManyAttributes)
 	t.Run("ServerComponentToVersionedAttributes", testServerComponentToManyVersionedAttributes)
 	t.Run("ServerCredentialTypeToServerCredentials", testServerCredentialTypeToManyServerCredentials)
+	t.Run("ServerCredentialToServerCredentialVersions", testServerCredentialToManyServerCredentialVersions)
 	t.Run("ServerToAttributes", testServerToManyAttributes)
 	t.Run("ServerToServerComponents", testServerToManyServerComponents)
 	t.Run("ServerToServerCredentials", testServerToManyServerCredentials)
@@ -246,8 +289,10 @@ func TestToOneSet(t *testing.T) {
 	t.Run("AttributesFirmwareSetToComponentFirmwareSetUsingFirmwareSetAttributesFirmwareSets", testAttributesFirmwareSetToOneSetOpComponentFirmwareSetUsingFirmwareSet)
 	t.Run("ComponentFirmwareSetMapToComponentFirmwareSetUsingFirmwareSetComponentFirmwareSetMaps", testComponentFirmwareSetMapToOneSetOpComponentFirmwareSetUsingFirmwareSet)
 	t.Run("ComponentFirmwareSetMapToComponentFirmwareVersionUsingFirmwareComponentFirmwareSetMaps", testComponentFirmwareSetMapToOneSetOpComponentFirmwareVersionUsingFirmware)
+	t.Run("ComponentFirmwareSetRevisionToComponentFirmwareSetUsingFirmwareSetComponentFirmwareSetRevisions", testComponentFirmwareSetRevisionToOneSetOpComponentFirmwareSetUsingFirmwareSet)
 	t.Run("ServerComponentToServerUsingServerComponents", testServerComponentToOneSetOpServerUsingServer)
 	t.Run("ServerComponentToServerComponentTypeUsingServerComponents", testServerComponentToOneSetOpServerComponentTypeUsingServerComponentType)
+	t.Run("ServerCredentialVersionToServerCredentialUsingServerCredentialVersions", testServerCredentialVersionToOneSetOpServerCredentialUsingServerCredential)
 	t.Run("ServerCredentialToServerCredentialTypeUsingServerCredentials", testServerCredentialToOneSetOpServerCredentialTypeUsingServerCredentialType)
 	t.Run("ServerCredentialToServerUsingServerCredentials", testServerCredentialToOneSetOpServerUsingServer)
 	t.Run("VersionedAttributeToServerUsingVersionedAttributes", testVersionedAttributeToOneSetOpServerUsingServer)
@@ -277,11 +322,13 @@ func TestOneToOneRemove(t *testing.T) {}
 func TestToManyAdd(t *testing.T) {
 	t.Run("ComponentFirmwareSetToFirmwareSetAttributesFirmwareSets", testComponentFirmwareSetToManyAddOpFirmwareSetAttributesFirmwareSets)
 	t.Run("ComponentFirmwareSetToFirmwareSetComponentFirmwareSetMaps", testComponentFirmwareSetToManyAddOpFirmwareSetComponentFirmwareSetMaps)
+	t.Run("ComponentFirmwareSetToFirmwareSetComponentFirmwareSetRevisions", testComponentFirmwareSetToManyAddOpFirmwareSetComponentFirmwareSetRevisions)
 	t.Run("ComponentFirmwareVersionToFirmwareComponentFirmwareSetMaps", testComponentFirmwareVersionToManyAddOpFirmwareComponentFirmwareSetMaps)
 	t.Run("ServerComponentTypeToServerComponents", testServerComponentTypeToManyAddOpServerComponents)
 	t.Run("ServerComponentToAttributes", testServerComponentToManyAddOpAttributes)
 	t.Run("ServerComponentToVersionedAttributes", testServerComponentToManyAddOpVersionedAttributes)
 	t.Run("ServerCredentialTypeToServerCredentials", testServerCredentialTypeToManyAddOpServerCredentials)
+	t.Run("ServerCredentialToServerCredentialVersions", testServerCredentialToManyAddOpServerCredentialVersions)
 	t.Run("ServerToAttributes", testServerToManyAddOpAttributes)
 	t.Run("ServerToServerComponents", testServerToManyAddOpServerComponents)
 	t.Run("ServerToServerCredentials", testServerToManyAddOpServerCredentials)
@@ -313,10 +360,13 @@ func TestReload(t *testing.T) {
 	t.Run("AttributesFirmwareSets", testAttributesFirmwareSetsReload)
 	t.Run("ComponentFirmwareSets", testComponentFirmwareSetsReload)
 	t.Run("ComponentFirmwareSetMaps", testComponentFirmwareSetMapsReload)
+	t.Run("ComponentFirmwareSetRevisions", testComponentFirmwareSetRevisionsReload)
 	t.Run("ComponentFirmwareVersions", testComponentFirmwareVersionsReload)
+	t.Run("CredentialAccessEvents", testCredentialAccessEventsReload)
 	t.Run("ServerComponentTypes", testServerComponentTypesReload)
 	t.Run("ServerComponents", testServerComponentsReload)
 	t.Run("ServerCredentialTypes", testServerCredentialTypesReload)
+	t.Run("ServerCredentialVersions", testServerCredentialVersionsReload)
 	t.Run("ServerCredentials", testServerCredentialsReload)
 	t.Run("Servers", testServersReload)
 	t.Run("VersionedAttributes", testVersionedAttributesReload)
@@ -327,10 +377,13 @@ func TestReloadAll(t *testing.T) {
 	t.Run("AttributesFirmwareSets", testAttributesFirmwareSetsReloadAll)
 	t.Run("ComponentFirmwareSets", testComponentFirmwareSetsReloadAll)
 	t.Run("ComponentFirmwareSetMaps", testComponentFirmwareSetMapsReloadAll)
+	t.Run("ComponentFirmwareSetRevisions", testComponentFirmwareSetRevisionsReloadAll)
 	t.Run("ComponentFirmwareVersions", testComponentFirmwareVersionsReloadAll)
+	t.Run("CredentialAccessEvents", testCredentialAccessEventsReloadAll)
 	t.Run("ServerComponentTypes", testServerComponentTypesReloadAll)
 	t.Run("ServerComponents", testServerComponentsReloadAll)
 	t.Run("ServerCredentialTypes", testServerCredentialTypesReloadAll)
+	t.Run("ServerCredentialVersions", testServerCredentialVersionsReloadAll)
 	t.Run("ServerCredentials", testServerCredentialsReloadAll)
 	t.Run("Servers", testServersReloadAll)
 	t.Run("VersionedAttributes", testVersionedAttributesReloadAll)
@@ -341,10 +394,13 @@ func TestSelect(t *testing.T) {
 	t.Run("AttributesFirmwareSets", testAttributesFirmwareSetsSelect)
 	t.Run("ComponentFirmwareSets", testComponentFirmwareSetsSelect)
 	t.Run("ComponentFirmwareSetMaps", testComponentFirmwareSetMapsSelect)
+	t.Run("ComponentFirmwareSetRevisions", testComponentFirmwareSetRevisionsSelect)
 	t.Run("ComponentFirmwareVersions", testComponentFirmwareVersionsSelect)
+	t.Run("CredentialAccessEvents", testCredentialAccessEventsSelect)
 	t.Run("ServerComponentTypes", testServerComponentTypesSelect)
 	t.Run("ServerComponents", testServerComponentsSelect)
 	t.Run("ServerCredentialTypes", testServerCredentialTypesSelect)
+	t.Run("ServerCredentialVersions", testServerCredentialVersionsSelect)
 	t.Run("ServerCredentials", testServerCredentialsSelect)
 	t.Run("Servers", testServersSelect)
 	t.Run("VersionedAttributes", testVersionedAttributesSelect)
@@ -355,10 +411,13 @@ func TestUpdate(t *testing.T) {
 	t.Run("AttributesFirmwareSets", testAttributesFirmwareSetsUpdate)
 	t.Run("ComponentFirmwareSets", testComponentFirmwareSetsUpdate)
 	t.Run("ComponentFirmwareSetMaps", testComponentFirmwareSetMapsUpdate)
+	t.Run("ComponentFirmwareSetRevisions", testComponentFirmwareSetRevisionsUpdate)
 	t.Run("ComponentFirmwareVersions", testComponentFirmwareVersionsUpdate)
+	t.Run("CredentialAccessEvents", testCredentialAccessEventsUpdate)
 	t.Run("ServerComponentTypes", testServerComponentTypesUpdate)
 	t.Run("ServerComponents", testServerComponentsUpdate)
 	t.Run("ServerCredentialTypes", testServerCredentialTypesUpdate)
+	t.Run("ServerCredentialVersions", testServerCredentialVersionsUpdate)
 	t.Run("ServerCredentials", testServerCredentialsUpdate)
 	t.Run("Servers", testServersUpdate)
 	t.Run("VersionedAttributes", testVersionedAttributesUpdate)
@@ -369,10 +428,13 @@ func TestSliceUpdateAll(t *testing.T) {
 	t.Run("AttributesFirmwareSets", testAttributesFirmwareSetsSliceUpdateAll)
 	t.Run("ComponentFirmwareSets", testComponentFirmwareSetsSliceUpdateAll)
 	t.Run("ComponentFirmwareSetMaps", testComponentFirmwareSetMapsSliceUpdateAll)
+	t.Run("ComponentFirmwareSetRevisions", testComponentFirmwareSetRevisionsSliceUpdateAll)
 	t.Run("ComponentFirmwareVersions", testComponentFirmwareVersionsSliceUpdateAll)
+	t.Run("CredentialAccessEvents", testCredentialAccessEventsSliceUpdateAll)
 	t.Run("ServerComponentTypes", testServerComponentTypesSliceUpdateAll)
 	t.Run("ServerComponents", testServerComponentsSliceUpdateAll)
 	t.Run("ServerCredentialTypes", testServerCredentialTypesSliceUpdateAll)
+	t.Run("ServerCredentialVersions", testServerCredentialVersionsSliceUpdateAll)
 	t.Run("ServerCredentials", testServerCredentialsSliceUpdateAll)
 	t.Run("Servers", testServersSliceUpdateAll)
 	t.Run("VersionedAttributes", testVersionedAttributesSliceUpdateAll)
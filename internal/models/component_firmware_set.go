@@ -73,17 +73,20 @@ var ComponentFirmwareSetWhere = struct {
 
 // ComponentFirmwareSetRels is where relationship names are stored.
 var ComponentFirmwareSetRels = struct {
-	FirmwareSetAttributesFirmwareSets   string
-	FirmwareSetComponentFirmwareSetMaps string
+	FirmwareSetAttributesFirmwareSets        string
+	FirmwareSetComponentFirmwareSetMaps      string
+	FirmwareSetComponentFirmwareSetRevisions string
 }{
-	FirmwareSetAttributesFirmwareSets:   "FirmwareSetAttributesFirmwareSets",
-	FirmwareSetComponentFirmwareSetMaps: "FirmwareSetComponentFirmwareSetMaps",
+	FirmwareSetAttributesFirmwareSets:        "FirmwareSetAttributesFirmwareSets",
+	FirmwareSetComponentFirmwareSetMaps:      "FirmwareSetComponentFirmwareSetMaps",
+	FirmwareSetComponentFirmwareSetRevisions: "FirmwareSetComponentFirmwareSetRevisions",
 }
 
 // componentFirmwareSetR is where relationships are stored.
 type componentFirmwareSetR struct {
-	FirmwareSetAttributesFirmwareSets   AttributesFirmwareSetSlice   `boil:"FirmwareSetAttributesFirmwareSets" json:"FirmwareSetAttributesFirmwareSets" toml:"FirmwareSetAttributesFirmwareSets" yaml:"FirmwareSetAttributesFirmwareSets"`
-	FirmwareSetComponentFirmwareSetMaps ComponentFirmwareSetMapSlice `boil:"FirmwareSetComponentFirmwareSetMaps" json:"FirmwareSetComponentFirmwareSetMaps" toml:"FirmwareSetComponentFirmwareSetMaps" yaml:"FirmwareSetComponentFirmwareSetMaps"`
+	FirmwareSetAttributesFirmwareSets        AttributesFirmwareSetSlice        `boil:"FirmwareSetAttributesFirmwareSets" json:"FirmwareSetAttributesFirmwareSets" toml:"FirmwareSetAttributesFirmwareSets" yaml:"FirmwareSetAttributesFirmwareSets"`
+	FirmwareSetComponentFirmwareSetMaps      ComponentFirmwareSetMapSlice      `boil:"FirmwareSetComponentFirmwareSetMaps" json:"FirmwareSetComponentFirmwareSetMaps" toml:"FirmwareSetComponentFirmwareSetMaps" yaml:"FirmwareSetComponentFirmwareSetMaps"`
+	FirmwareSetComponentFirmwareSetRevisions ComponentFirmwareSetRevisionSlice `boil:"FirmwareSetComponentFirmwareSetRevisions" json:"FirmwareSetComponentFirmwareSetRevisions" toml:"FirmwareSetComponentFirmwareSetRevisions" yaml:"FirmwareSetComponentFirmwareSetRevisions"`
 }
 
 // NewStruct creates a new relationship struct
@@ -105,6 +108,13 @@ func (r *componentFirmwareSetR) GetFirmwareSetComponentFirmwareSetMaps() Compone
 	return r.FirmwareSetComponentFirmwareSetMaps
 }
 
+func (r *componentFirmwareSetR) GetFirmwareSetComponentFirmwareSetRevisions() ComponentFirmwareSetRevisionSlice {
+	if r == nil {
+		return nil
+	}
+	return r.FirmwareSetComponentFirmwareSetRevisions
+}
+
 // componentFirmwareSetL is where Load methods for each relationship are stored.
 type componentFirmwareSetL struct{}
 
@@ -422,6 +432,20 @@ func (o *ComponentFirmwareSet) FirmwareSetComponentFirmwareSetMaps(mods ...qm.Qu
 	return ComponentFirmwareSetMaps(queryMods...)
 }
 
+// FirmwareSetComponentFirmwareSetRevisions retrieves all the component_firmware_set_revision's ComponentFirmwareSetRevisions with an executor via firmware_set_id column.
+func (o *ComponentFirmwareSet) FirmwareSetComponentFirmwareSetRevisions(mods ...qm.QueryMod) componentFirmwareSetRevisionQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"component_firmware_set_revision\".\"firmware_set_id\"=?", o.ID),
+	)
+
+	return ComponentFirmwareSetRevisions(queryMods...)
+}
+
 // LoadFirmwareSetAttributesFirmwareSets allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
 func (componentFirmwareSetL) LoadFirmwareSetAttributesFirmwareSets(ctx context.Context, e boil.ContextExecutor, singular bool, maybeComponentFirmwareSet interface{}, mods queries.Applicator) error {
@@ -618,6 +642,104 @@ func (componentFirmwareSetL) LoadFirmwareSetComponentFirmwareSetMaps(ctx context
 	return nil
 }
 
+// LoadFirmwareSetComponentFirmwareSetRevisions allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (componentFirmwareSetL) LoadFirmwareSetComponentFirmwareSetRevisions(ctx context.Context, e boil.ContextExecutor, singular bool, maybeComponentFirmwareSet interface{}, mods queries.Applicator) error {
+	var slice []*ComponentFirmwareSet
+	var object *ComponentFirmwareSet
+
+	if singular {
+		object = maybeComponentFirmwareSet.(*ComponentFirmwareSet)
+	} else {
+		slice = *maybeComponentFirmwareSet.(*[]*ComponentFirmwareSet)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &componentFirmwareSetR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &componentFirmwareSetR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`component_firmware_set_revision`),
+		qm.WhereIn(`component_firmware_set_revision.firmware_set_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load component_firmware_set_revision")
+	}
+
+	var resultSlice []*ComponentFirmwareSetRevision
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice component_firmware_set_revision")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on component_firmware_set_revision")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for component_firmware_set_revision")
+	}
+
+	if len(componentFirmwareSetRevisionAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.FirmwareSetComponentFirmwareSetRevisions = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &componentFirmwareSetRevisionR{}
+			}
+			foreign.R.FirmwareSet = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.FirmwareSetID {
+				local.R.FirmwareSetComponentFirmwareSetRevisions = append(local.R.FirmwareSetComponentFirmwareSetRevisions, foreign)
+				if foreign.R == nil {
+					foreign.R = &componentFirmwareSetRevisionR{}
+				}
+				foreign.R.FirmwareSet = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
 // AddFirmwareSetAttributesFirmwareSets adds the given related objects to the existing relationships
 // of the component_firmware_set, optionally inserting them as new records.
 // Appends related to o.R.FirmwareSetAttributesFirmwareSets.
@@ -798,6 +920,59 @@ func (o *ComponentFirmwareSet) AddFirmwareSetComponentFirmwareSetMaps(ctx contex
 	return nil
 }
 
+// AddFirmwareSetComponentFirmwareSetRevisions adds the given related objects to the existing relationships
+// of the component_firmware_set, optionally inserting them as new records.
+// Appends related to o.R.FirmwareSetComponentFirmwareSetRevisions.
+// Sets related.R.FirmwareSet appropriately.
+func (o *ComponentFirmwareSet) AddFirmwareSetComponentFirmwareSetRevisions(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*ComponentFirmwareSetRevision) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.FirmwareSetID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"component_firmware_set_revision\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"firmware_set_id"}),
+				strmangle.WhereClause("\"", "\"", 2, componentFirmwareSetRevisionPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.FirmwareSetID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &componentFirmwareSetR{
+			FirmwareSetComponentFirmwareSetRevisions: related,
+		}
+	} else {
+		o.R.FirmwareSetComponentFirmwareSetRevisions = append(o.R.FirmwareSetComponentFirmwareSetRevisions, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &componentFirmwareSetRevisionR{
+				FirmwareSet: o,
+			}
+		} else {
+			rel.R.FirmwareSet = o
+		}
+	}
+	return nil
+}
+
 // ComponentFirmwareSets retrieves all the records using an executor.
 func ComponentFirmwareSets(mods ...qm.QueryMod) componentFirmwareSetQuery {
 	mods = append(mods, qm.From("\"component_firmware_set\""))
@@ -25,72 +25,97 @@ import (
 
 // ComponentFirmwareVersion is an object representing the database table.
 type ComponentFirmwareVersion struct {
-	ID            string            `boil:"id" json:"id" toml:"id" yaml:"id"`
-	Component     string            `boil:"component" json:"component" toml:"component" yaml:"component"`
-	Vendor        string            `boil:"vendor" json:"vendor" toml:"vendor" yaml:"vendor"`
-	Model         types.StringArray `boil:"model" json:"model" toml:"model" yaml:"model"`
-	Filename      string            `boil:"filename" json:"filename" toml:"filename" yaml:"filename"`
-	Version       string            `boil:"version" json:"version" toml:"version" yaml:"version"`
-	Checksum      string            `boil:"checksum" json:"checksum" toml:"checksum" yaml:"checksum"`
-	UpstreamURL   string            `boil:"upstream_url" json:"upstream_url" toml:"upstream_url" yaml:"upstream_url"`
-	RepositoryURL string            `boil:"repository_url" json:"repository_url" toml:"repository_url" yaml:"repository_url"`
-	CreatedAt     null.Time         `boil:"created_at" json:"created_at,omitempty" toml:"created_at" yaml:"created_at,omitempty"`
-	UpdatedAt     null.Time         `boil:"updated_at" json:"updated_at,omitempty" toml:"updated_at" yaml:"updated_at,omitempty"`
+	ID                 string            `boil:"id" json:"id" toml:"id" yaml:"id"`
+	Component          string            `boil:"component" json:"component" toml:"component" yaml:"component"`
+	Vendor             string            `boil:"vendor" json:"vendor" toml:"vendor" yaml:"vendor"`
+	Model              types.StringArray `boil:"model" json:"model" toml:"model" yaml:"model"`
+	Filename           string            `boil:"filename" json:"filename" toml:"filename" yaml:"filename"`
+	Version            string            `boil:"version" json:"version" toml:"version" yaml:"version"`
+	Checksum           string            `boil:"checksum" json:"checksum" toml:"checksum" yaml:"checksum"`
+	UpstreamURL        string            `boil:"upstream_url" json:"upstream_url" toml:"upstream_url" yaml:"upstream_url"`
+	RepositoryURL      string            `boil:"repository_url" json:"repository_url" toml:"repository_url" yaml:"repository_url"`
+	CreatedAt          null.Time         `boil:"created_at" json:"created_at,omitempty" toml:"created_at" yaml:"created_at,omitempty"`
+	UpdatedAt          null.Time         `boil:"updated_at" json:"updated_at,omitempty" toml:"updated_at" yaml:"updated_at,omitempty"`
+	VersionSortKey     null.String       `boil:"version_sort_key" json:"version_sort_key,omitempty" toml:"version_sort_key" yaml:"version_sort_key,omitempty"`
+	Status             string            `boil:"status" json:"status" toml:"status" yaml:"status"`
+	StatusReason       null.String       `boil:"status_reason" json:"status_reason,omitempty" toml:"status_reason" yaml:"status_reason,omitempty"`
+	VerificationStatus null.String       `boil:"verification_status" json:"verification_status,omitempty" toml:"verification_status" yaml:"verification_status,omitempty"`
+	VerifiedAt         null.Time         `boil:"verified_at" json:"verified_at,omitempty" toml:"verified_at" yaml:"verified_at,omitempty"`
 
 	R *componentFirmwareVersionR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L componentFirmwareVersionL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var ComponentFirmwareVersionColumns = struct {
-	ID            string
-	Component     string
-	Vendor        string
-	Model         string
-	Filename      string
-	Version       string
-	Checksum      string
-	UpstreamURL   string
-	RepositoryURL string
-	CreatedAt     string
-	UpdatedAt     string
+	ID                 string
+	Component          string
+	Vendor             string
+	Model              string
+	Filename           string
+	Version            string
+	Checksum           string
+	UpstreamURL        string
+	RepositoryURL      string
+	CreatedAt          string
+	UpdatedAt          string
+	VersionSortKey     string
+	Status             string
+	StatusReason       string
+	VerificationStatus string
+	VerifiedAt         string
 }{
-	ID:            "id",
-	Component:     "component",
-	Vendor:        "vendor",
-	Model:         "model",
-	Filename:      "filename",
-	Version:       "version",
-	Checksum:      "checksum",
-	UpstreamURL:   "upstream_url",
-	RepositoryURL: "repository_url",
-	CreatedAt:     "created_at",
-	UpdatedAt:     "updated_at",
+	ID:                 "id",
+	Component:          "component",
+	Vendor:             "vendor",
+	Model:              "model",
+	Filename:           "filename",
+	Version:            "version",
+	Checksum:           "checksum",
+	UpstreamURL:        "upstream_url",
+	RepositoryURL:      "repository_url",
+	CreatedAt:          "created_at",
+	UpdatedAt:          "updated_at",
+	VersionSortKey:     "version_sort_key",
+	Status:             "status",
+	StatusReason:       "status_reason",
+	VerificationStatus: "verification_status",
+	VerifiedAt:         "verified_at",
 }
 
 var ComponentFirmwareVersionTableColumns = struct {
-	ID            string
-	Component     string
-	Vendor        string
-	Model         string
-	Filename      string
-	Version       string
-	Checksum      string
-	UpstreamURL   string
-	RepositoryURL string
-	CreatedAt     string
-	UpdatedAt     string
+	ID                 string
+	Component          string
+	Vendor             string
+	Model              string
+	Filename           string
+	Version            string
+	Checksum           string
+	UpstreamURL        string
+	RepositoryURL      string
+	CreatedAt          string
+	UpdatedAt          string
+	VersionSortKey     string
+	Status             string
+	StatusReason       string
+	VerificationStatus string
+	VerifiedAt         string
 }{
-	ID:            "component_firmware_version.id",
-	Component:     "component_firmware_version.component",
-	Vendor:        "component_firmware_version.vendor",
-	Model:         "component_firmware_version.model",
-	Filename:      "component_firmware_version.filename",
-	Version:       "component_firmware_version.version",
-	Checksum:      "component_firmware_version.checksum",
-	UpstreamURL:   "component_firmware_version.upstream_url",
-	RepositoryURL: "component_firmware_version.repository_url",
-	CreatedAt:     "component_firmware_version.created_at",
-	UpdatedAt:     "component_firmware_version.updated_at",
+	ID:                 "component_firmware_version.id",
+	Component:          "component_firmware_version.component",
+	Vendor:             "component_firmware_version.vendor",
+	Model:              "component_firmware_version.model",
+	Filename:           "component_firmware_version.filename",
+	Version:            "component_firmware_version.version",
+	Checksum:           "component_firmware_version.checksum",
+	UpstreamURL:        "component_firmware_version.upstream_url",
+	RepositoryURL:      "component_firmware_version.repository_url",
+	CreatedAt:          "component_firmware_version.created_at",
+	UpdatedAt:          "component_firmware_version.updated_at",
+	VersionSortKey:     "component_firmware_version.version_sort_key",
+	Status:             "component_firmware_version.status",
+	StatusReason:       "component_firmware_version.status_reason",
+	VerificationStatus: "component_firmware_version.verification_status",
+	VerifiedAt:         "component_firmware_version.verified_at",
 }
 
 // Generated where
@@ -117,29 +142,39 @@ func (w whereHelpertypes_StringArray) GTE(x types.StringArray) qm.QueryMod {
 }
 
 var ComponentFirmwareVersionWhere = struct {
-	ID            whereHelperstring
-	Component     whereHelperstring
-	Vendor        whereHelperstring
-	Model         whereHelpertypes_StringArray
-	Filename      whereHelperstring
-	Version       whereHelperstring
-	Checksum      whereHelperstring
-	UpstreamURL   whereHelperstring
-	RepositoryURL whereHelperstring
-	CreatedAt     whereHelpernull_Time
-	UpdatedAt     whereHelpernull_Time
+	ID                 whereHelperstring
+	Component          whereHelperstring
+	Vendor             whereHelperstring
+	Model              whereHelpertypes_StringArray
+	Filename           whereHelperstring
+	Version            whereHelperstring
+	Checksum           whereHelperstring
+	UpstreamURL        whereHelperstring
+	RepositoryURL      whereHelperstring
+	CreatedAt          whereHelpernull_Time
+	UpdatedAt          whereHelpernull_Time
+	VersionSortKey     whereHelpernull_String
+	Status             whereHelperstring
+	StatusReason       whereHelpernull_String
+	VerificationStatus whereHelpernull_String
+	VerifiedAt         whereHelpernull_Time
 }{
-	ID:            whereHelperstring{field: "\"component_firmware_version\".\"id\""},
-	Component:     whereHelperstring{field: "\"component_firmware_version\".\"component\""},
-	Vendor:        whereHelperstring{field: "\"component_firmware_version\".\"vendor\""},
-	Model:         whereHelpertypes_StringArray{field: "\"component_firmware_version\".\"model\""},
-	Filename:      whereHelperstring{field: "\"component_firmware_version\".\"filename\""},
-	Version:       whereHelperstring{field: "\"component_firmware_version\".\"version\""},
-	Checksum:      whereHelperstring{field: "\"component_firmware_version\".\"checksum\""},
-	UpstreamURL:   whereHelperstring{field: "\"component_firmware_version\".\"upstream_url\""},
-	RepositoryURL: whereHelperstring{field: "\"component_firmware_version\".\"repository_url\""},
-	CreatedAt:     whereHelpernull_Time{field: "\"component_firmware_version\".\"created_at\""},
-	UpdatedAt:     whereHelpernull_Time{field: "\"component_firmware_version\".\"updated_at\""},
+	ID:                 whereHelperstring{field: "\"component_firmware_version\".\"id\""},
+	Component:          whereHelperstring{field: "\"component_firmware_version\".\"component\""},
+	Vendor:             whereHelperstring{field: "\"component_firmware_version\".\"vendor\""},
+	Model:              whereHelpertypes_StringArray{field: "\"component_firmware_version\".\"model\""},
+	Filename:           whereHelperstring{field: "\"component_firmware_version\".\"filename\""},
+	Version:            whereHelperstring{field: "\"component_firmware_version\".\"version\""},
+	Checksum:           whereHelperstring{field: "\"component_firmware_version\".\"checksum\""},
+	UpstreamURL:        whereHelperstring{field: "\"component_firmware_version\".\"upstream_url\""},
+	RepositoryURL:      whereHelperstring{field: "\"component_firmware_version\".\"repository_url\""},
+	CreatedAt:          whereHelpernull_Time{field: "\"component_firmware_version\".\"created_at\""},
+	UpdatedAt:          whereHelpernull_Time{field: "\"component_firmware_version\".\"updated_at\""},
+	VersionSortKey:     whereHelpernull_String{field: "\"component_firmware_version\".\"version_sort_key\""},
+	Status:             whereHelperstring{field: "\"component_firmware_version\".\"status\""},
+	StatusReason:       whereHelpernull_String{field: "\"component_firmware_version\".\"status_reason\""},
+	VerificationStatus: whereHelpernull_String{field: "\"component_firmware_version\".\"verification_status\""},
+	VerifiedAt:         whereHelpernull_Time{field: "\"component_firmware_version\".\"verified_at\""},
 }
 
 // ComponentFirmwareVersionRels is where relationship names are stored.
@@ -170,9 +205,9 @@ func (r *componentFirmwareVersionR) GetFirmwareComponentFirmwareSetMaps() Compon
 type componentFirmwareVersionL struct{}
 
 var (
-	componentFirmwareVersionAllColumns            = []string{"id", "component", "vendor", "model", "filename", "version", "checksum", "upstream_url", "repository_url", "created_at", "updated_at"}
+	componentFirmwareVersionAllColumns            = []string{"id", "component", "vendor", "model", "filename", "version", "checksum", "upstream_url", "repository_url", "created_at", "updated_at", "version_sort_key", "status", "status_reason", "verification_status", "verified_at"}
 	componentFirmwareVersionColumnsWithoutDefault = []string{"component", "vendor", "model", "filename", "version", "checksum", "upstream_url", "repository_url"}
-	componentFirmwareVersionColumnsWithDefault    = []string{"id", "created_at", "updated_at"}
+	componentFirmwareVersionColumnsWithDefault    = []string{"id", "created_at", "updated_at", "version_sort_key", "status", "status_reason", "verification_status", "verified_at"}
 	componentFirmwareVersionPrimaryKeyColumns     = []string{"id"}
 	componentFirmwareVersionGeneratedColumns      = []string{}
 )
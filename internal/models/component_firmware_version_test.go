@@ -770,7 +770,7 @@ func testComponentFirmwareVersionsSelect(t *testing.T) {
 }
 
 var (
-	componentFirmwareVersionDBTypes = map[string]string{`ID`: `uuid`, `Component`: `string`, `Vendor`: `string`, `Model`: `ARRAYstring`, `Filename`: `string`, `Version`: `string`, `Checksum`: `string`, `UpstreamURL`: `string`, `RepositoryURL`: `string`, `CreatedAt`: `timestamptz`, `UpdatedAt`: `timestamptz`}
+	componentFirmwareVersionDBTypes = map[string]string{`ID`: `uuid`, `Component`: `string`, `Vendor`: `string`, `Model`: `ARRAYstring`, `Filename`: `string`, `Version`: `string`, `Checksum`: `string`, `UpstreamURL`: `string`, `RepositoryURL`: `string`, `CreatedAt`: `timestamptz`, `UpdatedAt`: `timestamptz`, `VersionSortKey`: `string`, `Status`: `string`, `StatusReason`: `string`, `VerificationStatus`: `string`, `VerifiedAt`: `timestamptz`}
 	_                               = bytes.MinRead
 )
 
@@ -10,10 +10,13 @@ func TestUpsert(t *testing.T) {
 	t.Run("AttributesFirmwareSets", testAttributesFirmwareSetsUpsert)
 	t.Run("ComponentFirmwareSets", testComponentFirmwareSetsUpsert)
 	t.Run("ComponentFirmwareSetMaps", testComponentFirmwareSetMapsUpsert)
+	t.Run("ComponentFirmwareSetRevisions", testComponentFirmwareSetRevisionsUpsert)
 	t.Run("ComponentFirmwareVersions", testComponentFirmwareVersionsUpsert)
+	t.Run("CredentialAccessEvents", testCredentialAccessEventsUpsert)
 	t.Run("ServerComponentTypes", testServerComponentTypesUpsert)
 	t.Run("ServerComponents", testServerComponentsUpsert)
 	t.Run("ServerCredentialTypes", testServerCredentialTypesUpsert)
+	t.Run("ServerCredentialVersions", testServerCredentialVersionsUpsert)
 	t.Run("ServerCredentials", testServerCredentialsUpsert)
 	t.Run("Servers", testServersUpsert)
 	t.Run("VersionedAttributes", testVersionedAttributesUpsert)
@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
@@ -23,47 +24,57 @@ import (
 
 // ServerCredentialType is an object representing the database table.
 type ServerCredentialType struct {
-	ID        string    `boil:"id" json:"id" toml:"id" yaml:"id"`
-	Name      string    `boil:"name" json:"name" toml:"name" yaml:"name"`
-	Slug      string    `boil:"slug" json:"slug" toml:"slug" yaml:"slug"`
-	Builtin   bool      `boil:"builtin" json:"builtin" toml:"builtin" yaml:"builtin"`
-	CreatedAt time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
-	UpdatedAt time.Time `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	ID             string     `boil:"id" json:"id" toml:"id" yaml:"id"`
+	Name           string     `boil:"name" json:"name" toml:"name" yaml:"name"`
+	Slug           string     `boil:"slug" json:"slug" toml:"slug" yaml:"slug"`
+	Builtin        bool       `boil:"builtin" json:"builtin" toml:"builtin" yaml:"builtin"`
+	CreatedAt      time.Time  `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt      time.Time  `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	PasswordPolicy null.JSON  `boil:"password_policy" json:"password_policy,omitempty" toml:"password_policy" yaml:"password_policy,omitempty"`
+	MaxAge         null.Int64 `boil:"max_age" json:"max_age,omitempty" toml:"max_age" yaml:"max_age,omitempty"`
 
 	R *serverCredentialTypeR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L serverCredentialTypeL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var ServerCredentialTypeColumns = struct {
-	ID        string
-	Name      string
-	Slug      string
-	Builtin   string
-	CreatedAt string
-	UpdatedAt string
+	ID             string
+	Name           string
+	Slug           string
+	Builtin        string
+	CreatedAt      string
+	UpdatedAt      string
+	PasswordPolicy string
+	MaxAge         string
 }{
-	ID:        "id",
-	Name:      "name",
-	Slug:      "slug",
-	Builtin:   "builtin",
-	CreatedAt: "created_at",
-	UpdatedAt: "updated_at",
+	ID:             "id",
+	Name:           "name",
+	Slug:           "slug",
+	Builtin:        "builtin",
+	CreatedAt:      "created_at",
+	UpdatedAt:      "updated_at",
+	PasswordPolicy: "password_policy",
+	MaxAge:         "max_age",
 }
 
 var ServerCredentialTypeTableColumns = struct {
-	ID        string
-	Name      string
-	Slug      string
-	Builtin   string
-	CreatedAt string
-	UpdatedAt string
+	ID             string
+	Name           string
+	Slug           string
+	Builtin        string
+	CreatedAt      string
+	UpdatedAt      string
+	PasswordPolicy string
+	MaxAge         string
 }{
-	ID:        "server_credential_types.id",
-	Name:      "server_credential_types.name",
-	Slug:      "server_credential_types.slug",
-	Builtin:   "server_credential_types.builtin",
-	CreatedAt: "server_credential_types.created_at",
-	UpdatedAt: "server_credential_types.updated_at",
+	ID:             "server_credential_types.id",
+	Name:           "server_credential_types.name",
+	Slug:           "server_credential_types.slug",
+	Builtin:        "server_credential_types.builtin",
+	CreatedAt:      "server_credential_types.created_at",
+	UpdatedAt:      "server_credential_types.updated_at",
+	PasswordPolicy: "server_credential_types.password_policy",
+	MaxAge:         "server_credential_types.max_age",
 }
 
 // Generated where
@@ -77,41 +88,72 @@ func (w whereHelperbool) LTE(x bool) qm.QueryMod { return qmhelper.Where(w.field
 func (w whereHelperbool) GT(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
 func (w whereHelperbool) GTE(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
 
-type whereHelpertime_Time struct{ field string }
+type whereHelpernull_JSON struct{ field string }
 
-func (w whereHelpertime_Time) EQ(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.EQ, x)
+func (w whereHelpernull_JSON) EQ(x null.JSON) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
 }
-func (w whereHelpertime_Time) NEQ(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.NEQ, x)
+func (w whereHelpernull_JSON) NEQ(x null.JSON) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
 }
-func (w whereHelpertime_Time) LT(x time.Time) qm.QueryMod {
+func (w whereHelpernull_JSON) LT(x null.JSON) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.LT, x)
 }
-func (w whereHelpertime_Time) LTE(x time.Time) qm.QueryMod {
+func (w whereHelpernull_JSON) LTE(x null.JSON) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.LTE, x)
 }
-func (w whereHelpertime_Time) GT(x time.Time) qm.QueryMod {
+func (w whereHelpernull_JSON) GT(x null.JSON) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.GT, x)
 }
-func (w whereHelpertime_Time) GTE(x time.Time) qm.QueryMod {
+func (w whereHelpernull_JSON) GTE(x null.JSON) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.GTE, x)
 }
 
+func (w whereHelpernull_JSON) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_JSON) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
+type whereHelpernull_Int64 struct{ field string }
+
+func (w whereHelpernull_Int64) EQ(x null.Int64) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_Int64) NEQ(x null.Int64) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_Int64) LT(x null.Int64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_Int64) LTE(x null.Int64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_Int64) GT(x null.Int64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_Int64) GTE(x null.Int64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+func (w whereHelpernull_Int64) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Int64) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
 var ServerCredentialTypeWhere = struct {
-	ID        whereHelperstring
-	Name      whereHelperstring
-	Slug      whereHelperstring
-	Builtin   whereHelperbool
-	CreatedAt whereHelpertime_Time
-	UpdatedAt whereHelpertime_Time
+	ID             whereHelperstring
+	Name           whereHelperstring
+	Slug           whereHelperstring
+	Builtin        whereHelperbool
+	CreatedAt      whereHelpertime_Time
+	UpdatedAt      whereHelpertime_Time
+	PasswordPolicy whereHelpernull_JSON
+	MaxAge         whereHelpernull_Int64
 }{
-	ID:        whereHelperstring{field: "\"server_credential_types\".\"id\""},
-	Name:      whereHelperstring{field: "\"server_credential_types\".\"name\""},
-	Slug:      whereHelperstring{field: "\"server_credential_types\".\"slug\""},
-	Builtin:   whereHelperbool{field: "\"server_credential_types\".\"builtin\""},
-	CreatedAt: whereHelpertime_Time{field: "\"server_credential_types\".\"created_at\""},
-	UpdatedAt: whereHelpertime_Time{field: "\"server_credential_types\".\"updated_at\""},
+	ID:             whereHelperstring{field: "\"server_credential_types\".\"id\""},
+	Name:           whereHelperstring{field: "\"server_credential_types\".\"name\""},
+	Slug:           whereHelperstring{field: "\"server_credential_types\".\"slug\""},
+	Builtin:        whereHelperbool{field: "\"server_credential_types\".\"builtin\""},
+	CreatedAt:      whereHelpertime_Time{field: "\"server_credential_types\".\"created_at\""},
+	UpdatedAt:      whereHelpertime_Time{field: "\"server_credential_types\".\"updated_at\""},
+	PasswordPolicy: whereHelpernull_JSON{field: "\"server_credential_types\".\"password_policy\""},
+	MaxAge:         whereHelpernull_Int64{field: "\"server_credential_types\".\"max_age\""},
 }
 
 // ServerCredentialTypeRels is where relationship names are stored.
@@ -142,9 +184,9 @@ func (r *serverCredentialTypeR) GetServerCredentials() ServerCredentialSlice {
 type serverCredentialTypeL struct{}
 
 var (
-	serverCredentialTypeAllColumns            = []string{"id", "name", "slug", "builtin", "created_at", "updated_at"}
+	serverCredentialTypeAllColumns            = []string{"id", "name", "slug", "builtin", "created_at", "updated_at", "password_policy", "max_age"}
 	serverCredentialTypeColumnsWithoutDefault = []string{"name", "slug", "created_at", "updated_at"}
-	serverCredentialTypeColumnsWithDefault    = []string{"id", "builtin"}
+	serverCredentialTypeColumnsWithDefault    = []string{"id", "builtin", "password_policy", "max_age"}
 	serverCredentialTypePrimaryKeyColumns     = []string{"id"}
 	serverCredentialTypeGeneratedColumns      = []string{}
 )
@@ -770,7 +770,7 @@ func testServerCredentialTypesSelect(t *testing.T) {
 }
 
 var (
-	serverCredentialTypeDBTypes = map[string]string{`ID`: `uuid`, `Name`: `string`, `Slug`: `string`, `Builtin`: `bool`, `CreatedAt`: `timestamptz`, `UpdatedAt`: `timestamptz`}
+	serverCredentialTypeDBTypes = map[string]string{`ID`: `uuid`, `Name`: `string`, `Slug`: `string`, `Builtin`: `bool`, `CreatedAt`: `timestamptz`, `UpdatedAt`: `timestamptz`, `PasswordPolicy`: `jsonb`, `MaxAge`: `int8`}
 	_                           = bytes.MinRead
 )
 
@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
@@ -30,6 +31,7 @@ type ServerCredential struct {
 	CreatedAt              time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
 	UpdatedAt              time.Time `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
 	Username               string    `boil:"username" json:"username" toml:"username" yaml:"username"`
+	ExpiryNotifiedAt       null.Time `boil:"expiry_notified_at" json:"expiry_notified_at,omitempty" toml:"expiry_notified_at" yaml:"expiry_notified_at,omitempty"`
 
 	R *serverCredentialR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L serverCredentialL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -43,6 +45,7 @@ var ServerCredentialColumns = struct {
 	CreatedAt              string
 	UpdatedAt              string
 	Username               string
+	ExpiryNotifiedAt       string
 }{
 	ID:                     "id",
 	ServerID:               "server_id",
@@ -51,6 +54,7 @@ var ServerCredentialColumns = struct {
 	CreatedAt:              "created_at",
 	UpdatedAt:              "updated_at",
 	Username:               "username",
+	ExpiryNotifiedAt:       "expiry_notified_at",
 }
 
 var ServerCredentialTableColumns = struct {
@@ -61,6 +65,7 @@ var ServerCredentialTableColumns = struct {
 	CreatedAt              string
 	UpdatedAt              string
 	Username               string
+	ExpiryNotifiedAt       string
 }{
 	ID:                     "server_credentials.id",
 	ServerID:               "server_credentials.server_id",
@@ -69,6 +74,7 @@ var ServerCredentialTableColumns = struct {
 	CreatedAt:              "server_credentials.created_at",
 	UpdatedAt:              "server_credentials.updated_at",
 	Username:               "server_credentials.username",
+	ExpiryNotifiedAt:       "server_credentials.expiry_notified_at",
 }
 
 // Generated where
@@ -81,6 +87,7 @@ var ServerCredentialWhere = struct {
 	CreatedAt              whereHelpertime_Time
 	UpdatedAt              whereHelpertime_Time
 	Username               whereHelperstring
+	ExpiryNotifiedAt       whereHelpernull_Time
 }{
 	ID:                     whereHelperstring{field: "\"server_credentials\".\"id\""},
 	ServerID:               whereHelperstring{field: "\"server_credentials\".\"server_id\""},
@@ -89,21 +96,25 @@ var ServerCredentialWhere = struct {
 	CreatedAt:              whereHelpertime_Time{field: "\"server_credentials\".\"created_at\""},
 	UpdatedAt:              whereHelpertime_Time{field: "\"server_credentials\".\"updated_at\""},
 	Username:               whereHelperstring{field: "\"server_credentials\".\"username\""},
+	ExpiryNotifiedAt:       whereHelpernull_Time{field: "\"server_credentials\".\"expiry_notified_at\""},
 }
 
 // ServerCredentialRels is where relationship names are stored.
 var ServerCredentialRels = struct {
-	ServerCredentialType string
-	Server               string
+	ServerCredentialType     string
+	Server                   string
+	ServerCredentialVersions string
 }{
-	ServerCredentialType: "ServerCredentialType",
-	Server:               "Server",
+	ServerCredentialType:     "ServerCredentialType",
+	Server:                   "Server",
+	ServerCredentialVersions: "ServerCredentialVersions",
 }
 
 // serverCredentialR is where relationships are stored.
 type serverCredentialR struct {
-	ServerCredentialType *ServerCredentialType `boil:"ServerCredentialType" json:"ServerCredentialType" toml:"ServerCredentialType" yaml:"ServerCredentialType"`
-	Server               *Server               `boil:"Server" json:"Server" toml:"Server" yaml:"Server"`
+	ServerCredentialType     *ServerCredentialType        `boil:"ServerCredentialType" json:"ServerCredentialType" toml:"ServerCredentialType" yaml:"ServerCredentialType"`
+	Server                   *Server                      `boil:"Server" json:"Server" toml:"Server" yaml:"Server"`
+	ServerCredentialVersions ServerCredentialVersionSlice `boil:"ServerCredentialVersions" json:"ServerCredentialVersions" toml:"ServerCredentialVersions" yaml:"ServerCredentialVersions"`
 }
 
 // NewStruct creates a new relationship struct
@@ -125,13 +136,20 @@ func (r *serverCredentialR) GetServer() *Server {
 	return r.Server
 }
 
+func (r *serverCredentialR) GetServerCredentialVersions() ServerCredentialVersionSlice {
+	if r == nil {
+		return nil
+	}
+	return r.ServerCredentialVersions
+}
+
 // serverCredentialL is where Load methods for each relationship are stored.
 type serverCredentialL struct{}
 
 var (
-	serverCredentialAllColumns            = []string{"id", "server_id", "server_credential_type_id", "password", "created_at", "updated_at", "username"}
+	serverCredentialAllColumns            = []string{"id", "server_id", "server_credential_type_id", "password", "created_at", "updated_at", "username", "expiry_notified_at"}
 	serverCredentialColumnsWithoutDefault = []string{"server_id", "server_credential_type_id", "password", "created_at", "updated_at", "username"}
-	serverCredentialColumnsWithDefault    = []string{"id"}
+	serverCredentialColumnsWithDefault    = []string{"id", "expiry_notified_at"}
 	serverCredentialPrimaryKeyColumns     = []string{"id"}
 	serverCredentialGeneratedColumns      = []string{}
 )
@@ -436,6 +454,20 @@ func (o *ServerCredential) Server(mods ...qm.QueryMod) serverQuery {
 	return Servers(queryMods...)
 }
 
+// ServerCredentialVersions retrieves all the server_credential_version's ServerCredentialVersions with an executor.
+func (o *ServerCredential) ServerCredentialVersions(mods ...qm.QueryMod) serverCredentialVersionQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"server_credential_versions\".\"server_credential_id\"=?", o.ID),
+	)
+
+	return ServerCredentialVersions(queryMods...)
+}
+
 // LoadServerCredentialType allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for an N-1 relationship.
 func (serverCredentialL) LoadServerCredentialType(ctx context.Context, e boil.ContextExecutor, singular bool, maybeServerCredential interface{}, mods queries.Applicator) error {
@@ -645,6 +677,104 @@ func (serverCredentialL) LoadServer(ctx context.Context, e boil.ContextExecutor,
 	return nil
 }
 
+// LoadServerCredentialVersions allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (serverCredentialL) LoadServerCredentialVersions(ctx context.Context, e boil.ContextExecutor, singular bool, maybeServerCredential interface{}, mods queries.Applicator) error {
+	var slice []*ServerCredential
+	var object *ServerCredential
+
+	if singular {
+		object = maybeServerCredential.(*ServerCredential)
+	} else {
+		slice = *maybeServerCredential.(*[]*ServerCredential)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &serverCredentialR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &serverCredentialR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`server_credential_versions`),
+		qm.WhereIn(`server_credential_versions.server_credential_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load server_credential_versions")
+	}
+
+	var resultSlice []*ServerCredentialVersion
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice server_credential_versions")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on server_credential_versions")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for server_credential_versions")
+	}
+
+	if len(serverCredentialVersionAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.ServerCredentialVersions = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &serverCredentialVersionR{}
+			}
+			foreign.R.ServerCredential = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.ServerCredentialID {
+				local.R.ServerCredentialVersions = append(local.R.ServerCredentialVersions, foreign)
+				if foreign.R == nil {
+					foreign.R = &serverCredentialVersionR{}
+				}
+				foreign.R.ServerCredential = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
 // SetServerCredentialType of the serverCredential to the related item.
 // Sets o.R.ServerCredentialType to related.
 // Adds o to related.R.ServerCredentials.
@@ -739,6 +869,59 @@ func (o *ServerCredential) SetServer(ctx context.Context, exec boil.ContextExecu
 	return nil
 }
 
+// AddServerCredentialVersions adds the given related objects to the existing relationships
+// of the server_credential, optionally inserting them as new records.
+// Appends related to o.R.ServerCredentialVersions.
+// Sets related.R.ServerCredential appropriately.
+func (o *ServerCredential) AddServerCredentialVersions(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*ServerCredentialVersion) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.ServerCredentialID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"server_credential_versions\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"server_credential_id"}),
+				strmangle.WhereClause("\"", "\"", 2, serverCredentialVersionPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.ServerCredentialID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &serverCredentialR{
+			ServerCredentialVersions: related,
+		}
+	} else {
+		o.R.ServerCredentialVersions = append(o.R.ServerCredentialVersions, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &serverCredentialVersionR{
+				ServerCredential: o,
+			}
+		} else {
+			rel.R.ServerCredential = o
+		}
+	}
+	return nil
+}
+
 // ServerCredentials retrieves all the records using an executor.
 func ServerCredentials(mods ...qm.QueryMod) serverCredentialQuery {
 	mods = append(mods, qm.From("\"server_credentials\""))
@@ -154,6 +154,27 @@ func (p *AttributeListParams) queryMods(tblName string) qm.QueryMod {
 	return qm.Expr(queryMods...)
 }
 
+// whereClause returns the sql condition on the namespace and data of the attributes
+// table, for queries that can't be built from query mods
+func (p *AttributeListParams) whereClause(tblName string) (string, []interface{}) {
+	where := fmt.Sprintf("%s.namespace = ?", tblName)
+	values := []interface{}{p.Namespace}
+
+	if len(p.Keys) == 0 {
+		return where, values
+	}
+
+	jsonPath := strings.TrimSuffix(strings.Repeat("?, ", len(p.Keys)), ", ")
+
+	for _, k := range p.Keys {
+		values = append(values, k)
+	}
+
+	jsonb, values := p.setJSONBWhereClause(tblName, jsonPath, values)
+
+	return where + " AND " + jsonb, values
+}
+
 func (p *AttributeListParams) setJSONBWhereClause(tblName, jsonPath string, values []interface{}) (string, []interface{}) {
 	where := ""
 
@@ -317,3 +317,39 @@ func TestSetJSONBWhereClause(t *testing.T) {
 		})
 	}
 }
+
+func TestAttributeListParamsWhereClause(t *testing.T) {
+	testCases := []struct {
+		testName string
+		alp      AttributeListParams
+		where    string
+		values   []interface{}
+	}{
+		{
+			"namespace only",
+			AttributeListParams{Namespace: "sh.hollow.firmware_set.labels"},
+			"a.namespace = ?",
+			[]interface{}{"sh.hollow.firmware_set.labels"},
+		},
+		{
+			"equal",
+			AttributeListParams{Namespace: "sh.hollow.firmware_set.labels", Keys: []string{"model"}, Operator: OperatorEqual, Value: "r640"},
+			"a.namespace = ? AND json_extract_path_text(a.data::JSONB, ?) = ?",
+			[]interface{}{"sh.hollow.firmware_set.labels", "model", "r640"},
+		},
+		{
+			"key exists",
+			AttributeListParams{Namespace: "hollow.versioned", Keys: []string{"a", "b"}},
+			"a.namespace = ? AND a.data::JSONB -> ? \\? ?",
+			[]interface{}{"hollow.versioned", "a", "b"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			where, values := tc.alp.whereClause("a")
+			assert.Equal(t, tc.where, where)
+			assert.Equal(t, tc.values, values)
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package serverservice
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"go.hollow.sh/serverservice/internal/models"
+)
+
+const (
+	// CredentialAccessActionRead is recorded when a decrypted credential is returned to a client
+	CredentialAccessActionRead = "read"
+	// CredentialAccessActionGenerate is recorded when a generated credential is returned to a client
+	CredentialAccessActionGenerate = "generate"
+)
+
+// CredentialAccessEvent is an audit record of a client accessing a decrypted server credential
+type CredentialAccessEvent struct {
+	ServerID       uuid.UUID `json:"server_uuid"`
+	CredentialSlug string    `json:"credential_slug"`
+	Action         string    `json:"action"`
+	Subject        string    `json:"subject"`
+	User           string    `json:"user"`
+	SourceIP       string    `json:"source_ip"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+func (e *CredentialAccessEvent) fromDBModel(dbE *models.CredentialAccessEvent) error {
+	var err error
+
+	e.ServerID, err = uuid.Parse(dbE.ServerID)
+	if err != nil {
+		return err
+	}
+
+	e.CredentialSlug = dbE.CredentialSlug
+	e.Action = dbE.Action
+	e.Subject = dbE.Subject
+	e.User = dbE.Username
+	e.SourceIP = dbE.SourceIP
+	e.CreatedAt = dbE.CreatedAt
+
+	return nil
+}
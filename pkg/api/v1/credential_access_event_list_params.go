@@ -0,0 +1,110 @@
+package serverservice
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"go.hollow.sh/serverservice/internal/models"
+)
+
+// CredentialAccessEventListParams allows you to filter the credential access audit log
+type CredentialAccessEventListParams struct {
+	ServerID       string    `form:"server_uuid"`
+	CredentialSlug string    `form:"credential_slug"`
+	Action         string    `form:"action"`
+	Subject        string    `form:"subject"`
+	User           string    `form:"user"`
+	SourceIP       string    `form:"source_ip"`
+	Since          time.Time `form:"since"`
+	Until          time.Time `form:"until"`
+	Pagination     *PaginationParams
+}
+
+func (p *CredentialAccessEventListParams) setQuery(q url.Values) {
+	if p == nil {
+		return
+	}
+
+	if p.ServerID != "" {
+		q.Set("server_uuid", p.ServerID)
+	}
+
+	if p.CredentialSlug != "" {
+		q.Set("credential_slug", p.CredentialSlug)
+	}
+
+	if p.Action != "" {
+		q.Set("action", p.Action)
+	}
+
+	if p.Subject != "" {
+		q.Set("subject", p.Subject)
+	}
+
+	if p.User != "" {
+		q.Set("user", p.User)
+	}
+
+	if p.SourceIP != "" {
+		q.Set("source_ip", p.SourceIP)
+	}
+
+	if !p.Since.IsZero() {
+		q.Set("since", p.Since.Format(time.RFC3339))
+	}
+
+	if !p.Until.IsZero() {
+		q.Set("until", p.Until.Format(time.RFC3339))
+	}
+
+	p.Pagination.setQuery(q)
+}
+
+// queryMods converts the list params into sql conditions that can be added to sql queries
+func (p *CredentialAccessEventListParams) queryMods() []qm.QueryMod {
+	mods := []qm.QueryMod{}
+
+	if p.ServerID != "" {
+		m := models.CredentialAccessEventWhere.ServerID.EQ(p.ServerID)
+		mods = append(mods, m)
+	}
+
+	if p.CredentialSlug != "" {
+		m := models.CredentialAccessEventWhere.CredentialSlug.EQ(p.CredentialSlug)
+		mods = append(mods, m)
+	}
+
+	if p.Action != "" {
+		m := models.CredentialAccessEventWhere.Action.EQ(p.Action)
+		mods = append(mods, m)
+	}
+
+	if p.Subject != "" {
+		m := models.CredentialAccessEventWhere.Subject.EQ(p.Subject)
+		mods = append(mods, m)
+	}
+
+	if p.User != "" {
+		m := models.CredentialAccessEventWhere.Username.EQ(p.User)
+		mods = append(mods, m)
+	}
+
+	if p.SourceIP != "" {
+		m := models.CredentialAccessEventWhere.SourceIP.EQ(p.SourceIP)
+		mods = append(mods, m)
+	}
+
+	if !p.Since.IsZero() {
+		m := models.CredentialAccessEventWhere.CreatedAt.GTE(p.Since)
+		mods = append(mods, m)
+	}
+
+	if !p.Until.IsZero() {
+		m := models.CredentialAccessEventWhere.CreatedAt.LT(p.Until)
+		mods = append(mods, m)
+	}
+
+	return mods
+}
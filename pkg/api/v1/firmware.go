@@ -1,13 +1,68 @@
 package serverservice
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/volatiletech/null/v8"
 
+	"go.hollow.sh/serverservice/internal/fwverify"
 	"go.hollow.sh/serverservice/internal/models"
 )
 
+const (
+	// FirmwareStatusActive is the status of firmware that can be installed
+	FirmwareStatusActive = "active"
+	// FirmwareStatusDeprecated is the status of firmware that shouldn't be used for new installs
+	FirmwareStatusDeprecated = "deprecated"
+	// FirmwareStatusRecalled is the status of firmware the vendor pulled, it must not be installed
+	FirmwareStatusRecalled = "recalled"
+)
+
+const (
+	// FirmwareVerified is the verification status of firmware whose artifact has its checksum
+	FirmwareVerified = fwverify.StatusVerified
+	// FirmwareMismatch is the verification status of firmware whose artifact has another checksum
+	FirmwareMismatch = fwverify.StatusMismatch
+	// FirmwareVerifyFailed is the verification status of firmware whose artifact couldn't be read
+	FirmwareVerifyFailed = fwverify.StatusFailed
+	// FirmwareUnverified filters firmware that hasn't been verified, or whose checksum
+	// or repository URL changed since
+	FirmwareUnverified = "unverified"
+)
+
+var errFirmwareStatus = errors.New("invalid firmware status")
+
+// ComponentFirmwareStatus is the status of a firmware along with the reason it was set
+type ComponentFirmwareStatus struct {
+	Status string `json:"status" binding:"required"`
+	Reason string `json:"reason"`
+}
+
+// validate checks the status is known and that a reason is given for any status but active
+func (s *ComponentFirmwareStatus) validate() error {
+	switch s.Status {
+	case FirmwareStatusActive:
+		return nil
+	case FirmwareStatusDeprecated, FirmwareStatusRecalled:
+		if s.Reason == "" {
+			return fmt.Errorf("%w: a reason is required for %s firmware", errFirmwareStatus, s.Status)
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("%w: %q", errFirmwareStatus, s.Status)
+	}
+}
+
+// apply sets the status of the firmware, the reason is cleared for active firmware
+func (s *ComponentFirmwareStatus) apply(dbF *models.ComponentFirmwareVersion) {
+	dbF.Status = s.Status
+	dbF.StatusReason = null.NewString(s.Reason, s.Status != FirmwareStatusActive)
+}
+
 // ComponentFirmwareVersion represents a firmware file
 type ComponentFirmwareVersion struct {
 	UUID          uuid.UUID `json:"uuid"`
@@ -19,8 +74,15 @@ type ComponentFirmwareVersion struct {
 	Checksum      string    `json:"checksum" binding:"required,lowercase"`
 	UpstreamURL   string    `json:"upstream_url" binding:"required"`
 	RepositoryURL string    `json:"repository_url" binding:"required"`
-	CreatedAt     time.Time `json:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at"`
+	// Status is active, deprecated or recalled. It's left unchanged by an update when empty.
+	Status       string `json:"status,omitempty"`
+	StatusReason string `json:"status_reason,omitempty"`
+	// VerificationStatus is the result of the last check of the checksum of the artifact
+	// in the repository, it's set by the firmware verify command or job
+	VerificationStatus string     `json:"verification_status,omitempty"`
+	VerifiedAt         *time.Time `json:"verified_at,omitempty"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
 }
 
 func (f *ComponentFirmwareVersion) fromDBModel(dbF *models.ComponentFirmwareVersion) error {
@@ -39,6 +101,10 @@ func (f *ComponentFirmwareVersion) fromDBModel(dbF *models.ComponentFirmwareVers
 	f.Checksum = dbF.Checksum
 	f.UpstreamURL = dbF.UpstreamURL
 	f.RepositoryURL = dbF.RepositoryURL
+	f.Status = dbF.Status
+	f.StatusReason = dbF.StatusReason.String
+	f.VerificationStatus = dbF.VerificationStatus.String
+	f.VerifiedAt = dbF.VerifiedAt.Ptr()
 	f.CreatedAt = dbF.CreatedAt.Time
 	f.UpdatedAt = dbF.UpdatedAt.Time
 
@@ -61,5 +127,14 @@ func (f *ComponentFirmwareVersion) toDBModel() (*models.ComponentFirmwareVersion
 		dbF.ID = f.UUID.String()
 	}
 
+	if f.Status != "" {
+		status := ComponentFirmwareStatus{Status: f.Status, Reason: f.StatusReason}
+		if err := status.validate(); err != nil {
+			return nil, err
+		}
+
+		status.apply(dbF)
+	}
+
 	return dbF, nil
 }
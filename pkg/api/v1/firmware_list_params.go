@@ -2,21 +2,52 @@ package serverservice
 
 import (
 	"net/url"
+	"strings"
+	"time"
 
 	"github.com/volatiletech/sqlboiler/types"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 
+	"go.hollow.sh/serverservice/internal/fwversion"
 	"go.hollow.sh/serverservice/internal/models"
 )
 
 // ComponentFirmwareVersionListParams allows you to filter the results
 type ComponentFirmwareVersionListParams struct {
-	Vendor     string   `form:"vendor"`
-	Model      []string `form:"model"`
-	Version    string   `form:"version"`
-	Filename   string   `form:"filename"`
-	Checksum   string   `form:"checksum"`
-	Pagination *PaginationParams
+	Vendor    string   `form:"vendor"`
+	Model     []string `form:"model"`
+	Version   string   `form:"version"`
+	Filename  string   `form:"filename"`
+	Checksum  string   `form:"checksum"`
+	Component string   `form:"component"`
+	// ModelLike limits the results to firmware with a model matching the LIKE pattern,
+	// ignoring case. Without a % or _ wildcard it matches the models starting with it.
+	ModelLike string `form:"model_like"`
+	// UpdatedSince limits the results to firmware created or updated since the time
+	UpdatedSince time.Time `form:"updated_since"`
+	// Query limits the results to firmware with the text in the filename, version or
+	// one of the models, ignoring case
+	Query string `form:"q"`
+	// VersionGT and VersionLT limit the results to firmware with a version higher or
+	// lower than the given version. Versions are compared by their numeric and
+	// alphabetic segments, so 2.14.1 is higher than 2.9.0 and A22 is higher than A9.
+	VersionGT string `form:"version_gt"`
+	VersionLT string `form:"version_lt"`
+	// Latest limits the results to the firmware with the highest version for each
+	// vendor, component and model among the firmware matching the other filters. With
+	// a Model filter only those models are considered.
+	Latest bool `form:"latest"`
+	// Status limits the results to firmware with one of the statuses, recalled
+	// firmware is excluded when it's empty
+	Status []string `form:"status"`
+	// VerificationStatus limits the results to firmware with one of the verification
+	// statuses, use mismatch to find the artifacts that don't match their checksum
+	// and unverified for the firmware that hasn't been verified
+	VerificationStatus []string `form:"verification_status"`
+	// FirmwareSetAttributeListParams limits the results to firmware in a firmware set
+	// with matching attributes, they're encoded like the attr params of firmware sets
+	FirmwareSetAttributeListParams []AttributeListParams
+	Pagination                     *PaginationParams
 }
 
 func (p *ComponentFirmwareVersionListParams) setQuery(q url.Values) {
@@ -46,6 +77,44 @@ func (p *ComponentFirmwareVersionListParams) setQuery(q url.Values) {
 		q.Set("checksum", p.Checksum)
 	}
 
+	if p.Component != "" {
+		q.Set("component", p.Component)
+	}
+
+	if p.ModelLike != "" {
+		q.Set("model_like", p.ModelLike)
+	}
+
+	if !p.UpdatedSince.IsZero() {
+		q.Set("updated_since", p.UpdatedSince.Format(time.RFC3339))
+	}
+
+	if p.Query != "" {
+		q.Set("q", p.Query)
+	}
+
+	if p.VersionGT != "" {
+		q.Set("version_gt", p.VersionGT)
+	}
+
+	if p.VersionLT != "" {
+		q.Set("version_lt", p.VersionLT)
+	}
+
+	if p.Latest {
+		q.Set("latest", "true")
+	}
+
+	for _, status := range p.Status {
+		q.Add("status", status)
+	}
+
+	for _, status := range p.VerificationStatus {
+		q.Add("verification_status", status)
+	}
+
+	encodeAttributesListParams(p.FirmwareSetAttributeListParams, "set_attr", q)
+
 	p.Pagination.setQuery(q)
 }
 
@@ -53,30 +122,192 @@ func (p *ComponentFirmwareVersionListParams) setQuery(q url.Values) {
 func (p *ComponentFirmwareVersionListParams) queryMods() []qm.QueryMod {
 	mods := []qm.QueryMod{}
 
-	if p.Vendor != "" {
-		m := models.ComponentFirmwareVersionWhere.Vendor.EQ(p.Vendor)
-		mods = append(mods, m)
-	}
-
 	if p.Model != nil {
 		m := qm.Where("model @> ?", types.StringArray(p.Model))
 		mods = append(mods, m)
 	}
 
+	for _, f := range p.filters(models.TableNames.ComponentFirmwareVersion) {
+		mods = append(mods, qm.Where(f.clause, f.args...))
+	}
+
+	if p.Latest {
+		mods = append(mods, p.latestQueryMod())
+	}
+
+	return mods
+}
+
+// firmwareFilter is a sql condition on the firmware along with its arguments
+type firmwareFilter struct {
+	clause string
+	args   []interface{}
+}
+
+// filters returns the conditions of the params on the firmware of the table, or table
+// alias, but the model and latest filters. Latest compares the firmware with the newer
+// firmware matching the same conditions.
+func (p *ComponentFirmwareVersionListParams) filters(table string) []firmwareFilter {
+	filters := []firmwareFilter{}
+
+	add := func(clause string, args ...interface{}) {
+		filters = append(filters, firmwareFilter{clause: strings.ReplaceAll(clause, "{t}", table), args: args})
+	}
+
+	if p.Vendor != "" {
+		add("{t}.vendor = ?", p.Vendor)
+	}
+
 	if p.Version != "" {
-		m := models.ComponentFirmwareVersionWhere.Version.EQ(p.Version)
-		mods = append(mods, m)
+		add("{t}.version = ?", p.Version)
 	}
 
 	if p.Filename != "" {
-		m := models.ComponentFirmwareVersionWhere.Filename.EQ(p.Filename)
-		mods = append(mods, m)
+		add("{t}.filename = ?", p.Filename)
 	}
 
 	if p.Checksum != "" {
-		m := models.ComponentFirmwareVersionWhere.Checksum.EQ(p.Checksum)
-		mods = append(mods, m)
+		add("{t}.checksum = ?", p.Checksum)
 	}
 
-	return mods
+	if p.Component != "" {
+		add("{t}.component = ?", p.Component)
+	}
+
+	if p.ModelLike != "" {
+		pattern := p.ModelLike
+		if !strings.ContainsAny(pattern, "%_") {
+			pattern += "%"
+		}
+
+		add("EXISTS (SELECT 1 FROM unnest({t}.model) AS ml(model) WHERE ml.model ILIKE ?)", pattern)
+	}
+
+	if !p.UpdatedSince.IsZero() {
+		add("{t}.updated_at >= ?", p.UpdatedSince)
+	}
+
+	if p.Query != "" {
+		text := "%" + likeEscaper.Replace(p.Query) + "%"
+		add(`({t}.filename ILIKE ? OR {t}.version ILIKE ?
+			OR EXISTS (SELECT 1 FROM unnest({t}.model) AS mq(model) WHERE mq.model ILIKE ?))`, text, text, text)
+	}
+
+	if p.VersionGT != "" {
+		add("{t}.version_sort_key > ?", fwversion.SortKey(p.VersionGT))
+	}
+
+	if p.VersionLT != "" {
+		add("{t}.version_sort_key < ?", fwversion.SortKey(p.VersionLT))
+	}
+
+	if len(p.Status) != 0 {
+		add("{t}.status = ANY(?)", types.StringArray(p.Status))
+	} else {
+		add("{t}.status <> ?", FirmwareStatusRecalled)
+	}
+
+	if len(p.VerificationStatus) != 0 {
+		clause, args := p.verificationFilter()
+		add(clause, args...)
+	}
+
+	if len(p.FirmwareSetAttributeListParams) != 0 {
+		clause, args := p.firmwareSetAttributesFilter()
+		add(clause, args...)
+	}
+
+	return filters
+}
+
+// likeEscaper escapes the LIKE wildcards in text matched literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// firmwareSetAttributesFilter keeps the firmware in a firmware set with attributes
+// matching each of the params, params with the OR operator are alternatives to the
+// params before them, as they are for firmware sets
+func (p *ComponentFirmwareVersionListParams) firmwareSetAttributesFilter() (string, []interface{}) {
+	where := ""
+	args := []interface{}{}
+
+	for i, lp := range p.FirmwareSetAttributeListParams {
+		clause, values := lp.whereClause("a")
+
+		if i > 0 {
+			if lp.AttributeOperator == AttributeLogicalOR {
+				where += " OR "
+			} else {
+				where += " AND "
+			}
+		}
+
+		where += `EXISTS (
+			SELECT 1 FROM component_firmware_set_map AS sm
+			JOIN attributes_firmware_set AS a ON a.firmware_set_id = sm.firmware_set_id
+			WHERE sm.firmware_id = {t}.id AND ` + clause + `
+		)`
+
+		args = append(args, values...)
+	}
+
+	return "(" + where + ")", args
+}
+
+// verificationFilter keeps the firmware with one of the verification statuses,
+// unverified firmware has none
+func (p *ComponentFirmwareVersionListParams) verificationFilter() (string, []interface{}) {
+	statuses := []string{}
+	unverified := false
+
+	for _, status := range p.VerificationStatus {
+		if status == FirmwareUnverified {
+			unverified = true
+			continue
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	switch {
+	case unverified && len(statuses) != 0:
+		return "({t}.verification_status = ANY(?) OR {t}.verification_status IS NULL)", []interface{}{types.StringArray(statuses)}
+	case unverified:
+		return "{t}.verification_status IS NULL", nil
+	default:
+		return "{t}.verification_status = ANY(?)", []interface{}{types.StringArray(statuses)}
+	}
+}
+
+// latestQueryMod keeps the firmware that no other firmware of the same vendor and
+// component, matching the same filters, has a higher version for, for at least one of
+// its models. A higher version that the filters leave out doesn't hide the latest one
+// they keep.
+func (p *ComponentFirmwareVersionListParams) latestQueryMod() qm.QueryMod {
+	modelFilter := ""
+	args := []interface{}{}
+
+	if p.Model != nil {
+		modelFilter = "m.model = ANY(?) AND "
+
+		args = append(args, types.StringArray(p.Model))
+	}
+
+	newerFilter := ""
+
+	for _, f := range p.filters("newer") {
+		newerFilter += " AND " + f.clause
+
+		args = append(args, f.args...)
+	}
+
+	return qm.Where(`EXISTS (
+		SELECT 1 FROM unnest(component_firmware_version.model) AS m(model)
+		WHERE `+modelFilter+`NOT EXISTS (
+			SELECT 1 FROM component_firmware_version AS newer
+			WHERE newer.vendor = component_firmware_version.vendor
+			AND newer.component = component_firmware_version.component
+			AND m.model = ANY(newer.model)
+			AND newer.version_sort_key > component_firmware_version.version_sort_key`+newerFilter+`
+		)
+	)`, args...)
 }
@@ -0,0 +1,355 @@
+package serverservice
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"go.hollow.sh/serverservice/internal/fwversion"
+	"go.hollow.sh/serverservice/internal/models"
+)
+
+// FirmwareInstalledNamespace is the namespace of the component versioned attributes that
+// report the installed firmware, as in {"firmware": {"installed": "2.6.6"}}
+const FirmwareInstalledNamespace = "sh.hollow.alloy.outofband.status"
+
+// ServerVendorAttributesNamespace is the namespace of the server attributes with the vendor
+// and hardware model of the server, as in {"vendor": "dell", "model": "r640"}
+const ServerVendorAttributesNamespace = "sh.hollow.alloy.server_vendor_attributes"
+
+const (
+	// FirmwarePlanSkipUpToDate is the reason a component at or above the version in the set is skipped
+	FirmwarePlanSkipUpToDate = "up to date"
+	// FirmwarePlanSkipRecalled is the reason a component whose firmware in the set is recalled is skipped
+	FirmwarePlanSkipRecalled = "recalled"
+	// FirmwarePlanUnplannedNoFirmware is the reason a component the set has no firmware for isn't planned
+	FirmwarePlanUnplannedNoFirmware = "no firmware in the set"
+)
+
+// firmwareInstallOrder ranks the components that have to be updated before the others,
+// the BMC installs the other firmware so it's updated first
+var firmwareInstallOrder = map[string]int{
+	"bmc":  0,
+	"bios": 1,
+}
+
+// ServerFirmwarePlan is the ordered list of firmware to install on the components of a
+// server to bring them to the versions of a firmware set
+type ServerFirmwarePlan struct {
+	ServerUUID      uuid.UUID `json:"server_uuid"`
+	FirmwareSetUUID uuid.UUID `json:"firmware_set_uuid"`
+	FirmwareSetName string    `json:"firmware_set_name"`
+	// Model is the hardware model of the server the firmware is for
+	Model string `json:"model"`
+	// Steps are in the order the firmware is installed
+	Steps []ServerFirmwarePlanStep `json:"steps"`
+	// Skipped are the components with firmware in the set that don't need an install
+	Skipped []ServerFirmwarePlanStep `json:"skipped"`
+	// Unplanned are the components the set has no firmware for
+	Unplanned []ServerFirmwarePlanUnplanned `json:"unplanned"`
+}
+
+// ServerFirmwarePlanStep is the firmware to install on a component. Installed is empty
+// when the component doesn't report its firmware.
+type ServerFirmwarePlanStep struct {
+	ComponentUUID uuid.UUID `json:"component_uuid"`
+	ComponentSlug string    `json:"component_slug"`
+	ComponentName string    `json:"component_name"`
+	Vendor        string    `json:"vendor"`
+	Model         string    `json:"model"`
+	Serial        string    `json:"serial"`
+	Installed     string    `json:"installed"`
+	FirmwareUUID  uuid.UUID `json:"firmware_uuid"`
+	Version       string    `json:"version"`
+	Filename      string    `json:"filename"`
+	RepositoryURL string    `json:"repository_url"`
+	Checksum      string    `json:"checksum"`
+	// Deprecated is set when the firmware in the set is deprecated, it's still installed
+	// but the set should be moved to a newer firmware
+	Deprecated bool `json:"deprecated,omitempty"`
+	// Reason is why a skipped component isn't updated
+	Reason string `json:"reason,omitempty"`
+}
+
+// ServerFirmwarePlanUnplanned is a component of the server the plan doesn't update, along
+// with the reason
+type ServerFirmwarePlanUnplanned struct {
+	ComponentUUID uuid.UUID `json:"component_uuid"`
+	ComponentSlug string    `json:"component_slug"`
+	ComponentName string    `json:"component_name"`
+	Vendor        string    `json:"vendor"`
+	Model         string    `json:"model"`
+	Serial        string    `json:"serial"`
+	Installed     string    `json:"installed"`
+	Reason        string    `json:"reason"`
+}
+
+// serverFirmwarePlanParams are the query params of a firmware plan request
+type serverFirmwarePlanParams struct {
+	FirmwareSet uuid.UUID
+	Model       string
+	Namespace   string
+}
+
+func (p *serverFirmwarePlanParams) setQuery(q url.Values) {
+	q.Set("firmware_set", p.FirmwareSet.String())
+
+	if p.Model != "" {
+		q.Set("model", p.Model)
+	}
+
+	if p.Namespace != "" {
+		q.Set("namespace", p.Namespace)
+	}
+}
+
+// installedFirmware returns the firmware version reported in the versioned attributes of
+// the component in the namespace, the latest report is used when there are several
+func installedFirmware(dbC *models.ServerComponent, namespace string) string {
+	if dbC.R == nil {
+		return ""
+	}
+
+	var latest *models.VersionedAttribute
+
+	for _, va := range dbC.R.VersionedAttributes {
+		if va.Namespace != namespace {
+			continue
+		}
+
+		if latest == nil || va.CreatedAt.Time.After(latest.CreatedAt.Time) {
+			latest = va
+		}
+	}
+
+	if latest == nil {
+		return ""
+	}
+
+	return installedVersion(latest)
+}
+
+// installedVersion returns the installed firmware version reported in the versioned
+// attributes, it's empty when they don't have one
+func installedVersion(va *models.VersionedAttribute) string {
+	var data struct {
+		Firmware struct {
+			Installed string `json:"installed"`
+		} `json:"firmware"`
+	}
+
+	if err := json.Unmarshal(va.Data, &data); err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(data.Firmware.Installed)
+}
+
+// serverHardwareModel returns the hardware model in the vendor attributes of the server,
+// it's empty when the attributes don't have one
+func serverHardwareModel(attrs *models.Attribute) string {
+	var data struct {
+		Model string `json:"model"`
+	}
+
+	if err := json.Unmarshal(attrs.Data, &data); err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(data.Model)
+}
+
+// planFirmware returns the firmware in the set for the component, matched on the component
+// type, vendor and hardware model. It returns nil when no firmware matches, and an error
+// when more than one does because the installer couldn't tell which to use.
+func planFirmware(slug, vendor, model string, firmwares []*models.ComponentFirmwareVersion) (*models.ComponentFirmwareVersion, error) {
+	var match *models.ComponentFirmwareVersion
+
+	for _, f := range firmwares {
+		if !strings.EqualFold(f.Component, slug) {
+			continue
+		}
+
+		if vendor != "" && !strings.EqualFold(f.Vendor, vendor) {
+			continue
+		}
+
+		if !firmwareForModel(f, model) {
+			continue
+		}
+
+		if match != nil && match.ID != f.ID {
+			return nil, fmt.Errorf("%w: firmware '%s' (%s) and '%s' (%s) are both for the %s %s of model %s",
+				errServerFirmwarePlan, match.ID, match.Version, f.ID, f.Version, vendor, slug, model)
+		}
+
+		match = f
+	}
+
+	return match, nil
+}
+
+// firmwareForModel returns if the firmware is for the hardware model, ignoring case
+func firmwareForModel(f *models.ComponentFirmwareVersion, model string) bool {
+	for _, m := range f.Model {
+		if strings.EqualFold(m, model) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// newServerFirmwarePlan compares the installed firmware of the components to the firmware
+// in the set for the hardware model. Components at or above the version in the set, and
+// components whose firmware is recalled, are skipped. Components without firmware in the
+// set are listed as unplanned. It returns an error when the set has no firmware for the
+// model.
+func newServerFirmwarePlan(srv *models.Server, firmwareSet *models.ComponentFirmwareSet, model string, components models.ServerComponentSlice, firmwares []*models.ComponentFirmwareVersion, namespace string) (*ServerFirmwarePlan, error) {
+	forModel := false
+
+	for _, f := range firmwares {
+		if firmwareForModel(f, model) {
+			forModel = true
+			break
+		}
+	}
+
+	if !forModel {
+		return nil, fmt.Errorf("%w: firmware set %s has no firmware for model %s", errServerFirmwarePlan, firmwareSet.Name, model)
+	}
+
+	plan := &ServerFirmwarePlan{
+		FirmwareSetName: firmwareSet.Name,
+		Model:           model,
+		Steps:           []ServerFirmwarePlanStep{},
+		Skipped:         []ServerFirmwarePlanStep{},
+		Unplanned:       []ServerFirmwarePlanUnplanned{},
+	}
+
+	var err error
+
+	plan.ServerUUID, err = uuid.Parse(srv.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	plan.FirmwareSetUUID, err = uuid.Parse(firmwareSet.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dbC := range components {
+		slug := ""
+		if dbC.R != nil && dbC.R.ServerComponentType != nil {
+			slug = dbC.R.ServerComponentType.Slug
+		}
+
+		var f *models.ComponentFirmwareVersion
+
+		f, err = planFirmware(slug, dbC.Vendor.String, model, firmwares)
+		if err != nil {
+			return nil, err
+		}
+
+		if f == nil {
+			unplanned := ServerFirmwarePlanUnplanned{
+				ComponentSlug: slug,
+				ComponentName: dbC.Name.String,
+				Vendor:        dbC.Vendor.String,
+				Model:         dbC.Model.String,
+				Serial:        dbC.Serial.String,
+				Installed:     installedFirmware(dbC, namespace),
+				Reason:        FirmwarePlanUnplannedNoFirmware,
+			}
+
+			unplanned.ComponentUUID, err = uuid.Parse(dbC.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			plan.Unplanned = append(plan.Unplanned, unplanned)
+
+			continue
+		}
+
+		step := ServerFirmwarePlanStep{
+			ComponentSlug: slug,
+			ComponentName: dbC.Name.String,
+			Vendor:        dbC.Vendor.String,
+			Model:         dbC.Model.String,
+			Serial:        dbC.Serial.String,
+			Installed:     installedFirmware(dbC, namespace),
+			Version:       f.Version,
+			Filename:      f.Filename,
+			RepositoryURL: f.RepositoryURL,
+			Checksum:      f.Checksum,
+			Deprecated:    f.Status == FirmwareStatusDeprecated,
+		}
+
+		step.ComponentUUID, err = uuid.Parse(dbC.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		step.FirmwareUUID, err = uuid.Parse(f.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case f.Status == FirmwareStatusRecalled:
+			step.Reason = FirmwarePlanSkipRecalled
+			plan.Skipped = append(plan.Skipped, step)
+		case step.Installed != "" && fwversion.Compare(step.Installed, f.Version) >= 0:
+			step.Reason = FirmwarePlanSkipUpToDate
+			plan.Skipped = append(plan.Skipped, step)
+		default:
+			plan.Steps = append(plan.Steps, step)
+		}
+	}
+
+	sortFirmwarePlanSteps(plan.Steps)
+	sortFirmwarePlanSteps(plan.Skipped)
+
+	sort.SliceStable(plan.Unplanned, func(i, j int) bool {
+		a, b := plan.Unplanned[i], plan.Unplanned[j]
+
+		if a.ComponentSlug != b.ComponentSlug {
+			return a.ComponentSlug < b.ComponentSlug
+		}
+
+		return a.Serial < b.Serial
+	})
+
+	return plan, nil
+}
+
+// sortFirmwarePlanSteps orders the steps by install order, then by component type and serial
+func sortFirmwarePlanSteps(steps []ServerFirmwarePlanStep) {
+	rank := func(slug string) int {
+		if r, ok := firmwareInstallOrder[strings.ToLower(slug)]; ok {
+			return r
+		}
+
+		return len(firmwareInstallOrder)
+	}
+
+	sort.SliceStable(steps, func(i, j int) bool {
+		a, b := steps[i], steps[j]
+
+		if ra, rb := rank(a.ComponentSlug), rank(b.ComponentSlug); ra != rb {
+			return ra < rb
+		}
+
+		if a.ComponentSlug != b.ComponentSlug {
+			return a.ComponentSlug < b.ComponentSlug
+		}
+
+		return a.Serial < b.Serial
+	})
+}
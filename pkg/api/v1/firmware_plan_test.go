@@ -0,0 +1,147 @@
+package serverservice
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/types"
+
+	"go.hollow.sh/serverservice/internal/models"
+)
+
+func TestNewServerFirmwarePlan(t *testing.T) {
+	srv := &models.Server{ID: uuid.NewString()}
+	firmwareSet := &models.ComponentFirmwareSet{ID: uuid.NewString(), Name: "r640"}
+
+	r640 := types.StringArray{"R640"}
+
+	bmc := &models.ComponentFirmwareVersion{ID: uuid.NewString(), Vendor: "Dell", Model: r640, Component: "bmc", Version: "5.10.00.00", Filename: "idrac.exe", RepositoryURL: "https://repo/bmc", Checksum: "aa"}
+	bios := &models.ComponentFirmwareVersion{ID: uuid.NewString(), Vendor: "Dell", Model: r640, Component: "bios", Version: "2.4.4", Filename: "bios.exe", RepositoryURL: "https://repo/bios", Checksum: "bb", Status: FirmwareStatusDeprecated}
+	nic := &models.ComponentFirmwareVersion{ID: uuid.NewString(), Vendor: "Intel", Model: r640, Component: "nic", Version: "22.0.9", Filename: "nic.bin", RepositoryURL: "https://repo/nic", Checksum: "cc"}
+	cpld := &models.ComponentFirmwareVersion{ID: uuid.NewString(), Vendor: "Dell", Model: r640, Component: "cpld", Version: "1.0.1", Status: FirmwareStatusRecalled}
+
+	installed := func(version string, at time.Time) *models.VersionedAttribute {
+		return &models.VersionedAttribute{
+			Namespace: FirmwareInstalledNamespace,
+			Data:      types.JSON(`{"firmware":{"installed":"` + version + `"}}`),
+			CreatedAt: null.TimeFrom(at),
+		}
+	}
+
+	component := func(slug, vendor, serial string, attrs ...*models.VersionedAttribute) *models.ServerComponent {
+		c := &models.ServerComponent{
+			ID:     uuid.NewString(),
+			Vendor: null.StringFrom(vendor),
+			Serial: null.StringFrom(serial),
+		}
+		c.R = c.R.NewStruct()
+		c.R.ServerComponentType = &models.ServerComponentType{Slug: slug}
+		c.R.VersionedAttributes = attrs
+
+		return c
+	}
+
+	now := time.Now()
+
+	components := models.ServerComponentSlice{
+		component("nic", "intel", "nic-2", installed("22.0.9", now)),
+		component("nic", "intel", "nic-1", installed("21.5.9", now)),
+		component("bios", "dell", "bios", installed("2.10.0", now.Add(-time.Hour)), installed("2.2.0", now)),
+		component("cpld", "dell", "cpld"),
+		component("bmc", "dell", "bmc"),
+		component("drive", "micron", "drive", installed("1.0", now)),
+	}
+
+	plan, err := newServerFirmwarePlan(srv, firmwareSet, "r640", components, []*models.ComponentFirmwareVersion{nic, cpld, bios, bmc}, FirmwareInstalledNamespace)
+	require.NoError(t, err)
+
+	assert.Equal(t, srv.ID, plan.ServerUUID.String())
+	assert.Equal(t, firmwareSet.ID, plan.FirmwareSetUUID.String())
+	assert.Equal(t, "r640", plan.FirmwareSetName)
+	assert.Equal(t, "r640", plan.Model)
+
+	steps := []string{}
+	for _, s := range plan.Steps {
+		steps = append(steps, s.Serial+" "+s.Installed+" -> "+s.Version)
+	}
+
+	assert.Equal(t, []string{"bmc  -> 5.10.00.00", "bios 2.2.0 -> 2.4.4", "nic-1 21.5.9 -> 22.0.9"}, steps, "the BMC goes first, the latest report of the BIOS is used")
+	assert.Equal(t, "https://repo/bmc", plan.Steps[0].RepositoryURL)
+	assert.Equal(t, "aa", plan.Steps[0].Checksum)
+	assert.Equal(t, bmc.ID, plan.Steps[0].FirmwareUUID.String())
+	assert.False(t, plan.Steps[0].Deprecated)
+	assert.True(t, plan.Steps[1].Deprecated, "deprecated firmware is still installed but flagged")
+
+	skipped := map[string]string{}
+	for _, s := range plan.Skipped {
+		skipped[s.Serial] = s.Reason
+	}
+
+	assert.Equal(t, map[string]string{"nic-2": FirmwarePlanSkipUpToDate, "cpld": FirmwarePlanSkipRecalled}, skipped)
+
+	require.Len(t, plan.Unplanned, 1)
+	assert.Equal(t, components[5].ID, plan.Unplanned[0].ComponentUUID.String())
+	assert.Equal(t, "drive", plan.Unplanned[0].ComponentSlug)
+	assert.Equal(t, "1.0", plan.Unplanned[0].Installed)
+	assert.Equal(t, FirmwarePlanUnplannedNoFirmware, plan.Unplanned[0].Reason)
+}
+
+func TestNewServerFirmwarePlanModels(t *testing.T) {
+	srv := &models.Server{ID: uuid.NewString()}
+	firmwareSet := &models.ComponentFirmwareSet{ID: uuid.NewString(), Name: "dell"}
+
+	r640BIOS := &models.ComponentFirmwareVersion{ID: uuid.NewString(), Vendor: "Dell", Model: types.StringArray{"R640"}, Component: "bios", Version: "2.4.4"}
+	r6515BIOS := &models.ComponentFirmwareVersion{ID: uuid.NewString(), Vendor: "Dell", Model: types.StringArray{"R6515", "R7515"}, Component: "bios", Version: "2.6.6"}
+
+	bios := &models.ServerComponent{ID: uuid.NewString(), Vendor: null.StringFrom("dell"), Serial: null.StringFrom("bios")}
+	bios.R = bios.R.NewStruct()
+	bios.R.ServerComponentType = &models.ServerComponentType{Slug: "bios"}
+
+	components := models.ServerComponentSlice{bios}
+	firmwares := []*models.ComponentFirmwareVersion{r640BIOS, r6515BIOS}
+
+	plan, err := newServerFirmwarePlan(srv, firmwareSet, "r6515", components, firmwares, FirmwareInstalledNamespace)
+	require.NoError(t, err)
+	require.Len(t, plan.Steps, 1)
+	assert.Equal(t, r6515BIOS.ID, plan.Steps[0].FirmwareUUID.String(), "the firmware for the server model is used")
+
+	plan, err = newServerFirmwarePlan(srv, firmwareSet, "R640", components, firmwares, FirmwareInstalledNamespace)
+	require.NoError(t, err)
+	require.Len(t, plan.Steps, 1)
+	assert.Equal(t, r640BIOS.ID, plan.Steps[0].FirmwareUUID.String())
+
+	_, err = newServerFirmwarePlan(srv, firmwareSet, "R740", components, firmwares, FirmwareInstalledNamespace)
+	assert.ErrorIs(t, err, errServerFirmwarePlan)
+	assert.ErrorContains(t, err, "has no firmware for model R740")
+
+	otherR640BIOS := &models.ComponentFirmwareVersion{ID: uuid.NewString(), Vendor: "dell", Model: types.StringArray{"r640"}, Component: "BIOS", Version: "2.5.0"}
+
+	_, err = newServerFirmwarePlan(srv, firmwareSet, "R640", components, append(firmwares, otherR640BIOS), FirmwareInstalledNamespace)
+	assert.ErrorIs(t, err, errServerFirmwarePlan)
+	assert.ErrorContains(t, err, "are both for the dell bios of model R640")
+}
+
+func TestServerHardwareModel(t *testing.T) {
+	assert.Equal(t, "r640", serverHardwareModel(&models.Attribute{Data: types.JSON(`{"vendor":"dell","model":" r640 "}`)}))
+	assert.Empty(t, serverHardwareModel(&models.Attribute{Data: types.JSON(`{"vendor":"dell"}`)}))
+	assert.Empty(t, serverHardwareModel(&models.Attribute{Data: types.JSON(`[]`)}))
+}
+
+func TestInstalledFirmware(t *testing.T) {
+	c := &models.ServerComponent{}
+	assert.Empty(t, installedFirmware(c, FirmwareInstalledNamespace))
+
+	c.R = c.R.NewStruct()
+	c.R.VersionedAttributes = models.VersionedAttributeSlice{
+		{Namespace: "other", Data: types.JSON(`{"firmware":{"installed":"1.0"}}`)},
+		{Namespace: FirmwareInstalledNamespace, Data: types.JSON(`{"firmware":{"installed":" 2.0 "}}`)},
+	}
+
+	assert.Equal(t, "2.0", installedFirmware(c, FirmwareInstalledNamespace))
+	assert.Equal(t, "1.0", installedFirmware(c, "other"))
+	assert.Empty(t, installedFirmware(c, "missing"))
+}
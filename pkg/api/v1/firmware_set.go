@@ -2,6 +2,9 @@ package serverservice
 
 import (
 	"encoding/json"
+	"net/url"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -18,6 +21,9 @@ type ComponentFirmwareSet struct {
 	Attributes        []Attributes               `json:"attributes"`
 	ComponentFirmware []ComponentFirmwareVersion `json:"component_firmware"`
 	UUID              uuid.UUID                  `json:"uuid"`
+	// Deprecated and Recalled are set when the set includes firmware with that status
+	Deprecated bool `json:"deprecated"`
+	Recalled   bool `json:"recalled"`
 }
 
 func convertFromDBModelAttributesFirmwareSet(dbAttrsFirmwareSet models.AttributesFirmwareSetSlice) ([]Attributes, error) {
@@ -65,6 +71,13 @@ func (s *ComponentFirmwareSet) fromDBModel(dbFS *models.ComponentFirmwareSet, fi
 		}
 
 		s.ComponentFirmware = append(s.ComponentFirmware, f)
+
+		switch f.Status {
+		case FirmwareStatusDeprecated:
+			s.Deprecated = true
+		case FirmwareStatusRecalled:
+			s.Recalled = true
+		}
 	}
 
 	// relation attributes
@@ -81,6 +94,79 @@ func (s *ComponentFirmwareSet) fromDBModel(dbFS *models.ComponentFirmwareSet, fi
 	return nil
 }
 
+// ComponentFirmwareSetCoverage reports the components of a hardware model that a firmware
+// set has firmware for. Missing lists the expected components without firmware in the set.
+type ComponentFirmwareSetCoverage struct {
+	Model    string   `json:"model"`
+	Expected []string `json:"expected"`
+	Covered  []string `json:"covered"`
+	Missing  []string `json:"missing"`
+}
+
+// componentFirmwareSetCoverageParams are the query params of a firmware set coverage request
+type componentFirmwareSetCoverageParams struct {
+	Model      string
+	Components []string
+}
+
+func (p *componentFirmwareSetCoverageParams) setQuery(q url.Values) {
+	q.Set("model", p.Model)
+
+	if len(p.Components) != 0 {
+		q.Set("component", strings.Join(p.Components, ","))
+	}
+}
+
+// newComponentFirmwareSetCoverage compares the components of the firmware for the model
+// to the expected components. Models are compared ignoring case, as the firmware set
+// conflicts are. Recalled firmware doesn't cover its component.
+func newComponentFirmwareSetCoverage(model string, expected []string, firmwares []*models.ComponentFirmwareVersion) *ComponentFirmwareSetCoverage {
+	covered := map[string]bool{}
+
+	for _, f := range firmwares {
+		if f.Status == FirmwareStatusRecalled {
+			continue
+		}
+
+		for _, m := range f.Model {
+			if strings.EqualFold(m, model) {
+				covered[strings.ToLower(f.Component)] = true
+			}
+		}
+	}
+
+	coverage := &ComponentFirmwareSetCoverage{
+		Model:    model,
+		Expected: []string{},
+		Covered:  []string{},
+		Missing:  []string{},
+	}
+
+	seen := map[string]bool{}
+
+	for _, component := range expected {
+		component = strings.ToLower(component)
+		if seen[component] {
+			continue
+		}
+
+		seen[component] = true
+		coverage.Expected = append(coverage.Expected, component)
+
+		if covered[component] {
+			coverage.Covered = append(coverage.Covered, component)
+		} else {
+			coverage.Missing = append(coverage.Missing, component)
+		}
+	}
+
+	sort.Strings(coverage.Expected)
+	sort.Strings(coverage.Covered)
+	sort.Strings(coverage.Missing)
+
+	return coverage
+}
+
 // ComponentFirmwareSetRequest represents the payload to create a firmware set
 type ComponentFirmwareSetRequest struct {
 	Name                   string       `json:"name"`
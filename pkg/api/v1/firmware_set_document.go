@@ -0,0 +1,193 @@
+package serverservice
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"go.hollow.sh/serverservice/internal/dbtools"
+	"go.hollow.sh/serverservice/internal/models"
+)
+
+// ComponentFirmwareSetDocumentFormat is the encoding of an exported firmware set
+type ComponentFirmwareSetDocumentFormat string
+
+const (
+	// ComponentFirmwareSetDocumentYAML encodes a firmware set document as YAML
+	ComponentFirmwareSetDocumentYAML ComponentFirmwareSetDocumentFormat = "yaml"
+	// ComponentFirmwareSetDocumentJSON encodes a firmware set document as JSON
+	ComponentFirmwareSetDocumentJSON ComponentFirmwareSetDocumentFormat = "json"
+
+	// ComponentFirmwareSetCreated is the import status of a firmware set that didn't exist
+	ComponentFirmwareSetCreated = dbtools.FirmwareSetCreated
+	// ComponentFirmwareSetUpdated is the import status of a firmware set that was changed
+	ComponentFirmwareSetUpdated = dbtools.FirmwareSetUpdated
+	// ComponentFirmwareSetUnchanged is the import status of a firmware set already matching the document
+	ComponentFirmwareSetUnchanged = dbtools.FirmwareSetUnchanged
+)
+
+var (
+	errInvalidDocumentFormat = errors.New("invalid firmware set document format")
+	// ErrComponentFirmwareSetDocument is returned when a firmware set document is invalid
+	// or references firmware that doesn't exist
+	ErrComponentFirmwareSetDocument = dbtools.ErrFirmwareSetDocument
+)
+
+// ComponentFirmwareSetDocument is a portable description of a firmware set. Firmware
+// is referenced by vendor, component, version and filename rather than UUID, so the
+// document can be kept in version control and imported into any serverservice.
+type ComponentFirmwareSetDocument struct {
+	Name       string                                  `json:"name" yaml:"name"`
+	Attributes []ComponentFirmwareSetDocumentAttribute `json:"attributes,omitempty" yaml:"attributes,omitempty"`
+	Firmware   []ComponentFirmwareReference            `json:"firmware" yaml:"firmware"`
+}
+
+// ComponentFirmwareSetDocumentAttribute is the data of a firmware set attributes namespace
+type ComponentFirmwareSetDocumentAttribute struct {
+	Namespace string      `json:"namespace" yaml:"namespace"`
+	Data      interface{} `json:"data" yaml:"data"`
+}
+
+// ComponentFirmwareReference identifies a firmware by the fields that are unique to it.
+// In revisions it also has the UUID and checksum the firmware had when the revision was
+// recorded. Exports leave them out, and imports ignore them.
+type ComponentFirmwareReference struct {
+	Vendor    string `json:"vendor" yaml:"vendor"`
+	Component string `json:"component" yaml:"component"`
+	Version   string `json:"version" yaml:"version"`
+	Filename  string `json:"filename" yaml:"filename"`
+	UUID      string `json:"uuid,omitempty" yaml:"uuid,omitempty"`
+	Checksum  string `json:"checksum,omitempty" yaml:"checksum,omitempty"`
+}
+
+// pinned returns if both references have the same UUID and checksum, when both have them
+func (f ComponentFirmwareReference) pinned(other ComponentFirmwareReference) bool {
+	if f.UUID == "" || other.UUID == "" {
+		return true
+	}
+
+	return f.UUID == other.UUID && f.Checksum == other.Checksum
+}
+
+// ComponentFirmwareSetImportResult is the outcome of importing a firmware set document
+type ComponentFirmwareSetImportResult struct {
+	UUID   string `json:"uuid"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+func (f ComponentFirmwareReference) String() string {
+	return strings.Join([]string{f.Vendor, f.Component, f.Version, f.Filename}, "/")
+}
+
+// DecodeComponentFirmwareSetDocument reads a YAML or JSON firmware set document
+func DecodeComponentFirmwareSetDocument(r io.Reader) (*ComponentFirmwareSetDocument, error) {
+	doc := &ComponentFirmwareSetDocument{}
+
+	// JSON is read as YAML
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+
+	if err := dec.Decode(doc); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrComponentFirmwareSetDocument, err)
+	}
+
+	return doc, nil
+}
+
+// Encode writes the document in the format
+func (d *ComponentFirmwareSetDocument) Encode(w io.Writer, format ComponentFirmwareSetDocumentFormat) error {
+	switch format {
+	case ComponentFirmwareSetDocumentYAML:
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+
+		if err := enc.Encode(d); err != nil {
+			return err
+		}
+
+		return enc.Close()
+	case ComponentFirmwareSetDocumentJSON:
+		enc := json.NewEncoder(w)
+		enc.SetEscapeHTML(false)
+		enc.SetIndent("", "  ")
+
+		return enc.Encode(d)
+	default:
+		return fmt.Errorf("%w: %q", errInvalidDocumentFormat, format)
+	}
+}
+
+// contentType returns the media type of the document format
+func (f ComponentFirmwareSetDocumentFormat) contentType() string {
+	if f == ComponentFirmwareSetDocumentJSON {
+		return "application/json; charset=utf-8"
+	}
+
+	return "application/yaml; charset=utf-8"
+}
+
+// newComponentFirmwareSetDocument returns the document of the firmware set, the
+// attributes and firmware are sorted so exports of the same set are identical
+func newComponentFirmwareSetDocument(dbFS *models.ComponentFirmwareSet, firmwares []*models.ComponentFirmwareVersion) (*ComponentFirmwareSetDocument, error) {
+	dbDoc, err := dbtools.NewFirmwareSetDocument(dbFS, firmwares)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &ComponentFirmwareSetDocument{
+		Name:     dbDoc.Name,
+		Firmware: []ComponentFirmwareReference{},
+	}
+
+	for _, a := range dbDoc.Attributes {
+		doc.Attributes = append(doc.Attributes, ComponentFirmwareSetDocumentAttribute{Namespace: a.Namespace, Data: a.Data})
+	}
+
+	// exports are imported into other services, where the UUIDs are different
+	for _, f := range dbDoc.Firmware {
+		doc.Firmware = append(doc.Firmware, ComponentFirmwareReference{
+			Vendor:    f.Vendor,
+			Component: f.Component,
+			Version:   f.Version,
+			Filename:  f.Filename,
+		})
+	}
+
+	return doc, nil
+}
+
+// toDBDocument returns the document in the form the firmware set import takes
+func (d *ComponentFirmwareSetDocument) toDBDocument() *dbtools.FirmwareSetDocument {
+	dbDoc := &dbtools.FirmwareSetDocument{Name: d.Name}
+
+	for _, a := range d.Attributes {
+		dbDoc.Attributes = append(dbDoc.Attributes, dbtools.FirmwareSetDocumentAttribute{Namespace: a.Namespace, Data: a.Data})
+	}
+
+	for _, f := range d.Firmware {
+		dbDoc.Firmware = append(dbDoc.Firmware, dbtools.FirmwareReference(f))
+	}
+
+	return dbDoc
+}
+
+// attributesJSON returns the data of each attributes namespace encoded as JSON
+func (d *ComponentFirmwareSetDocument) attributesJSON() (map[string][]byte, error) {
+	attrs := map[string][]byte{}
+
+	for _, a := range d.Attributes {
+		data, err := json.Marshal(a.Data)
+		if err != nil {
+			return nil, fmt.Errorf("%w: attributes namespace %q: %s", ErrComponentFirmwareSetDocument, a.Namespace, err)
+		}
+
+		attrs[a.Namespace] = data
+	}
+
+	return attrs, nil
+}
@@ -0,0 +1,34 @@
+package serverservice
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComponentFirmwareSetDocumentEncode(t *testing.T) {
+	doc := &ComponentFirmwareSetDocument{
+		Name: "r640",
+		Attributes: []ComponentFirmwareSetDocumentAttribute{
+			{Namespace: "sh.hollow.firmware_set.labels", Data: map[string]interface{}{"vendor": "dell", "model": "r640"}},
+		},
+		Firmware: []ComponentFirmwareReference{
+			{Vendor: "Dell", Component: "bmc", Version: "5.10.00.00", Filename: "iDRAC-with-Lifecycle-Controller_Firmware_P8HC9_WN64_5.10.00.00_A00.EXE"},
+		},
+	}
+
+	for _, format := range []ComponentFirmwareSetDocumentFormat{ComponentFirmwareSetDocumentYAML, ComponentFirmwareSetDocumentJSON} {
+		t.Run(string(format), func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			require.NoError(t, doc.Encode(buf, format))
+
+			decoded, err := DecodeComponentFirmwareSetDocument(buf)
+			require.NoError(t, err)
+			assert.Equal(t, doc, decoded)
+		})
+	}
+
+	assert.ErrorIs(t, doc.Encode(&bytes.Buffer{}, "xml"), errInvalidDocumentFormat)
+}
@@ -0,0 +1,167 @@
+package serverservice
+
+import (
+	"encoding/json"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.hollow.sh/serverservice/internal/dbtools"
+	"go.hollow.sh/serverservice/internal/models"
+)
+
+// ComponentFirmwareSetRevision is the content of a firmware set as of one of its changes.
+// Revisions are numbered from 1 and never change once recorded.
+type ComponentFirmwareSetRevision struct {
+	Revision  int64                        `json:"revision"`
+	Document  ComponentFirmwareSetDocument `json:"document"`
+	CreatedAt time.Time                    `json:"created_at"`
+}
+
+// ComponentFirmwareSetCloneRequest is the payload to create a firmware set from the
+// attributes and firmware of another
+type ComponentFirmwareSetCloneRequest struct {
+	Name string `json:"name" binding:"required"`
+	// ComponentFirmwareUUIDs are added to the clone, replacing the firmware of the
+	// source set for the same vendor, component and model
+	ComponentFirmwareUUIDs []string `json:"component_firmware_uuids"`
+}
+
+// ComponentFirmwareSetDiff lists the changes between two revisions of a firmware set
+type ComponentFirmwareSetDiff struct {
+	From     int64                        `json:"from"`
+	To       int64                        `json:"to"`
+	FromName string                       `json:"from_name"`
+	ToName   string                       `json:"to_name"`
+	Added    []ComponentFirmwareReference `json:"added"`
+	Removed  []ComponentFirmwareReference `json:"removed"`
+	Changed  []ComponentFirmwareChange    `json:"changed"`
+	// Attributes are the namespaces added, removed or changed
+	Attributes []string `json:"attributes"`
+}
+
+// ComponentFirmwareChange is a firmware replaced by another for the same vendor and component
+type ComponentFirmwareChange struct {
+	From ComponentFirmwareReference `json:"from"`
+	To   ComponentFirmwareReference `json:"to"`
+}
+
+type componentFirmwareSetDiffParams struct {
+	From int64
+	To   int64
+}
+
+func (p *componentFirmwareSetDiffParams) setQuery(q url.Values) {
+	if p.From != 0 {
+		q.Set("from", strconv.FormatInt(p.From, 10))
+	}
+
+	if p.To != 0 {
+		q.Set("to", strconv.FormatInt(p.To, 10))
+	}
+}
+
+func (v *ComponentFirmwareSetRevision) fromDBModel(dbR *models.ComponentFirmwareSetRevision) error {
+	v.Revision = dbR.Revision
+	v.CreatedAt = dbR.CreatedAt
+
+	return json.Unmarshal(dbR.Document, &v.Document)
+}
+
+// sort orders the attributes by namespace and the firmware by reference
+func (d *ComponentFirmwareSetDocument) sort() {
+	sort.Slice(d.Attributes, func(i, j int) bool {
+		return d.Attributes[i].Namespace < d.Attributes[j].Namespace
+	})
+
+	sort.Slice(d.Firmware, func(i, j int) bool {
+		return d.Firmware[i].String() < d.Firmware[j].String()
+	})
+}
+
+// newComponentFirmwareSetDiff compares the documents of the revisions. A firmware removed
+// while another for the same vendor and component is added is reported as changed, and
+// so is a firmware whose UUID or checksum differs between the revisions.
+func newComponentFirmwareSetDiff(from, to *ComponentFirmwareSetRevision) *ComponentFirmwareSetDiff {
+	diff := &ComponentFirmwareSetDiff{
+		From:       from.Revision,
+		To:         to.Revision,
+		FromName:   from.Document.Name,
+		ToName:     to.Document.Name,
+		Added:      []ComponentFirmwareReference{},
+		Removed:    []ComponentFirmwareReference{},
+		Changed:    []ComponentFirmwareChange{},
+		Attributes: []string{},
+	}
+
+	from.Document.sort()
+	to.Document.sort()
+
+	fromRefs := map[string]bool{}
+	for _, f := range from.Document.Firmware {
+		fromRefs[f.String()] = true
+	}
+
+	toRefs := map[string]ComponentFirmwareReference{}
+	for _, f := range to.Document.Firmware {
+		toRefs[f.String()] = f
+	}
+
+	added := []ComponentFirmwareReference{}
+
+	for _, f := range to.Document.Firmware {
+		if !fromRefs[f.String()] {
+			added = append(added, f)
+		}
+	}
+
+	for _, f := range from.Document.Firmware {
+		if other, ok := toRefs[f.String()]; ok {
+			// the firmware was edited, or deleted and added again, between the revisions
+			if !f.pinned(other) {
+				diff.Changed = append(diff.Changed, ComponentFirmwareChange{From: f, To: other})
+			}
+
+			continue
+		}
+
+		replaced := false
+
+		for i, a := range added {
+			if strings.EqualFold(a.Vendor, f.Vendor) && strings.EqualFold(a.Component, f.Component) {
+				diff.Changed = append(diff.Changed, ComponentFirmwareChange{From: f, To: a})
+				added = append(added[:i], added[i+1:]...)
+				replaced = true
+
+				break
+			}
+		}
+
+		if !replaced {
+			diff.Removed = append(diff.Removed, f)
+		}
+	}
+
+	diff.Added = append(diff.Added, added...)
+
+	fromAttrs, _ := from.Document.attributesJSON()
+	toAttrs, _ := to.Document.attributesJSON()
+
+	for ns, data := range fromAttrs {
+		if other, ok := toAttrs[ns]; !ok || !dbtools.SameJSON(data, other) {
+			diff.Attributes = append(diff.Attributes, ns)
+		}
+	}
+
+	for ns := range toAttrs {
+		if _, ok := fromAttrs[ns]; !ok {
+			diff.Attributes = append(diff.Attributes, ns)
+		}
+	}
+
+	sort.Strings(diff.Attributes)
+
+	return diff
+}
@@ -0,0 +1,73 @@
+package serverservice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewComponentFirmwareSetDiff(t *testing.T) {
+	bios := ComponentFirmwareReference{Vendor: "Dell", Component: "bios", Version: "2.4.4", Filename: "BIOS_2.4.4.EXE"}
+	newBIOS := ComponentFirmwareReference{Vendor: "Dell", Component: "BIOS", Version: "2.5.0", Filename: "BIOS_2.5.0.EXE"}
+	bmc := ComponentFirmwareReference{Vendor: "Dell", Component: "bmc", Version: "5.10.00.00", Filename: "iDRAC_5.10.EXE"}
+	cpld := ComponentFirmwareReference{Vendor: "Dell", Component: "cpld", Version: "1.0.1", Filename: "CPLD_1.0.1.EXE"}
+
+	from := &ComponentFirmwareSetRevision{
+		Revision: 1,
+		Document: ComponentFirmwareSetDocument{
+			Name: "r640",
+			Attributes: []ComponentFirmwareSetDocumentAttribute{
+				{Namespace: "labels", Data: map[string]interface{}{"model": "r640"}},
+				{Namespace: "owner", Data: "fleet"},
+			},
+			Firmware: []ComponentFirmwareReference{bmc, bios},
+		},
+	}
+
+	to := &ComponentFirmwareSetRevision{
+		Revision: 3,
+		Document: ComponentFirmwareSetDocument{
+			Name: "r640-2023",
+			Attributes: []ComponentFirmwareSetDocumentAttribute{
+				{Namespace: "labels", Data: map[string]interface{}{"model": "r640", "vendor": "dell"}},
+				{Namespace: "release", Data: "2023"},
+			},
+			Firmware: []ComponentFirmwareReference{newBIOS, cpld},
+		},
+	}
+
+	assert.Equal(t, &ComponentFirmwareSetDiff{
+		From:       1,
+		To:         3,
+		FromName:   "r640",
+		ToName:     "r640-2023",
+		Added:      []ComponentFirmwareReference{cpld},
+		Removed:    []ComponentFirmwareReference{bmc},
+		Changed:    []ComponentFirmwareChange{{From: bios, To: newBIOS}},
+		Attributes: []string{"labels", "owner", "release"},
+	}, newComponentFirmwareSetDiff(from, to))
+
+	same := newComponentFirmwareSetDiff(from, from)
+	assert.Empty(t, same.Added)
+	assert.Empty(t, same.Removed)
+	assert.Empty(t, same.Changed)
+	assert.Empty(t, same.Attributes)
+
+	// the same firmware edited between the revisions
+	pinned := bmc
+	pinned.UUID, pinned.Checksum = "0b3d1c3a-7a4e-4c3f-9d6e-3f1f6f0f3b1a", "abc"
+	edited := pinned
+	edited.Checksum = "def"
+
+	from.Document.Firmware = []ComponentFirmwareReference{pinned}
+	to.Document.Firmware = []ComponentFirmwareReference{edited}
+
+	diff := newComponentFirmwareSetDiff(from, to)
+	assert.Empty(t, diff.Added)
+	assert.Empty(t, diff.Removed)
+	assert.Equal(t, []ComponentFirmwareChange{{From: pinned, To: edited}}, diff.Changed)
+
+	// revisions recorded before the checksums were kept aren't compared by checksum
+	from.Document.Firmware = []ComponentFirmwareReference{bmc}
+	assert.Empty(t, newComponentFirmwareSetDiff(from, to).Changed)
+}
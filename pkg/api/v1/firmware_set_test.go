@@ -0,0 +1,81 @@
+package serverservice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/sqlboiler/v4/types"
+
+	"go.hollow.sh/serverservice/internal/models"
+)
+
+func TestFirmwareSetConflict(t *testing.T) {
+	bios := &models.ComponentFirmwareVersion{ID: "bios-2.4.4", Vendor: "Dell", Component: "bios", Version: "2.4.4", Model: types.StringArray{"R640", "R740"}}
+	bmc := &models.ComponentFirmwareVersion{ID: "bmc-5.10", Vendor: "Dell", Component: "bmc", Version: "5.10.00.00", Model: types.StringArray{"R640"}}
+	otherBIOS := &models.ComponentFirmwareVersion{ID: "bios-2.6.6", Vendor: "dell", Component: "BIOS", Version: "2.6.6", Model: types.StringArray{"R6515", "r740"}}
+
+	assert.NoError(t, firmwareSetConflict([]*models.ComponentFirmwareVersion{bios, bmc}))
+	assert.NoError(t, firmwareSetConflict([]*models.ComponentFirmwareVersion{bios, bmc, bios}), "the same firmware isn't a conflict")
+
+	err := firmwareSetConflict([]*models.ComponentFirmwareVersion{bios, bmc, otherBIOS})
+	assert.ErrorIs(t, err, errComponentFirmwareSetMap)
+	assert.ErrorContains(t, err, "'bios-2.4.4' (2.4.4) and 'bios-2.6.6' (2.6.6) are both for the dell BIOS of model r740")
+}
+
+func TestComponentFirmwareSetCoverage(t *testing.T) {
+	firmwares := []*models.ComponentFirmwareVersion{
+		{Component: "bios", Model: types.StringArray{"R640"}, Status: FirmwareStatusActive},
+		{Component: "BMC", Model: types.StringArray{"R640"}, Status: FirmwareStatusDeprecated},
+		{Component: "cpld", Model: types.StringArray{"R640"}, Status: FirmwareStatusRecalled},
+		{Component: "nic", Model: types.StringArray{"R6515"}, Status: FirmwareStatusActive},
+	}
+
+	coverage := newComponentFirmwareSetCoverage("R640", []string{"nic", "cpld", "bmc", "bios", "BIOS"}, firmwares)
+
+	assert.Equal(t, &ComponentFirmwareSetCoverage{
+		Model:    "R640",
+		Expected: []string{"bios", "bmc", "cpld", "nic"},
+		Covered:  []string{"bios", "bmc"},
+		Missing:  []string{"cpld", "nic"},
+	}, coverage)
+
+	coverage = newComponentFirmwareSetCoverage("r640", []string{"bios"}, firmwares)
+	assert.Equal(t, []string{"bios"}, coverage.Covered, "models are compared ignoring case")
+}
+
+func TestFirmwareSetOverride(t *testing.T) {
+	bios := &models.ComponentFirmwareVersion{ID: "bios-2.4.4", Vendor: "Dell", Component: "bios", Version: "2.4.4", Model: types.StringArray{"R640"}}
+	bmc := &models.ComponentFirmwareVersion{ID: "bmc-5.10", Vendor: "Dell", Component: "bmc", Version: "5.10.00.00", Model: types.StringArray{"R640"}}
+	newBIOS := &models.ComponentFirmwareVersion{ID: "bios-2.5.0", Vendor: "dell", Component: "BIOS", Version: "2.5.0", Model: types.StringArray{"r640"}}
+	otherBIOS := &models.ComponentFirmwareVersion{ID: "bios-2.6.6", Vendor: "Dell", Component: "bios", Version: "2.6.6", Model: types.StringArray{"R6515"}}
+
+	ids := func(firmwares []*models.ComponentFirmwareVersion) []string {
+		s := []string{}
+		for _, f := range firmwares {
+			s = append(s, f.ID)
+		}
+
+		return s
+	}
+
+	override := func(firmwares, overrides []*models.ComponentFirmwareVersion) []string {
+		merged, err := firmwareSetOverride(firmwares, overrides)
+		require.NoError(t, err)
+
+		return ids(merged)
+	}
+
+	current := []*models.ComponentFirmwareVersion{bios, bmc}
+
+	assert.Equal(t, []string{"bios-2.4.4", "bmc-5.10"}, override(current, nil))
+	assert.Equal(t, []string{"bmc-5.10", "bios-2.5.0"}, override(current, []*models.ComponentFirmwareVersion{newBIOS}))
+	assert.Equal(t, []string{"bios-2.4.4", "bmc-5.10", "bios-2.6.6"}, override(current, []*models.ComponentFirmwareVersion{otherBIOS}), "firmware for another model is added")
+	assert.Equal(t, []string{"bios-2.4.4", "bmc-5.10"}, override(current, []*models.ComponentFirmwareVersion{bmc}), "firmware already in the set isn't repeated")
+
+	sharedBIOS := &models.ComponentFirmwareVersion{ID: "bios-2.4.4-shared", Vendor: "Dell", Component: "bios", Version: "2.4.4", Model: types.StringArray{"R640", "R740"}}
+
+	_, err := firmwareSetOverride([]*models.ComponentFirmwareVersion{sharedBIOS, bmc}, []*models.ComponentFirmwareVersion{newBIOS})
+	assert.ErrorIs(t, err, errComponentFirmwareSetRequest)
+	assert.ErrorContains(t, err, "is replaced for R640 but not for R740")
+}
@@ -0,0 +1,69 @@
+package serverservice
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/volatiletech/null/v8"
+
+	"go.hollow.sh/serverservice/internal/models"
+)
+
+func TestComponentFirmwareStatus(t *testing.T) {
+	testCases := []struct {
+		status   ComponentFirmwareStatus
+		errorMsg string
+	}{
+		{ComponentFirmwareStatus{Status: FirmwareStatusActive}, ""},
+		{ComponentFirmwareStatus{Status: FirmwareStatusDeprecated, Reason: "superseded by 5.20"}, ""},
+		{ComponentFirmwareStatus{Status: FirmwareStatusRecalled, Reason: "bricks the BMC"}, ""},
+		{ComponentFirmwareStatus{Status: FirmwareStatusRecalled}, "a reason is required for recalled firmware"},
+		{ComponentFirmwareStatus{Status: "pulled", Reason: "bricks the BMC"}, `"pulled"`},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.status.Status, func(t *testing.T) {
+			err := tt.status.validate()
+			if tt.errorMsg == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.ErrorIs(t, err, errFirmwareStatus)
+			assert.ErrorContains(t, err, tt.errorMsg)
+		})
+	}
+
+	dbF := &models.ComponentFirmwareVersion{Status: FirmwareStatusRecalled, StatusReason: null.StringFrom("bricks the BMC")}
+
+	status := ComponentFirmwareStatus{Status: FirmwareStatusActive, Reason: "fixed"}
+	status.apply(dbF)
+
+	assert.Equal(t, FirmwareStatusActive, dbF.Status)
+	assert.False(t, dbF.StatusReason.Valid)
+}
+
+func TestComponentFirmwareVersionListParamsSetQuery(t *testing.T) {
+	p := &ComponentFirmwareVersionListParams{
+		Component:    "bios",
+		ModelLike:    "r6",
+		UpdatedSince: time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC),
+		Query:        "2.6",
+		FirmwareSetAttributeListParams: []AttributeListParams{
+			{Namespace: "sh.hollow.firmware_set.labels", Keys: []string{"model"}, Operator: OperatorEqual, Value: "r640"},
+		},
+	}
+
+	q := url.Values{}
+	p.setQuery(q)
+
+	assert.Equal(t, url.Values{
+		"component":     []string{"bios"},
+		"model_like":    []string{"r6"},
+		"updated_since": []string{"2023-06-01T00:00:00Z"},
+		"q":             []string{"2.6"},
+		"set_attr":      []string{"sh.hollow.firmware_set.labels~model~eq~r640"},
+	}, q)
+}
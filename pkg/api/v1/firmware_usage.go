@@ -0,0 +1,98 @@
+package serverservice
+
+import (
+	"net/url"
+
+	"github.com/google/uuid"
+
+	"go.hollow.sh/serverservice/internal/models"
+)
+
+// ComponentFirmwareUsage lists where a firmware is referenced: the firmware sets that
+// include it, and the server components that report it as installed
+type ComponentFirmwareUsage struct {
+	FirmwareUUID uuid.UUID                      `json:"firmware_uuid"`
+	FirmwareSets []ComponentFirmwareUsageSet    `json:"firmware_sets"`
+	Servers      []ComponentFirmwareUsageServer `json:"servers"`
+}
+
+// ComponentFirmwareUsageSet is a firmware set that includes the firmware
+type ComponentFirmwareUsageSet struct {
+	UUID uuid.UUID `json:"uuid"`
+	Name string    `json:"name"`
+}
+
+// ComponentFirmwareUsageServer is a server component that reports the firmware as installed
+type ComponentFirmwareUsageServer struct {
+	ServerUUID      uuid.UUID `json:"server_uuid"`
+	ServerName      string    `json:"server_name"`
+	ComponentUUID   uuid.UUID `json:"component_uuid"`
+	ComponentName   string    `json:"component_name"`
+	ComponentSerial string    `json:"component_serial"`
+}
+
+// InUse returns if a firmware set includes the firmware or a server has it installed
+func (u *ComponentFirmwareUsage) InUse() bool {
+	return len(u.FirmwareSets) != 0 || len(u.Servers) != 0
+}
+
+// componentFirmwareUsageParams are the query params of a firmware usage request
+type componentFirmwareUsageParams struct {
+	Namespace string
+}
+
+func (p *componentFirmwareUsageParams) setQuery(q url.Values) {
+	if p.Namespace != "" {
+		q.Set("namespace", p.Namespace)
+	}
+}
+
+func newComponentFirmwareUsage(firmwareID string, sets models.ComponentFirmwareSetSlice, components models.ServerComponentSlice) (*ComponentFirmwareUsage, error) {
+	usage := &ComponentFirmwareUsage{
+		FirmwareSets: []ComponentFirmwareUsageSet{},
+		Servers:      []ComponentFirmwareUsageServer{},
+	}
+
+	var err error
+
+	usage.FirmwareUUID, err = uuid.Parse(firmwareID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range sets {
+		set := ComponentFirmwareUsageSet{Name: s.Name}
+
+		set.UUID, err = uuid.Parse(s.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		usage.FirmwareSets = append(usage.FirmwareSets, set)
+	}
+
+	for _, dbC := range components {
+		srv := ComponentFirmwareUsageServer{
+			ComponentName:   dbC.Name.String,
+			ComponentSerial: dbC.Serial.String,
+		}
+
+		srv.ServerUUID, err = uuid.Parse(dbC.ServerID)
+		if err != nil {
+			return nil, err
+		}
+
+		srv.ComponentUUID, err = uuid.Parse(dbC.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		if dbC.R != nil && dbC.R.Server != nil {
+			srv.ServerName = dbC.R.Server.Name.String
+		}
+
+		usage.Servers = append(usage.Servers, srv)
+	}
+
+	return usage, nil
+}
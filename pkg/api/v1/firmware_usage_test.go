@@ -0,0 +1,48 @@
+package serverservice
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+
+	"go.hollow.sh/serverservice/internal/models"
+)
+
+func TestNewComponentFirmwareUsage(t *testing.T) {
+	firmwareID := uuid.NewString()
+
+	usage, err := newComponentFirmwareUsage(firmwareID, nil, nil)
+	require.NoError(t, err)
+	assert.False(t, usage.InUse())
+	assert.Equal(t, []ComponentFirmwareUsageSet{}, usage.FirmwareSets)
+	assert.Equal(t, []ComponentFirmwareUsageServer{}, usage.Servers)
+
+	set := &models.ComponentFirmwareSet{ID: uuid.NewString(), Name: "r640"}
+	component := &models.ServerComponent{
+		ID:       uuid.NewString(),
+		ServerID: uuid.NewString(),
+		Name:     null.StringFrom("iDRAC"),
+		Serial:   null.StringFrom("bmc"),
+	}
+	component.R = component.R.NewStruct()
+	component.R.Server = &models.Server{Name: null.StringFrom("Nemo")}
+
+	usage, err = newComponentFirmwareUsage(firmwareID, models.ComponentFirmwareSetSlice{set}, models.ServerComponentSlice{component})
+	require.NoError(t, err)
+	assert.True(t, usage.InUse())
+	assert.Equal(t, firmwareID, usage.FirmwareUUID.String())
+	assert.Equal(t, []ComponentFirmwareUsageSet{{UUID: uuid.MustParse(set.ID), Name: "r640"}}, usage.FirmwareSets)
+	assert.Equal(t, []ComponentFirmwareUsageServer{{
+		ServerUUID:      uuid.MustParse(component.ServerID),
+		ServerName:      "Nemo",
+		ComponentUUID:   uuid.MustParse(component.ID),
+		ComponentName:   "iDRAC",
+		ComponentSerial: "bmc",
+	}}, usage.Servers)
+
+	_, err = newComponentFirmwareUsage("not-a-uuid", nil, nil)
+	assert.Error(t, err)
+}
@@ -12,9 +12,11 @@ import (
 )
 
 var (
-	ErrNilServer  = errors.New("bogus server structure provided")
-	ErrBadJSONOut = errors.New("object serializaion failed")
-	ErrBadJSONIn  = errors.New("object deserializaion failed")
+	ErrNilServer     = errors.New("bogus server structure provided")
+	ErrNilCredential = errors.New("bogus credential structure provided")
+	ErrNilFirmware   = errors.New("bogus firmware structure provided")
+	ErrBadJSONOut    = errors.New("object serializaion failed")
+	ErrBadJSONIn     = errors.New("object deserializaion failed")
 )
 
 // MsgMetadata captures some message-type agnostic descriptive data a consumer might need
@@ -51,6 +53,101 @@ func NewCreateServerMessage(srv *models.Server) ([]byte, error) {
 	return byt, err
 }
 
+// CredentialExpired is a message type published via NATS when a server credential
+// is older than the max age of its credential type
+type CredentialExpired struct {
+	Metadata   *MsgMetadata `json:"metadata,omitempty"`
+	ServerID   string       `json:"server_id"`
+	SecretType string       `json:"secret_type"`
+	Username   string       `json:"username"`
+	DueAt      time.Time    `json:"due_at"`
+}
+
+// NewCredentialExpiredMessage composes a CredentialExpired message for NATS
+func NewCredentialExpiredMessage(due *ServerCredentialRotationDue) ([]byte, error) {
+	if due == nil {
+		return nil, ErrNilCredential
+	}
+	ce := &CredentialExpired{
+		Metadata: &MsgMetadata{
+			CreatedAt: time.Now(),
+			UpdatedAt: due.UpdatedAt,
+		},
+		ServerID:   due.ServerID.String(),
+		SecretType: due.SecretType,
+		Username:   due.Username,
+		DueAt:      due.DueAt,
+	}
+	byt, err := json.Marshal(ce)
+	if err != nil {
+		return nil, errors.Wrap(ErrBadJSONOut, err.Error())
+	}
+	return byt, err
+}
+
+// FirmwareStatusChanged is a message type published via NATS when the status of a
+// firmware changes, FirmwareSetIDs lists the firmware sets that include it. Firmware
+// created with a status other than active is published with no PreviousStatus.
+type FirmwareStatusChanged struct {
+	Metadata       *MsgMetadata `json:"metadata,omitempty"`
+	FirmwareID     string       `json:"firmware_id"`
+	Vendor         string       `json:"vendor"`
+	Model          []string     `json:"model"`
+	Component      string       `json:"component"`
+	Version        string       `json:"version"`
+	Filename       string       `json:"filename"`
+	Status         string       `json:"status"`
+	PreviousStatus string       `json:"previous_status"`
+	Reason         string       `json:"reason"`
+	FirmwareSetIDs []string     `json:"firmware_set_ids"`
+}
+
+// NewFirmwareStatusChangedMessage composes a FirmwareStatusChanged message for NATS
+func NewFirmwareStatusChangedMessage(fw *models.ComponentFirmwareVersion, previousStatus string, firmwareSetIDs []string) ([]byte, error) {
+	if fw == nil {
+		return nil, ErrNilFirmware
+	}
+	fsc := &FirmwareStatusChanged{
+		Metadata: &MsgMetadata{
+			CreatedAt: time.Now(),
+			UpdatedAt: fw.UpdatedAt.Time,
+		},
+		FirmwareID:     fw.ID,
+		Vendor:         fw.Vendor,
+		Model:          fw.Model,
+		Component:      fw.Component,
+		Version:        fw.Version,
+		Filename:       fw.Filename,
+		Status:         fw.Status,
+		PreviousStatus: previousStatus,
+		Reason:         fw.StatusReason.String,
+		FirmwareSetIDs: firmwareSetIDs,
+	}
+	byt, err := json.Marshal(fsc)
+	if err != nil {
+		return nil, errors.Wrap(ErrBadJSONOut, err.Error())
+	}
+	return byt, err
+}
+
+// DeserializeFirmwareStatusChanged reconstitutes a FirmwareStatusChanged from raw bytes
+func DeserializeFirmwareStatusChanged(inc []byte) (*FirmwareStatusChanged, error) {
+	fsc := &FirmwareStatusChanged{}
+	if err := json.Unmarshal(inc, fsc); err != nil {
+		return nil, errors.Wrap(ErrBadJSONIn, err.Error())
+	}
+	return fsc, nil
+}
+
+// DeserializeCredentialExpired reconstitutes a CredentialExpired from raw bytes
+func DeserializeCredentialExpired(inc []byte) (*CredentialExpired, error) {
+	ce := &CredentialExpired{}
+	if err := json.Unmarshal(inc, ce); err != nil {
+		return nil, errors.Wrap(ErrBadJSONIn, err.Error())
+	}
+	return ce, nil
+}
+
 // DeserializeCreateServer reconstitutes a CreateServer from raw bytes
 func DeserializeCreateServer(inc []byte) (*CreateServer, error) {
 	cs := &CreateServer{}
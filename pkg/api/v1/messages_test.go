@@ -3,7 +3,9 @@ package serverservice
 
 import (
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 	"github.com/volatiletech/null/v8"
 
@@ -40,3 +42,61 @@ func TestSerialization(t *testing.T) {
 	require.Equal(t, exp.FacilityCode, cs.FacilityCode, "good deserialize facility")
 	require.Equal(t, exp.ID, cs.ID, "good deserialize id")
 }
+
+func TestCredentialExpiredSerialization(t *testing.T) {
+	due := &ServerCredentialRotationDue{
+		ServerID:   uuid.New(),
+		SecretType: ServerCredentialTypeBMC,
+		Username:   "root",
+		UpdatedAt:  time.Now().Add(-2 * time.Hour).UTC(),
+		MaxAge:     3600,
+	}
+	due.DueAt = due.UpdatedAt.Add(time.Hour)
+
+	_, err := NewCredentialExpiredMessage((*ServerCredentialRotationDue)(nil))
+	require.ErrorIs(t, err, ErrNilCredential, "nil input")
+
+	byt, err := NewCredentialExpiredMessage(due)
+	require.NoError(t, err, "good credential obj")
+
+	_, err = DeserializeCredentialExpired([]byte("bogus"))
+	require.ErrorIs(t, err, ErrBadJSONIn, "bogus deserialize")
+
+	ce, err := DeserializeCredentialExpired(byt)
+	require.NoError(t, err, "good deserialize")
+	require.Equal(t, due.ServerID.String(), ce.ServerID, "good deserialize server id")
+	require.Equal(t, due.SecretType, ce.SecretType, "good deserialize secret type")
+	require.Equal(t, due.Username, ce.Username, "good deserialize username")
+	require.True(t, due.DueAt.Equal(ce.DueAt), "good deserialize due at")
+}
+
+func TestFirmwareStatusChangedSerialization(t *testing.T) {
+	fw := &models.ComponentFirmwareVersion{
+		ID:           uuid.NewString(),
+		Vendor:       "dell",
+		Model:        []string{"r640"},
+		Component:    "bmc",
+		Version:      "5.10.00.00",
+		Filename:     "bmc.EXE",
+		Status:       FirmwareStatusRecalled,
+		StatusReason: null.StringFrom("bricks the BMC"),
+	}
+	setIDs := []string{uuid.NewString()}
+
+	_, err := NewFirmwareStatusChangedMessage((*models.ComponentFirmwareVersion)(nil), FirmwareStatusActive, nil)
+	require.ErrorIs(t, err, ErrNilFirmware, "nil input")
+
+	byt, err := NewFirmwareStatusChangedMessage(fw, FirmwareStatusActive, setIDs)
+	require.NoError(t, err, "good firmware obj")
+
+	_, err = DeserializeFirmwareStatusChanged([]byte("bogus"))
+	require.ErrorIs(t, err, ErrBadJSONIn, "bogus deserialize")
+
+	fr, err := DeserializeFirmwareStatusChanged(byt)
+	require.NoError(t, err, "good deserialize")
+	require.Equal(t, fw.ID, fr.FirmwareID, "good deserialize id")
+	require.Equal(t, FirmwareStatusRecalled, fr.Status, "good deserialize status")
+	require.Equal(t, FirmwareStatusActive, fr.PreviousStatus, "good deserialize previous status")
+	require.Equal(t, "bricks the BMC", fr.Reason, "good deserialize reason")
+	require.Equal(t, setIDs, fr.FirmwareSetIDs, "good deserialize firmware sets")
+}
@@ -0,0 +1,148 @@
+package serverservice
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+const (
+	passwordLowercase = "abcdefghijklmnopqrstuvwxyz"
+	passwordUppercase = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	passwordDigits    = "0123456789"
+	passwordSymbols   = "!#$%&()*+,-./:;<=>?@[]^_{|}~"
+
+	minPasswordLength = 8
+	maxPasswordLength = 256
+)
+
+var (
+	// ErrInvalidPasswordPolicy is returned when a password policy can't generate a password
+	ErrInvalidPasswordPolicy = errors.New("invalid password policy")
+
+	// DefaultPasswordPolicy is used to generate passwords for server credential types
+	// that don't have a policy set
+	DefaultPasswordPolicy = PasswordPolicy{
+		Length:    32,
+		Lowercase: true,
+		Uppercase: true,
+		Digits:    true,
+		Symbols:   true,
+	}
+)
+
+// PasswordPolicy describes the passwords generated for a server credential type
+type PasswordPolicy struct {
+	Length    int    `json:"length"`
+	Lowercase bool   `json:"lowercase"`
+	Uppercase bool   `json:"uppercase"`
+	Digits    bool   `json:"digits"`
+	Symbols   bool   `json:"symbols"`
+	Exclude   string `json:"exclude,omitempty"`
+}
+
+// Validate returns an error if a password can't be generated with the policy
+func (p *PasswordPolicy) Validate() error {
+	if p.Length < minPasswordLength || p.Length > maxPasswordLength {
+		return fmt.Errorf("%w: length must be between %d and %d", ErrInvalidPasswordPolicy, minPasswordLength, maxPasswordLength)
+	}
+
+	classes := p.classes()
+	if len(classes) == 0 {
+		return fmt.Errorf("%w: at least one character class is required", ErrInvalidPasswordPolicy)
+	}
+
+	for _, class := range classes {
+		if class == "" {
+			return fmt.Errorf("%w: a character class has all its characters excluded", ErrInvalidPasswordPolicy)
+		}
+	}
+
+	if len(classes) > p.Length {
+		return fmt.Errorf("%w: length is shorter than the number of character classes", ErrInvalidPasswordPolicy)
+	}
+
+	return nil
+}
+
+// Generate returns a random password that contains at least one character from
+// each of the character classes in the policy
+func (p *PasswordPolicy) Generate() (string, error) {
+	if err := p.Validate(); err != nil {
+		return "", err
+	}
+
+	classes := p.classes()
+	all := strings.Join(classes, "")
+	password := make([]byte, 0, p.Length)
+
+	for _, class := range classes {
+		c, err := randomChar(class)
+		if err != nil {
+			return "", err
+		}
+
+		password = append(password, c)
+	}
+
+	for len(password) < p.Length {
+		c, err := randomChar(all)
+		if err != nil {
+			return "", err
+		}
+
+		password = append(password, c)
+	}
+
+	// shuffle so the required characters aren't always at the start
+	for i := len(password) - 1; i > 0; i-- {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return "", err
+		}
+
+		password[i], password[j.Int64()] = password[j.Int64()], password[i]
+	}
+
+	return string(password), nil
+}
+
+// classes returns the enabled character classes with the excluded characters removed
+func (p *PasswordPolicy) classes() []string {
+	classes := []string{}
+
+	for _, class := range []struct {
+		enabled bool
+		chars   string
+	}{
+		{p.Lowercase, passwordLowercase},
+		{p.Uppercase, passwordUppercase},
+		{p.Digits, passwordDigits},
+		{p.Symbols, passwordSymbols},
+	} {
+		if !class.enabled {
+			continue
+		}
+
+		classes = append(classes, strings.Map(func(r rune) rune {
+			if strings.ContainsRune(p.Exclude, r) {
+				return -1
+			}
+
+			return r
+		}, class.chars))
+	}
+
+	return classes
+}
+
+func randomChar(chars string) (byte, error) {
+	i, err := rand.Int(rand.Reader, big.NewInt(int64(len(chars))))
+	if err != nil {
+		return 0, err
+	}
+
+	return chars[i.Int64()], nil
+}
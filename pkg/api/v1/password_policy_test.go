@@ -0,0 +1,103 @@
+package serverservice_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	serverservice "go.hollow.sh/serverservice/pkg/api/v1"
+)
+
+func TestPasswordPolicyGenerate(t *testing.T) {
+	var testCases = []struct {
+		testName string
+		policy   serverservice.PasswordPolicy
+		allowed  string
+		required []string
+	}{
+		{
+			"default policy",
+			serverservice.DefaultPasswordPolicy,
+			"",
+			nil,
+		},
+		{
+			"digits only",
+			serverservice.PasswordPolicy{Length: 12, Digits: true},
+			"0123456789",
+			[]string{"0123456789"},
+		},
+		{
+			"lower and upper without ambiguous characters",
+			serverservice.PasswordPolicy{Length: 64, Lowercase: true, Uppercase: true, Exclude: "lIO"},
+			"abcdefghijkmnopqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ",
+			[]string{"abcdefghijkmnopqrstuvwxyz", "ABCDEFGHJKLMNPQRSTUVWXYZ"},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.testName, func(t *testing.T) {
+			password, err := tt.policy.Generate()
+			require.NoError(t, err)
+			assert.Len(t, password, tt.policy.Length)
+
+			if tt.allowed != "" {
+				assert.Empty(t, strings.Trim(password, tt.allowed))
+			}
+
+			for _, class := range tt.required {
+				assert.True(t, strings.ContainsAny(password, class), "missing a character from %q", class)
+			}
+		})
+	}
+
+	t.Run("passwords are random", func(t *testing.T) {
+		p1, err := serverservice.DefaultPasswordPolicy.Generate()
+		require.NoError(t, err)
+
+		p2, err := serverservice.DefaultPasswordPolicy.Generate()
+		require.NoError(t, err)
+
+		assert.NotEqual(t, p1, p2)
+	})
+}
+
+func TestPasswordPolicyValidate(t *testing.T) {
+	var testCases = []struct {
+		testName string
+		policy   serverservice.PasswordPolicy
+		errorMsg string
+	}{
+		{
+			"too short",
+			serverservice.PasswordPolicy{Length: 4, Digits: true},
+			"length must be between",
+		},
+		{
+			"too long",
+			serverservice.PasswordPolicy{Length: 1024, Digits: true},
+			"length must be between",
+		},
+		{
+			"no character classes",
+			serverservice.PasswordPolicy{Length: 16},
+			"at least one character class",
+		},
+		{
+			"all characters of a class excluded",
+			serverservice.PasswordPolicy{Length: 16, Lowercase: true, Digits: true, Exclude: "0123456789"},
+			"all its characters excluded",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.testName, func(t *testing.T) {
+			err := tt.policy.Validate()
+			require.Error(t, err)
+			assert.ErrorIs(t, err, serverservice.ErrInvalidPasswordPolicy)
+			assert.Contains(t, err.Error(), tt.errorMsg)
+		})
+	}
+}
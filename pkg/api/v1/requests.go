@@ -61,6 +61,34 @@ func newPutRequest(ctx context.Context, uri, path string, body interface{}) (*ht
 	return http.NewRequestWithContext(ctx, http.MethodPut, requestURL.String(), buf)
 }
 
+// newNDJSONPostRequest returns a POST request with a body of each of the items
+// encoded as JSON on its own line
+func newNDJSONPostRequest(ctx context.Context, uri, path string, items ...interface{}) (*http.Request, error) {
+	requestURL, err := url.Parse(fmt.Sprintf("%s/api/%s/%s", uri, apiVersion, path))
+	if err != nil {
+		return nil, err
+	}
+
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+
+	for _, item := range items {
+		if err := enc.Encode(item); err != nil {
+			return nil, err
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, requestURL.String(), buf)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/x-ndjson")
+
+	return req, nil
+}
+
 func newDeleteRequest(ctx context.Context, uri, path string) (*http.Request, error) {
 	requestURL, err := url.Parse(fmt.Sprintf("%s/api/%s/%s", uri, apiVersion, path))
 	if err != nil {
@@ -4,7 +4,10 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -14,23 +17,38 @@ import (
 	"go.hollow.sh/toolbox/events"
 	"go.hollow.sh/toolbox/ginjwt"
 	"go.uber.org/zap"
-	"gocloud.dev/secrets"
 
+	"go.hollow.sh/serverservice/internal/dbtools"
+	"go.hollow.sh/serverservice/internal/metrics"
 	"go.hollow.sh/serverservice/internal/models"
 )
 
 // Router provides a router for the v1 API
 type Router struct {
-	AuthMW        *ginjwt.Middleware
-	DB            *sqlx.DB
-	SecretsKeeper *secrets.Keeper
-	Logger        *zap.Logger
-	EventStream   events.Stream
+	AuthMW      *ginjwt.Middleware
+	DB          *sqlx.DB
+	Keyring     *dbtools.Keyring
+	Logger      *zap.Logger
+	EventStream events.Stream
+	// ClientCertificateScopes are the scopes granted to requests made with a TLS client
+	// certificate, by certificate subject
+	ClientCertificateScopes map[string][]string
+	// ExtendWriteDeadline pushes back the write deadline of the connection of the request,
+	// so long running responses like exports aren't cut by the server write timeout
+	ExtendWriteDeadline func(req *http.Request, d time.Duration)
+	// ExtendReadDeadline pushes back the read deadline of the connection of the request,
+	// so large request bodies like bulk imports aren't cut by the server read timeout
+	ExtendReadDeadline func(req *http.Request, d time.Duration)
+	// CredentialVersionsRetained is the number of previous values kept for each credential,
+	// DefaultCredentialVersionsRetained is used when it isn't set
+	CredentialVersionsRetained int
+	// FirmwareSetRevisions records a revision of a firmware set each time it changes
+	FirmwareSetRevisions bool
 }
 
 // Routes will add the routes for this API version to a router group
 func (r *Router) Routes(rg *gin.RouterGroup) {
-	amw := r.AuthMW
+	amw := r.authMiddleware()
 
 	// require all calls to have auth
 	rg.Use(amw.AuthRequired())
@@ -40,8 +58,12 @@ func (r *Router) Routes(rg *gin.RouterGroup) {
 	{
 		srvs.GET("", amw.RequiredScopes(readScopes("server")), r.serverList)
 		srvs.POST("", amw.RequiredScopes(createScopes("server")), r.serverCreate)
+		srvs.POST("/bulk", amw.RequiredScopes(createScopes("server")), r.serverBulkImport)
 
+		srvs.GET("/aggregate", amw.RequiredScopes(readScopes("server")), r.serverAggregate)
+		srvs.GET("/export", amw.RequiredScopes(readScopes("server")), r.serverExport)
 		srvs.GET("/components", amw.RequiredScopes(readScopes("server:component")), r.serverComponentList)
+		srvs.GET("/credentials/missing", amw.RequiredScopes(credentialMetadataScopes()), r.serverCredentialMissingList)
 
 		// /servers/:uuid
 		srv := srvs.Group("/:uuid")
@@ -69,12 +91,21 @@ func (r *Router) Routes(rg *gin.RouterGroup) {
 				srvComponents.DELETE("", amw.RequiredScopes(deleteScopes("server", "server:component")), r.serverComponentDelete)
 			}
 
+			// /servers/:uuid/firmware-plan
+			srv.GET("/firmware-plan", amw.RequiredScopes(readScopes("server", "server:component")), r.serverFirmwarePlan)
+
+			// /servers/:uuid/credentials
+			srv.GET("/credentials", amw.RequiredScopes(credentialMetadataScopes()), r.serverCredentialList)
+
 			// /servers/:uuid/credentials/:slug
 			svrCreds := srv.Group("credentials/:slug")
 			{
 				svrCreds.GET("", amw.RequiredScopes([]string{"read:server:credentials"}), r.serverCredentialGet)
 				svrCreds.PUT("", amw.RequiredScopes([]string{"write:server:credentials"}), r.serverCredentialUpsert)
 				svrCreds.DELETE("", amw.RequiredScopes([]string{"write:server:credentials"}), r.serverCredentialDelete)
+				svrCreds.POST("/generate", amw.RequiredScopes([]string{"write:server:credentials"}), r.serverCredentialGenerate)
+				svrCreds.GET("/versions", amw.RequiredScopes([]string{"read:server:credentials"}), r.serverCredentialVersionsList)
+				svrCreds.POST("/versions/:version/rollback", amw.RequiredScopes([]string{"write:server:credentials"}), r.serverCredentialRollback)
 			}
 
 			// /servers/:uuid/versioned-attributes
@@ -87,11 +118,20 @@ func (r *Router) Routes(rg *gin.RouterGroup) {
 		}
 	}
 
+	// /credentials
+	creds := rg.Group("/credentials")
+	{
+		creds.GET("/rotation-due", amw.RequiredScopes(credentialMetadataScopes()), r.credentialRotationDueList)
+	}
+
 	// /server-component-types
 	srvCmpntType := rg.Group("/server-component-types")
 	{
 		srvCmpntType.GET("", amw.RequiredScopes(readScopes("server-component-types")), r.serverComponentTypeList)
 		srvCmpntType.POST("", amw.RequiredScopes(updateScopes("server-component-types")), r.serverComponentTypeCreate)
+		srvCmpntType.PUT("/:slug", amw.RequiredScopes(updateScopes("server-component-types")), r.serverComponentTypeUpdate)
+		srvCmpntType.DELETE("/:slug", amw.RequiredScopes(deleteScopes("server-component-types")), r.serverComponentTypeDelete)
+		srvCmpntType.POST("/:slug/merge", amw.RequiredScopes(updateScopes("server-component-types")), r.serverComponentTypeMerge)
 	}
 
 	// /server-component-firmwares
@@ -102,6 +142,8 @@ func (r *Router) Routes(rg *gin.RouterGroup) {
 		srvCmpntFw.GET("/:uuid", amw.RequiredScopes(readScopes("server-component-firmwares")), r.serverComponentFirmwareGet)
 		srvCmpntFw.PUT("/:uuid", amw.RequiredScopes(updateScopes("server-component-firmwares")), r.serverComponentFirmwareUpdate)
 		srvCmpntFw.DELETE("/:uuid", amw.RequiredScopes(deleteScopes("server-component-firmwares")), r.serverComponentFirmwareDelete)
+		srvCmpntFw.GET("/:uuid/usage", amw.RequiredScopes(readScopes("server-component-firmwares")), r.serverComponentFirmwareUsage)
+		srvCmpntFw.PUT("/:uuid/status", amw.RequiredScopes(updateScopes("server-component-firmwares")), r.serverComponentFirmwareStatusUpdate)
 	}
 
 	// /server-credential-types
@@ -109,6 +151,14 @@ func (r *Router) Routes(rg *gin.RouterGroup) {
 	{
 		srvCredentialTypes.GET("", amw.RequiredScopes(readScopes("server-credential-types")), r.serverCredentialTypesList)
 		srvCredentialTypes.POST("", amw.RequiredScopes(createScopes("server-credential-types")), r.serverCredentialTypesCreate)
+		srvCredentialTypes.PUT("/:slug", amw.RequiredScopes(updateScopes("server-credential-types")), r.serverCredentialTypesUpdate)
+		srvCredentialTypes.DELETE("/:slug", amw.RequiredScopes(deleteScopes("server-credential-types")), r.serverCredentialTypesDelete)
+	}
+
+	// /audit
+	audit := rg.Group("/audit")
+	{
+		audit.GET("/credential-access", amw.RequiredScopes([]string{"admin", "read:audit"}), r.credentialAccessEventList)
 	}
 
 	// /server-component-firmware-sets
@@ -116,9 +166,16 @@ func (r *Router) Routes(rg *gin.RouterGroup) {
 	{
 		srvCmpntFwSets.GET("", amw.RequiredScopes(readScopes("server-component-firmware-sets")), r.serverComponentFirmwareSetList)
 		srvCmpntFwSets.POST("", amw.RequiredScopes(createScopes("server-component-firmware-sets")), r.serverComponentFirmwareSetCreate)
+		srvCmpntFwSets.POST("/import", amw.RequiredScopes(createScopes("server-component-firmware-sets")), r.serverComponentFirmwareSetImport)
 		srvCmpntFwSets.GET("/:uuid", amw.RequiredScopes(readScopes("server-component-firmware-sets")), r.serverComponentFirmwareSetGet)
 		srvCmpntFwSets.PUT("/:uuid", amw.RequiredScopes(updateScopes("server-component-firmware-sets")), r.serverComponentFirmwareSetUpdate)
 		srvCmpntFwSets.DELETE("/:uuid", amw.RequiredScopes(deleteScopes("server-component-firmware-sets")), r.serverComponentFirmwareSetDelete)
+		srvCmpntFwSets.GET("/:uuid/export", amw.RequiredScopes(readScopes("server-component-firmware-sets")), r.serverComponentFirmwareSetExport)
+		srvCmpntFwSets.GET("/:uuid/coverage", amw.RequiredScopes(readScopes("server-component-firmware-sets")), r.serverComponentFirmwareSetCoverage)
+		srvCmpntFwSets.POST("/:uuid/clone", amw.RequiredScopes(createScopes("server-component-firmware-sets")), r.serverComponentFirmwareSetClone)
+		srvCmpntFwSets.GET("/:uuid/revisions", amw.RequiredScopes(readScopes("server-component-firmware-sets")), r.serverComponentFirmwareSetRevisionsList)
+		srvCmpntFwSets.GET("/:uuid/revisions/:revision", amw.RequiredScopes(readScopes("server-component-firmware-sets")), r.serverComponentFirmwareSetRevisionGet)
+		srvCmpntFwSets.GET("/:uuid/diff", amw.RequiredScopes(readScopes("server-component-firmware-sets")), r.serverComponentFirmwareSetDiff)
 		srvCmpntFwSets.POST("/:uuid/remove-firmware", amw.RequiredScopes(deleteScopes("server-component-firmware-sets")), r.serverComponentFirmwareSetRemoveFirmware)
 	}
 }
@@ -132,6 +189,12 @@ func createScopes(items ...string) []string {
 	return s
 }
 
+// credentialMetadataScopes are the scopes allowed to list credentials without
+// reading the secret values
+func credentialMetadataScopes() []string {
+	return []string{"read:server:credentials", "read:server:credentials:metadata"}
+}
+
 func readScopes(items ...string) []string {
 	s := []string{"read"}
 	for _, i := range items {
@@ -168,6 +231,44 @@ func (r *Router) parseUUID(c *gin.Context) (uuid.UUID, error) {
 	return u, err
 }
 
+// ClientCertificateSubject returns the subject of the TLS client certificate the request
+// was made with, or an empty string when there was none. The server only accepts client
+// certificates that are signed by its client CAs.
+func ClientCertificateSubject(c *gin.Context) string {
+	if c.Request.TLS == nil || len(c.Request.TLS.PeerCertificates) == 0 {
+		return ""
+	}
+
+	return c.Request.TLS.PeerCertificates[0].Subject.String()
+}
+
+// requestSubject returns the identity of the caller, the JWT subject or the client
+// certificate subject when the request has no JWT subject, as when the request was
+// authorized by its client certificate
+func requestSubject(c *gin.Context) string {
+	if sub := ginjwt.GetSubject(c); sub != "" {
+		return sub
+	}
+
+	return ClientCertificateSubject(c)
+}
+
+// forceParam returns true when the request has a force query param that is empty or true
+func forceParam(c *gin.Context) bool {
+	v, ok := c.GetQuery("force")
+	if !ok {
+		return false
+	}
+
+	if v == "" {
+		return true
+	}
+
+	force, _ := strconv.ParseBool(v)
+
+	return force
+}
+
 func (r *Router) loadServerFromParams(c *gin.Context) (*models.Server, error) {
 	u, err := r.parseUUID(c)
 	if err != nil {
@@ -239,7 +340,9 @@ func (r *Router) publishCreateServerMessage(ctx context.Context, srv *models.Ser
 		return
 	}
 	if err := r.EventStream.Publish(ctx, subject, payload); err != nil {
+		metrics.EventsFailed.WithLabelValues(subject).Inc()
 		r.Logger.With(zap.Error(err)).Error("unable to publish create-server message")
 		return
 	}
+	metrics.EventsPublished.WithLabelValues(subject).Inc()
 }
@@ -0,0 +1,68 @@
+package serverservice
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.hollow.sh/toolbox/ginjwt"
+)
+
+// contextKeyCertificateScopes holds the scopes of a request authorized by its client certificate
+const contextKeyCertificateScopes = "serverservice.certificate_scopes"
+
+// certificateAuth authorizes requests made with a client certificate whose subject has
+// scopes configured, other requests are authorized by the JWT middleware. The server only
+// accepts client certificates signed by its client CAs, so the subject can be trusted.
+type certificateAuth struct {
+	jwt    *ginjwt.Middleware
+	scopes map[string][]string
+}
+
+func (r *Router) authMiddleware() *certificateAuth {
+	return &certificateAuth{jwt: r.AuthMW, scopes: r.ClientCertificateScopes}
+}
+
+// AuthRequired accepts requests with a client certificate that has scopes, and requires
+// a valid JWT from the others
+func (m *certificateAuth) AuthRequired() gin.HandlerFunc {
+	jwtAuth := m.jwt.AuthRequired()
+
+	return func(c *gin.Context) {
+		// requests without a client certificate have no subject, they never get the
+		// scopes of a certificate
+		if subject := ClientCertificateSubject(c); subject != "" {
+			if scopes, ok := m.scopes[subject]; ok {
+				c.Set(contextKeyCertificateScopes, scopes)
+				return
+			}
+		}
+
+		jwtAuth(c)
+	}
+}
+
+// RequiredScopes requires one of the scopes, from the client certificate when it
+// authorized the request or from the JWT otherwise
+func (m *certificateAuth) RequiredScopes(scopes []string) gin.HandlerFunc {
+	jwtScopes := m.jwt.RequiredScopes(scopes)
+
+	return func(c *gin.Context) {
+		v, ok := c.Get(contextKeyCertificateScopes)
+		if !ok {
+			jwtScopes(c)
+			return
+		}
+
+		granted, _ := v.([]string)
+
+		for _, s := range scopes {
+			for _, g := range granted {
+				if s == g {
+					return
+				}
+			}
+		}
+
+		c.AbortWithStatusJSON(http.StatusForbidden, &ServerResponse{Message: "not authorized, missing required scope"})
+	}
+}
@@ -0,0 +1,69 @@
+package serverservice
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.hollow.sh/toolbox/ginjwt"
+)
+
+func TestCertificateAuth(t *testing.T) {
+	r := &Router{
+		AuthMW:                  &ginjwt.Middleware{},
+		ClientCertificateScopes: map[string][]string{"CN=inventory,O=hollow": {"read:server"}},
+	}
+	amw := r.authMiddleware()
+
+	router := gin.New()
+	router.Use(amw.AuthRequired())
+	router.GET("/read", amw.RequiredScopes(readScopes("server")), func(c *gin.Context) {
+		c.String(http.StatusOK, requestSubject(c))
+	})
+	router.DELETE("/delete", amw.RequiredScopes(deleteScopes("server")), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	request := func(method, path, subject string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{
+			{Subject: pkix.Name{CommonName: subject, Organization: []string{"hollow"}}},
+		}}
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		return w
+	}
+
+	w := request(http.MethodGet, "/read", "inventory")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "CN=inventory,O=hollow", w.Body.String(), "the certificate subject is the caller")
+
+	w = request(http.MethodDelete, "/delete", "inventory")
+	assert.Equal(t, http.StatusForbidden, w.Code, "the certificate isn't granted a delete scope")
+	assert.Contains(t, w.Body.String(), "missing required scope")
+}
+
+func TestCertificateAuthWithoutCertificate(t *testing.T) {
+	r := &Router{
+		AuthMW:                  &ginjwt.Middleware{},
+		ClientCertificateScopes: map[string][]string{"": {"read:server"}},
+	}
+
+	router := gin.New()
+	router.Use(r.authMiddleware().AuthRequired())
+	router.GET("/read", func(c *gin.Context) {
+		_, ok := c.Get(contextKeyCertificateScopes)
+		assert.False(t, ok, "a request without a certificate isn't authorized by certificate scopes")
+		c.Status(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/read", nil))
+}
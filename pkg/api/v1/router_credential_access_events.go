@@ -0,0 +1,82 @@
+package serverservice
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"go.hollow.sh/toolbox/ginjwt"
+
+	"go.hollow.sh/serverservice/internal/models"
+)
+
+func (r *Router) credentialAccessEventList(c *gin.Context) {
+	pager := parsePagination(c)
+
+	var params CredentialAccessEventListParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		badRequestResponse(c, "invalid filter payload: CredentialAccessEventListParams{}", err)
+		return
+	}
+
+	if params.ServerID != "" {
+		if _, err := uuid.Parse(params.ServerID); err != nil {
+			badRequestResponse(c, "invalid server_uuid filter", err)
+			return
+		}
+	}
+
+	mods := params.queryMods()
+
+	count, err := models.CredentialAccessEvents(mods...).Count(c.Request.Context(), r.DB)
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	// add pagination
+	pager.OrderBy = models.CredentialAccessEventTableColumns.CreatedAt + " DESC"
+	mods = append(mods, pager.queryMods()...)
+
+	dbEvents, err := models.CredentialAccessEvents(mods...).All(c.Request.Context(), r.DB)
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	events := make([]CredentialAccessEvent, 0, len(dbEvents))
+
+	for _, dbE := range dbEvents {
+		e := CredentialAccessEvent{}
+		if err := e.fromDBModel(dbE); err != nil {
+			failedConvertingToVersioned(c, err)
+			return
+		}
+
+		events = append(events, e)
+	}
+
+	pd := paginationData{
+		pageCount:  len(events),
+		totalCount: count,
+		pager:      pager,
+	}
+
+	listResponse(c, events, pd)
+}
+
+// recordCredentialAccess adds an entry to the credential access audit log for the
+// client of the request
+func recordCredentialAccess(ctx context.Context, exec boil.ContextExecutor, c *gin.Context, serverID, slug, action string) error {
+	e := models.CredentialAccessEvent{
+		ServerID:       serverID,
+		CredentialSlug: slug,
+		Action:         action,
+		Subject:        requestSubject(c),
+		Username:       ginjwt.GetUser(c),
+		SourceIP:       c.ClientIP(),
+	}
+
+	return e.Insert(ctx, exec, boil.Infer())
+}
@@ -0,0 +1,78 @@
+package serverservice_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.hollow.sh/serverservice/internal/dbtools"
+	serverservice "go.hollow.sh/serverservice/pkg/api/v1"
+)
+
+func TestIntegrationCredentialAccessEventList(t *testing.T) {
+	s := serverTest(t)
+
+	scopedRealClientTests(t, []string{"read:audit"}, func(ctx context.Context, authToken string, respCode int, expectError bool) error {
+		s.Client.SetToken(authToken)
+
+		_, _, err := s.Client.ListCredentialAccessEvents(ctx, nil)
+		if !expectError {
+			require.NoError(t, err)
+		}
+
+		return err
+	})
+
+	t.Run("credential reads are recorded", func(t *testing.T) {
+		ctx := context.TODO()
+		id := uuid.MustParse(dbtools.FixtureNemo.ID)
+		start := time.Now().Add(-1 * time.Minute)
+
+		s.Client.SetToken(validToken(adminScopes))
+
+		_, _, err := s.Client.GetCredential(ctx, id, serverservice.ServerCredentialTypeBMC)
+		require.NoError(t, err)
+
+		// reads that fail are not recorded
+		_, _, err = s.Client.GetCredential(ctx, uuid.MustParse(dbtools.FixtureMarlin.ID), serverservice.ServerCredentialTypeBMC)
+		require.Error(t, err)
+
+		s.Client.SetToken(validToken([]string{"read:audit"}))
+
+		events, resp, err := s.Client.ListCredentialAccessEvents(ctx, &serverservice.CredentialAccessEventListParams{
+			ServerID: id.String(),
+			Since:    start,
+		})
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.EqualValues(t, 1, resp.TotalRecordCount)
+		assert.Equal(t, id, events[0].ServerID)
+		assert.Equal(t, serverservice.ServerCredentialTypeBMC, events[0].CredentialSlug)
+		assert.Equal(t, serverservice.CredentialAccessActionRead, events[0].Action)
+		assert.Equal(t, "test-user", events[0].Subject)
+
+		events, _, err = s.Client.ListCredentialAccessEvents(ctx, &serverservice.CredentialAccessEventListParams{
+			Subject: "someone-else",
+		})
+		require.NoError(t, err)
+		assert.Empty(t, events)
+
+		events, _, err = s.Client.ListCredentialAccessEvents(ctx, &serverservice.CredentialAccessEventListParams{
+			Until: start,
+		})
+		require.NoError(t, err)
+		assert.Empty(t, events)
+	})
+
+	t.Run("requires an audit scope", func(t *testing.T) {
+		s.Client.SetToken(validToken(adminScopes))
+
+		_, _, err := s.Client.ListCredentialAccessEvents(context.TODO(), nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "403")
+	})
+}
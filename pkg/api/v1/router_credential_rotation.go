@@ -0,0 +1,176 @@
+package serverservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"go.uber.org/zap"
+
+	"go.hollow.sh/serverservice/internal/metrics"
+	"go.hollow.sh/serverservice/internal/models"
+)
+
+var errEventStreamNotConnected = errors.New("event stream not connected")
+
+func (r *Router) credentialRotationDueList(c *gin.Context) {
+	pager := parsePagination(c)
+
+	slug := c.Query("slug")
+	if slug != "" {
+		exists, err := models.ServerCredentialTypes(models.ServerCredentialTypeWhere.Slug.EQ(slug)).Exists(c.Request.Context(), r.DB)
+		if err != nil {
+			dbErrorResponse(c, err)
+			return
+		}
+
+		if !exists {
+			notFoundResponse(c, "server credential type not found")
+			return
+		}
+	}
+
+	mods := credentialRotationDueQueryMods(slug)
+
+	count, err := models.ServerCredentials(mods...).Count(c.Request.Context(), r.DB)
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	// the credentials that have been due the longest come first
+	pager.OrderBy = models.ServerCredentialTableColumns.UpdatedAt + " ASC"
+	mods = append(mods, qm.Load(models.ServerCredentialRels.ServerCredentialType))
+	mods = append(mods, pager.queryMods()...)
+
+	dbCreds, err := models.ServerCredentials(mods...).All(c.Request.Context(), r.DB)
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	due := make([]ServerCredentialRotationDue, 0, len(dbCreds))
+
+	for _, dbS := range dbCreds {
+		d := ServerCredentialRotationDue{}
+		if err := d.fromDBModel(dbS); err != nil {
+			failedConvertingToVersioned(c, err)
+			return
+		}
+
+		due = append(due, d)
+	}
+
+	pd := paginationData{
+		pageCount:  len(due),
+		totalCount: count,
+		pager:      pager,
+	}
+
+	listResponse(c, due, pd)
+}
+
+// credentialRotationDueQueryMods returns the query mods to select the credentials of servers
+// that haven't been deleted and are older than the max age of their type. When slug is set
+// only credentials of that type are selected.
+func credentialRotationDueQueryMods(slug string) []qm.QueryMod {
+	mods := []qm.QueryMod{
+		qm.InnerJoin(fmt.Sprintf("%s AS t ON t.%s = %s",
+			models.TableNames.ServerCredentialTypes,
+			models.ServerCredentialTypeColumns.ID,
+			models.ServerCredentialTableColumns.ServerCredentialTypeID,
+		)),
+		qm.InnerJoin(fmt.Sprintf("%s AS s ON s.%s = %s",
+			models.TableNames.Servers,
+			models.ServerColumns.ID,
+			models.ServerCredentialTableColumns.ServerID,
+		)),
+		qm.Where(fmt.Sprintf("s.%s IS NULL", models.ServerColumns.DeletedAt)),
+		qm.Where(fmt.Sprintf("t.%s IS NOT NULL", models.ServerCredentialTypeColumns.MaxAge)),
+		qm.Where(fmt.Sprintf("%s + t.%s * INTERVAL '1 second' < now()",
+			models.ServerCredentialTableColumns.UpdatedAt,
+			models.ServerCredentialTypeColumns.MaxAge,
+		)),
+	}
+
+	if slug != "" {
+		mods = append(mods, qm.Where(fmt.Sprintf("t.%s = ?", models.ServerCredentialTypeColumns.Slug), slug))
+	}
+
+	return mods
+}
+
+// NotifyExpiredCredentials publishes a credential expired message to the event stream for
+// each credential that is due for rotation and hasn't been notified since it last changed.
+// Each credential is claimed before it's published, so when several replicas run this only
+// one of them notifies it. It returns the number of credentials that were notified.
+func (r *Router) NotifyExpiredCredentials(ctx context.Context) (int, error) {
+	if r.EventStream == nil {
+		return 0, errEventStreamNotConnected
+	}
+
+	mods := credentialRotationDueQueryMods("")
+	mods = append(mods,
+		models.ServerCredentialWhere.ExpiryNotifiedAt.IsNull(),
+		qm.Load(models.ServerCredentialRels.ServerCredentialType),
+	)
+
+	dbCreds, err := models.ServerCredentials(mods...).All(ctx, r.DB)
+	if err != nil {
+		return 0, err
+	}
+
+	subject := strings.Join([]string{"server", "credential", "expired"}, ".")
+	notified := 0
+
+	for _, dbS := range dbCreds {
+		claimed, err := models.ServerCredentials(
+			models.ServerCredentialWhere.ID.EQ(dbS.ID),
+			models.ServerCredentialWhere.ExpiryNotifiedAt.IsNull(),
+			// a credential rotated since it was listed isn't expired anymore
+			models.ServerCredentialWhere.UpdatedAt.EQ(dbS.UpdatedAt),
+		).UpdateAll(ctx, r.DB, models.M{models.ServerCredentialColumns.ExpiryNotifiedAt: time.Now()})
+		if err != nil {
+			return notified, err
+		}
+
+		// another replica notified the credential, or it changed, since it was listed
+		if claimed == 0 {
+			continue
+		}
+
+		d := ServerCredentialRotationDue{}
+		if err := d.fromDBModel(dbS); err != nil {
+			return notified, err
+		}
+
+		payload, err := NewCredentialExpiredMessage(&d)
+		if err != nil {
+			return notified, err
+		}
+
+		if err := r.EventStream.Publish(ctx, subject, payload); err != nil {
+			metrics.EventsFailed.WithLabelValues(subject).Inc()
+			r.Logger.With(zap.Error(err)).Error("unable to publish credential-expired message")
+
+			// release the claim so the credential is notified on the next run
+			if _, err := models.ServerCredentials(
+				models.ServerCredentialWhere.ID.EQ(dbS.ID),
+			).UpdateAll(ctx, r.DB, models.M{models.ServerCredentialColumns.ExpiryNotifiedAt: nil}); err != nil {
+				return notified, err
+			}
+
+			continue
+		}
+
+		metrics.EventsPublished.WithLabelValues(subject).Inc()
+
+		notified++
+	}
+
+	return notified, nil
+}
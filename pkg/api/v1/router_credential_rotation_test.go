@@ -0,0 +1,88 @@
+package serverservice_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.hollow.sh/serverservice/internal/dbtools"
+	"go.hollow.sh/serverservice/internal/models"
+	serverservice "go.hollow.sh/serverservice/pkg/api/v1"
+)
+
+func TestIntegrationCredentialsRotationDue(t *testing.T) {
+	s := serverTest(t)
+	s.Client.SetToken(validToken(adminScopes))
+
+	ctx := context.TODO()
+
+	due, _, err := s.Client.ListCredentialsRotationDue(ctx, "", nil)
+	require.NoError(t, err)
+	assert.Len(t, due, 0, "credential types without a max age never expire")
+
+	types, _, err := s.Client.ListServerCredentialTypes(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, types, 1)
+
+	bmc := types[0]
+	bmc.MaxAge = 1
+
+	_, err = s.Client.UpdateServerCredentialType(ctx, serverservice.ServerCredentialTypeBMC, &bmc)
+	require.NoError(t, err)
+
+	// make the fixture credential older than the max age
+	_, err = models.ServerCredentials(
+		models.ServerCredentialWhere.ID.EQ(dbtools.FixtureNemoBMCSecret.ID),
+	).UpdateAll(ctx, dbtools.DatabaseTest(t), models.M{models.ServerCredentialColumns.UpdatedAt: time.Now().Add(-time.Hour)})
+	require.NoError(t, err)
+
+	scopedRealClientTests(t, []string{"read:server:credentials:metadata"}, func(ctx context.Context, authToken string, respCode int, expectError bool) error {
+		s.Client.SetToken(authToken)
+
+		due, _, err := s.Client.ListCredentialsRotationDue(ctx, serverservice.ServerCredentialTypeBMC, nil)
+		if !expectError {
+			require.NoError(t, err)
+			require.Len(t, due, 1)
+			assert.Equal(t, dbtools.FixtureNemo.ID, due[0].ServerID.String())
+			assert.Equal(t, serverservice.ServerCredentialTypeBMC, due[0].SecretType)
+			assert.EqualValues(t, 1, due[0].MaxAge)
+			assert.WithinDuration(t, due[0].UpdatedAt.Add(time.Second), due[0].DueAt, 0)
+		}
+
+		return err
+	})
+
+	s.Client.SetToken(validToken(adminScopes))
+
+	t.Run("changing the credential resets its age", func(t *testing.T) {
+		_, err := s.Client.SetCredential(ctx, uuid.MustParse(dbtools.FixtureNemo.ID), serverservice.ServerCredentialTypeBMC, "root", "new-secret")
+		require.NoError(t, err)
+
+		bmc.MaxAge = 3600
+
+		_, err = s.Client.UpdateServerCredentialType(ctx, serverservice.ServerCredentialTypeBMC, &bmc)
+		require.NoError(t, err)
+
+		due, _, err := s.Client.ListCredentialsRotationDue(ctx, "", nil)
+		require.NoError(t, err)
+		assert.Len(t, due, 0)
+	})
+
+	t.Run("negative max age is rejected", func(t *testing.T) {
+		bmc.MaxAge = -1
+
+		_, err := s.Client.UpdateServerCredentialType(ctx, serverservice.ServerCredentialTypeBMC, &bmc)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "max age")
+	})
+
+	t.Run("fails if secret type slug not found", func(t *testing.T) {
+		_, _, err := s.Client.ListCredentialsRotationDue(ctx, "notfound", nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not found")
+	})
+}
@@ -1,10 +1,17 @@
 package serverservice
 
 import (
+	"context"
+	"fmt"
+	"strings"
+
 	"github.com/gin-gonic/gin"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"go.uber.org/zap"
 
+	"go.hollow.sh/serverservice/internal/dbtools"
+	"go.hollow.sh/serverservice/internal/metrics"
 	"go.hollow.sh/serverservice/internal/models"
 )
 
@@ -17,6 +24,7 @@ func (r *Router) serverComponentFirmwareList(c *gin.Context) {
 		return
 	}
 
+	params.FirmwareSetAttributeListParams = parseQueryAttributesListParams(c, "set_attr")
 	mods := params.queryMods()
 
 	count, err := models.ComponentFirmwareVersions(mods...).Count(c.Request.Context(), r.DB)
@@ -95,6 +103,12 @@ func (r *Router) serverComponentFirmwareCreate(c *gin.Context) {
 		return
 	}
 
+	// firmware created deprecated or recalled is announced like a status change, with
+	// no previous status
+	if dbFirmware.Status != FirmwareStatusActive {
+		r.firmwareStatusChanged(c.Request.Context(), dbFirmware, "")
+	}
+
 	createdResponse(c, dbFirmware.ID)
 }
 
@@ -104,7 +118,18 @@ func (r *Router) serverComponentFirmwareDelete(c *gin.Context) {
 		return
 	}
 
-	if _, err = dbFirmware.Delete(c.Request.Context(), r.DB); err != nil {
+	usage, err := firmwareUsage(c.Request.Context(), r.DB, dbFirmware, FirmwareInstalledNamespace)
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	if usage.InUse() && !forceParam(c) {
+		conflictResponse(c, fmt.Sprintf("firmware is in use by %d firmware sets and %d server components, use force to delete it", len(usage.FirmwareSets), len(usage.Servers)))
+		return
+	}
+
+	if err := r.serverComponentFirmwareDeleteTx(c.Request.Context(), dbFirmware, usage.FirmwareSets); err != nil {
 		dbErrorResponse(c, err)
 		return
 	}
@@ -124,6 +149,10 @@ func (r *Router) serverComponentFirmwareUpdate(c *gin.Context) {
 		return
 	}
 
+	if dbFirmware.Checksum != newValues.Checksum || dbFirmware.RepositoryURL != newValues.RepositoryURL {
+		dbtools.ResetFirmwareVerification(dbFirmware)
+	}
+
 	dbFirmware.Vendor = newValues.Vendor
 	dbFirmware.Model = newValues.Model
 	dbFirmware.Filename = newValues.Filename
@@ -133,12 +162,137 @@ func (r *Router) serverComponentFirmwareUpdate(c *gin.Context) {
 	dbFirmware.UpstreamURL = newValues.UpstreamURL
 	dbFirmware.RepositoryURL = newValues.RepositoryURL
 
-	cols := boil.Infer()
+	previousStatus := dbFirmware.Status
+
+	if newValues.Status != "" {
+		status := ComponentFirmwareStatus{Status: newValues.Status, Reason: newValues.StatusReason}
+		if err := status.validate(); err != nil {
+			badRequestResponse(c, "invalid payload: ComponentFirmwareVersion{}", err)
+			return
+		}
+
+		status.apply(dbFirmware)
+	}
+
+	if err := r.serverComponentFirmwareUpdateTx(c.Request.Context(), dbFirmware); err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	r.firmwareStatusChanged(c.Request.Context(), dbFirmware, previousStatus)
+
+	updatedResponse(c, dbFirmware.ID)
+}
+
+// serverComponentFirmwareUpdateTx updates the firmware and records a revision of the
+// firmware sets that include it, as their documents change with the firmware
+func (r *Router) serverComponentFirmwareUpdateTx(ctx context.Context, dbFirmware *models.ComponentFirmwareVersion) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	// nolint:errcheck // rollback is a no-op once the transaction is committed
+	defer tx.Rollback()
+
+	if _, err := dbFirmware.Update(ctx, tx, boil.Infer()); err != nil {
+		return err
+	}
+
+	maps, err := models.ComponentFirmwareSetMaps(
+		models.ComponentFirmwareSetMapWhere.FirmwareID.EQ(dbFirmware.ID),
+	).All(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range maps {
+		if err := r.recordFirmwareSetRevision(ctx, tx, m.FirmwareSetID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// serverComponentFirmwareStatusUpdate sets the status of a firmware, so installers stop
+// using deprecated or recalled firmware without removing it from the firmware sets
+func (r *Router) serverComponentFirmwareStatusUpdate(c *gin.Context) {
+	dbFirmware, err := r.loadComponentFirmwareVersionFromParams(c)
+	if err != nil {
+		return
+	}
+
+	var status ComponentFirmwareStatus
+	if err := c.ShouldBindJSON(&status); err != nil {
+		badRequestResponse(c, "invalid payload: ComponentFirmwareStatus{}", err)
+		return
+	}
+
+	if err := status.validate(); err != nil {
+		badRequestResponse(c, "invalid payload: ComponentFirmwareStatus{}", err)
+		return
+	}
+
+	previousStatus := dbFirmware.Status
+
+	status.apply(dbFirmware)
+
+	cols := boil.Whitelist(
+		models.ComponentFirmwareVersionColumns.Status,
+		models.ComponentFirmwareVersionColumns.StatusReason,
+		models.ComponentFirmwareVersionColumns.UpdatedAt,
+	)
 
 	if _, err := dbFirmware.Update(c.Request.Context(), r.DB, cols); err != nil {
 		dbErrorResponse(c, err)
 		return
 	}
 
+	r.firmwareStatusChanged(c.Request.Context(), dbFirmware, previousStatus)
+
 	updatedResponse(c, dbFirmware.ID)
 }
+
+// firmwareStatusChanged publishes a firmware.<status> message, such as firmware.recalled,
+// when the status of the firmware changed
+func (r *Router) firmwareStatusChanged(ctx context.Context, dbFirmware *models.ComponentFirmwareVersion, previousStatus string) {
+	if dbFirmware.Status == previousStatus {
+		return
+	}
+
+	if r.EventStream == nil {
+		r.Logger.Error("Event publish skipped, eventStream not connected")
+		return
+	}
+
+	subject := strings.Join([]string{"firmware", dbFirmware.Status}, ".")
+
+	maps, err := models.ComponentFirmwareSetMaps(
+		models.ComponentFirmwareSetMapWhere.FirmwareID.EQ(dbFirmware.ID),
+	).All(ctx, r.DB)
+	if err != nil {
+		r.Logger.With(zap.Error(err)).Error("unable to list the firmware sets of a firmware")
+		return
+	}
+
+	setIDs := make([]string, 0, len(maps))
+	for _, m := range maps {
+		setIDs = append(setIDs, m.FirmwareSetID)
+	}
+
+	payload, err := NewFirmwareStatusChangedMessage(dbFirmware, previousStatus, setIDs)
+	if err != nil {
+		r.Logger.With(zap.Error(err)).Error("unable to create a firmware-status-changed message")
+		return
+	}
+
+	if err := r.EventStream.Publish(ctx, subject, payload); err != nil {
+		metrics.EventsFailed.WithLabelValues(subject).Inc()
+		r.Logger.With(zap.Error(err)).Error("unable to publish firmware-status-changed message")
+
+		return
+	}
+
+	metrics.EventsPublished.WithLabelValues(subject).Inc()
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"path"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -13,6 +14,7 @@ import (
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 
+	"go.hollow.sh/serverservice/internal/dbtools"
 	"go.hollow.sh/serverservice/internal/models"
 )
 
@@ -129,26 +131,7 @@ func (r *Router) serverComponentFirmwareSetGet(c *gin.Context) {
 }
 
 func (r *Router) queryFirmwareSetFirmware(ctx context.Context, firmwareSetID string) ([]*models.ComponentFirmwareVersion, error) {
-	mapMods := []qm.QueryMod{
-		qm.Where("firmware_set_id=?", firmwareSetID),
-		qm.Load(models.ComponentFirmwareSetMapRels.Firmware),
-	}
-
-	// query firmware set references
-	dbFirmwareSetMap, err := models.ComponentFirmwareSetMaps(mapMods...).All(ctx, r.DB)
-	if err != nil {
-		return nil, err
-	}
-
-	firmwares := []*models.ComponentFirmwareVersion{}
-
-	for _, m := range dbFirmwareSetMap {
-		if m.R != nil && m.R.Firmware != nil {
-			firmwares = append(firmwares, m.R.Firmware)
-		}
-	}
-
-	return firmwares, nil
+	return dbtools.FirmwareSetFirmware(ctx, r.DB, firmwareSetID)
 }
 
 func (r *Router) serverComponentFirmwareSetCreate(c *gin.Context) {
@@ -193,6 +176,17 @@ func (r *Router) serverComponentFirmwareSetCreate(c *gin.Context) {
 		return
 	}
 
+	if err := r.firmwareSetVetConflicts(c.Request.Context(), "", firmwareUUIDs); err != nil {
+		if errors.Is(err, errComponentFirmwareSetMap) {
+			badRequestResponse(c, "", err)
+			return
+		}
+
+		dbErrorResponse(c, err)
+
+		return
+	}
+
 	dbFirmwareSet, err := firmwareSetPayload.toDBModelFirmwareSet()
 	if err != nil {
 		badRequestResponse(c, "invalid db model: ComponentFirmwareSet", err)
@@ -287,10 +281,157 @@ func (r *Router) firmwareSetCreateTx(ctx context.Context, dbFirmwareSet *models.
 		}
 	}
 
+	if err := r.recordFirmwareSetRevision(ctx, tx, dbFirmwareSet.ID); err != nil {
+		return err
+	}
+
 	// commit
 	return tx.Commit()
 }
 
+// serverComponentFirmwareSetClone creates a firmware set with the attributes and firmware
+// of another, the firmware in the request replaces the firmware for the same vendor,
+// component and model
+func (r *Router) serverComponentFirmwareSetClone(c *gin.Context) {
+	u, err := r.parseUUID(c)
+	if err != nil {
+		return
+	}
+
+	var payload ComponentFirmwareSetCloneRequest
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		badRequestResponse(c, "invalid payload: ComponentFirmwareSetCloneRequest{}", err)
+		return
+	}
+
+	source, err := models.ComponentFirmwareSets(
+		models.ComponentFirmwareSetWhere.ID.EQ(u.String()),
+		qm.Load(models.ComponentFirmwareSetRels.FirmwareSetAttributesFirmwareSets),
+	).One(c.Request.Context(), r.DB)
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	firmwares, err := r.queryFirmwareSetFirmware(c.Request.Context(), source.ID)
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	if len(payload.ComponentFirmwareUUIDs) > 0 {
+		if _, err := r.firmwareSetVetFirmwareUUIDsForCreate(c, payload.ComponentFirmwareUUIDs); err != nil {
+			if errors.Is(err, errDBErr) {
+				dbErrorResponse(c, err)
+				return
+			}
+
+			badRequestResponse(c, "", err)
+
+			return
+		}
+
+		overrides, err := models.ComponentFirmwareVersions(
+			models.ComponentFirmwareVersionWhere.ID.IN(payload.ComponentFirmwareUUIDs),
+		).All(c.Request.Context(), r.DB)
+		if err != nil {
+			dbErrorResponse(c, err)
+			return
+		}
+
+		firmwares, err = firmwareSetOverride(firmwares, overrides)
+		if err != nil {
+			badRequestResponse(c, "", err)
+			return
+		}
+	}
+
+	if err := firmwareSetConflict(firmwares); err != nil {
+		badRequestResponse(c, "", err)
+		return
+	}
+
+	firmwareUUIDs := make([]uuid.UUID, 0, len(firmwares))
+
+	for _, f := range firmwares {
+		id, err := uuid.Parse(f.ID)
+		if err != nil {
+			failedConvertingToVersioned(c, err)
+			return
+		}
+
+		firmwareUUIDs = append(firmwareUUIDs, id)
+	}
+
+	attrs, err := convertFromDBModelAttributesFirmwareSet(source.R.FirmwareSetAttributesFirmwareSets)
+	if err != nil {
+		failedConvertingToVersioned(c, err)
+		return
+	}
+
+	dbFirmwareSet := &models.ComponentFirmwareSet{Name: payload.Name}
+
+	if err := r.firmwareSetCreateTx(c.Request.Context(), dbFirmwareSet, attrs, firmwareUUIDs); err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	// the clone is created below the firmware sets, not the source set
+	uri := path.Join(path.Dir(path.Dir(uriWithoutQueryParams(c))), dbFirmwareSet.ID)
+	createdResponseAt(c, dbFirmwareSet.ID, uri)
+}
+
+// firmwareSetOverride returns the firmware with the overrides in place of the firmware
+// for the same vendor, component and any of the same models. It returns an error naming
+// the models left without firmware when a replaced firmware is also for models that the
+// overrides don't cover.
+func firmwareSetOverride(firmwares, overrides []*models.ComponentFirmwareVersion) ([]*models.ComponentFirmwareVersion, error) {
+	replaced := map[string]bool{}
+
+	key := func(f *models.ComponentFirmwareVersion, model string) string {
+		return strings.ToLower(f.Vendor) + "/" + strings.ToLower(f.Component) + "/" + strings.ToLower(model)
+	}
+
+	for _, o := range overrides {
+		replaced[o.ID] = true
+
+		for _, model := range o.Model {
+			replaced[key(o, model)] = true
+		}
+	}
+
+	merged := []*models.ComponentFirmwareVersion{}
+
+	for _, f := range firmwares {
+		if replaced[f.ID] {
+			continue
+		}
+
+		covered, uncovered := []string{}, []string{}
+
+		for _, model := range f.Model {
+			if replaced[key(f, model)] {
+				covered = append(covered, model)
+			} else {
+				uncovered = append(uncovered, model)
+			}
+		}
+
+		switch {
+		case len(covered) == 0:
+			merged = append(merged, f)
+		case len(uncovered) != 0:
+			return nil, errors.Wrap(
+				errComponentFirmwareSetRequest,
+				fmt.Sprintf("firmware '%s' (%s) for the %s %s is replaced for %s but not for %s, add firmware for those models",
+					f.ID, f.Version, f.Vendor, f.Component, strings.Join(covered, ", "), strings.Join(uncovered, ", ")),
+			)
+		}
+	}
+
+	return append(merged, overrides...), nil
+}
+
 func (r *Router) serverComponentFirmwareSetUpdate(c *gin.Context) {
 	dbFirmware, err := r.componentFirmwareSetFromParams(c)
 	if err != nil {
@@ -345,6 +486,17 @@ func (r *Router) serverComponentFirmwareSetUpdate(c *gin.Context) {
 
 			return
 		}
+
+		if err := r.firmwareSetVetConflicts(c.Request.Context(), dbFirmwareSet.ID, firmwareUUIDs); err != nil {
+			if errors.Is(err, errComponentFirmwareSetMap) {
+				badRequestResponse(c, "", err)
+				return
+			}
+
+			dbErrorResponse(c, err)
+
+			return
+		}
 	}
 
 	err = r.firmwareSetUpdateTx(c.Request.Context(), dbFirmwareSet, dbAttributesFirmwareSet, firmwareUUIDs)
@@ -422,6 +574,42 @@ func (r *Router) firmwareSetVetFirmwareUUIDsForUpdate(ctx context.Context, firmw
 	return vetted, nil
 }
 
+// firmwareSetVetConflicts checks the firmware along with the firmware already in the set
+// doesn't include two firmware for the same vendor, component and model, installers
+// can't tell which of them to use. The firmware set ID is empty for a new set.
+func (r *Router) firmwareSetVetConflicts(ctx context.Context, firmwareSetID string, firmwareUUIDs []uuid.UUID) error {
+	ids := make([]string, 0, len(firmwareUUIDs))
+	for _, id := range firmwareUUIDs {
+		ids = append(ids, id.String())
+	}
+
+	firmwares, err := models.ComponentFirmwareVersions(models.ComponentFirmwareVersionWhere.ID.IN(ids)).All(ctx, r.DB)
+	if err != nil {
+		return err
+	}
+
+	if firmwareSetID != "" {
+		current, err := r.queryFirmwareSetFirmware(ctx, firmwareSetID)
+		if err != nil {
+			return err
+		}
+
+		firmwares = append(current, firmwares...)
+	}
+
+	return firmwareSetConflict(firmwares)
+}
+
+// firmwareSetConflict returns an error when two of the firmware are for the same vendor,
+// component and model
+func firmwareSetConflict(firmwares []*models.ComponentFirmwareVersion) error {
+	if err := dbtools.FirmwareSetConflict(firmwares); err != nil {
+		return errors.Wrap(errComponentFirmwareSetMap, err.Error())
+	}
+
+	return nil
+}
+
 func (r *Router) firmwareSetMap(ctx context.Context, firmwareSet *models.ComponentFirmwareSet, firmwareUUID uuid.UUID) ([]*models.ComponentFirmwareSetMap, error) {
 	var m []*models.ComponentFirmwareSetMap
 
@@ -488,6 +676,10 @@ func (r *Router) firmwareSetUpdateTx(ctx context.Context, newValues *models.Comp
 		}
 	}
 
+	if err := r.recordFirmwareSetRevision(ctx, tx, newValues.ID); err != nil {
+		return err
+	}
+
 	// commit
 	return tx.Commit()
 }
@@ -592,6 +784,62 @@ func (r *Router) serverComponentFirmwareSetDelete(c *gin.Context) {
 	deletedResponse(c)
 }
 
+// serverComponentFirmwareSetCoverage reports the components of a hardware model the
+// firmware set is missing firmware for. The expected components are given by the
+// component query param, or are the components with firmware for the model.
+func (r *Router) serverComponentFirmwareSetCoverage(c *gin.Context) {
+	model := c.Query("model")
+	if model == "" {
+		badRequestResponse(c, "", errors.Wrap(errComponentFirmwareSetRequest, "expected a hardware model, got none"))
+		return
+	}
+
+	u, err := r.parseUUID(c)
+	if err != nil {
+		return
+	}
+
+	firmwareSet, err := models.FindComponentFirmwareSet(c.Request.Context(), r.DB, u.String())
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	firmwares, err := r.queryFirmwareSetFirmware(c.Request.Context(), firmwareSet.ID)
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	expected := []string{}
+
+	for _, v := range c.QueryArray("component") {
+		for _, component := range strings.Split(v, ",") {
+			if component = strings.TrimSpace(component); component != "" {
+				expected = append(expected, component)
+			}
+		}
+	}
+
+	if len(expected) == 0 {
+		available, err := models.ComponentFirmwareVersions(
+			qm.Select("DISTINCT "+models.ComponentFirmwareVersionColumns.Component),
+			qm.Where("EXISTS (SELECT 1 FROM unnest(component_firmware_version.model) AS m(model) WHERE lower(m.model) = lower(?))", model),
+			models.ComponentFirmwareVersionWhere.Status.NEQ(FirmwareStatusRecalled),
+		).All(c.Request.Context(), r.DB)
+		if err != nil {
+			dbErrorResponse(c, err)
+			return
+		}
+
+		for _, f := range available {
+			expected = append(expected, f.Component)
+		}
+	}
+
+	itemResponse(c, newComponentFirmwareSetCoverage(model, expected, firmwares))
+}
+
 func (r *Router) componentFirmwareSetFromParams(c *gin.Context) (*models.ComponentFirmwareSet, error) {
 	u, err := r.parseUUID(c)
 	if err != nil {
@@ -610,7 +858,7 @@ func (r *Router) componentFirmwareSetFromParams(c *gin.Context) (*models.Compone
 	return firmwareSet, nil
 }
 
-func (r *Router) firmwareSetDeleteMappingTx(ctx context.Context, _ *models.ComponentFirmwareSet, removeMappings []*models.ComponentFirmwareSetMap) error {
+func (r *Router) firmwareSetDeleteMappingTx(ctx context.Context, firmwareSet *models.ComponentFirmwareSet, removeMappings []*models.ComponentFirmwareSetMap) error {
 	// being transaction to insert a new firmware set and its mapping
 	tx, err := r.DB.BeginTx(ctx, nil)
 	if err != nil {
@@ -621,10 +869,14 @@ func (r *Router) firmwareSetDeleteMappingTx(ctx context.Context, _ *models.Compo
 	defer tx.Rollback()
 
 	for _, mapping := range removeMappings {
-		if _, err := mapping.Delete(ctx, r.DB); err != nil {
+		if _, err := mapping.Delete(ctx, tx); err != nil {
 			return err
 		}
 	}
 
+	if err := r.recordFirmwareSetRevision(ctx, tx, firmwareSet.ID); err != nil {
+		return err
+	}
+
 	return tx.Commit()
 }
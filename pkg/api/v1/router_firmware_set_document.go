@@ -0,0 +1,99 @@
+package serverservice
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"go.uber.org/zap"
+
+	"go.hollow.sh/serverservice/internal/dbtools"
+	"go.hollow.sh/serverservice/internal/models"
+)
+
+// errFirmwareSetImportScope stops an import that would change a firmware set without the
+// update scopes, the response is already written
+var errFirmwareSetImportScope = errors.New("missing firmware set update scope")
+
+// serverComponentFirmwareSetExport writes the firmware set as a document that references
+// firmware by vendor, component, version and filename
+func (r *Router) serverComponentFirmwareSetExport(c *gin.Context) {
+	format := ComponentFirmwareSetDocumentFormat(c.DefaultQuery("format", string(ComponentFirmwareSetDocumentYAML)))
+	if format != ComponentFirmwareSetDocumentYAML && format != ComponentFirmwareSetDocumentJSON {
+		badRequestResponse(c, "invalid document format", fmt.Errorf("%w: %q", errInvalidDocumentFormat, format))
+		return
+	}
+
+	u, err := r.parseUUID(c)
+	if err != nil {
+		return
+	}
+
+	dbFirmwareSet, err := models.ComponentFirmwareSets(
+		models.ComponentFirmwareSetWhere.ID.EQ(u.String()),
+		qm.Load(models.ComponentFirmwareSetRels.FirmwareSetAttributesFirmwareSets),
+	).One(c.Request.Context(), r.DB)
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	firmwares, err := r.queryFirmwareSetFirmware(c.Request.Context(), dbFirmwareSet.ID)
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	doc, err := newComponentFirmwareSetDocument(dbFirmwareSet, firmwares)
+	if err != nil {
+		failedConvertingToVersioned(c, err)
+		return
+	}
+
+	c.Header("Content-Type", format.contentType())
+	c.Status(http.StatusOK)
+
+	if err := doc.Encode(c.Writer, format); err != nil {
+		r.Logger.Error("failed writing firmware set document", zap.Error(err))
+	}
+}
+
+// serverComponentFirmwareSetImport creates or updates the firmware set with the name
+// of a YAML or JSON document. Changing a firmware set that exists needs the update scopes.
+func (r *Router) serverComponentFirmwareSetImport(c *gin.Context) {
+	doc, err := DecodeComponentFirmwareSetDocument(c.Request.Body)
+	if err != nil {
+		badRequestResponse(c, "invalid payload: ComponentFirmwareSetDocument{}", err)
+		return
+	}
+
+	opts := dbtools.FirmwareSetImportOptions{Revisions: r.FirmwareSetRevisions}
+
+	if r.AuthMW != nil {
+		// the middleware writes the response when the scope is missing
+		opts.Update = func(_ *models.ComponentFirmwareSet) error {
+			if r.authMiddleware().RequiredScopes(updateScopes("server-component-firmware-sets"))(c); c.IsAborted() {
+				return errFirmwareSetImportScope
+			}
+
+			return nil
+		}
+	}
+
+	result, err := dbtools.ImportFirmwareSet(c.Request.Context(), r.DB, doc.toDBDocument(), opts)
+	if err != nil {
+		switch {
+		case errors.Is(err, errFirmwareSetImportScope):
+		case errors.Is(err, ErrComponentFirmwareSetDocument):
+			badRequestResponse(c, "invalid payload: ComponentFirmwareSetDocument{}", err)
+		default:
+			dbErrorResponse(c, err)
+		}
+
+		return
+	}
+
+	itemResponse(c, &ComponentFirmwareSetImportResult{UUID: result.ID, Name: result.Name, Status: result.Status})
+}
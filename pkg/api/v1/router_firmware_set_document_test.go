@@ -0,0 +1,134 @@
+package serverservice_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.hollow.sh/serverservice/internal/dbtools"
+	serverservice "go.hollow.sh/serverservice/pkg/api/v1"
+)
+
+func TestIntegrationServerComponentFirmwareSetExport(t *testing.T) {
+	s := serverTest(t)
+
+	realClientTests(t, func(ctx context.Context, authToken string, respCode int, expectError bool) error {
+		s.Client.SetToken(authToken)
+
+		data, err := s.Client.ExportServerComponentFirmwareSet(ctx, uuid.MustParse(dbtools.FixtureFirmwareSetR640.ID), serverservice.ComponentFirmwareSetDocumentYAML)
+		if !expectError {
+			require.NoError(t, err)
+
+			doc, err := serverservice.DecodeComponentFirmwareSetDocument(bytes.NewReader(data))
+			require.NoError(t, err)
+
+			assert.Equal(t, &serverservice.ComponentFirmwareSetDocument{
+				Name: "r640",
+				Attributes: []serverservice.ComponentFirmwareSetDocumentAttribute{
+					{Namespace: "sh.hollow.firmware_set.labels", Data: map[string]interface{}{"vendor": "dell", "model": "r640"}},
+				},
+				Firmware: []serverservice.ComponentFirmwareReference{
+					{
+						Vendor:    dbtools.FixtureDellR640BIOS.Vendor,
+						Component: dbtools.FixtureDellR640BIOS.Component,
+						Version:   dbtools.FixtureDellR640BIOS.Version,
+						Filename:  dbtools.FixtureDellR640BIOS.Filename,
+					},
+					{
+						Vendor:    dbtools.FixtureDellR640BMC.Vendor,
+						Component: dbtools.FixtureDellR640BMC.Component,
+						Version:   dbtools.FixtureDellR640BMC.Version,
+						Filename:  dbtools.FixtureDellR640BMC.Filename,
+					},
+				},
+			}, doc)
+		}
+
+		return err
+	})
+}
+
+func TestIntegrationServerComponentFirmwareSetImport(t *testing.T) {
+	s := serverTest(t)
+	s.Client.SetToken(validToken(adminScopes))
+
+	data, err := s.Client.ExportServerComponentFirmwareSet(context.TODO(), uuid.MustParse(dbtools.FixtureFirmwareSetR640.ID), serverservice.ComponentFirmwareSetDocumentJSON)
+	require.NoError(t, err)
+
+	doc, err := serverservice.DecodeComponentFirmwareSetDocument(bytes.NewReader(data))
+	require.NoError(t, err)
+
+	t.Run("unchanged", func(t *testing.T) {
+		result, _, err := s.Client.ImportServerComponentFirmwareSet(context.TODO(), doc)
+		require.NoError(t, err)
+		assert.Equal(t, &serverservice.ComponentFirmwareSetImportResult{
+			UUID:   dbtools.FixtureFirmwareSetR640.ID,
+			Name:   "r640",
+			Status: serverservice.ComponentFirmwareSetUnchanged,
+		}, result)
+	})
+
+	t.Run("updating needs the update scopes", func(t *testing.T) {
+		s.Client.SetToken(validToken([]string{"create:server-component-firmware-sets"}))
+		defer s.Client.SetToken(validToken(adminScopes))
+
+		updated := *doc
+		updated.Firmware = doc.Firmware[1:]
+
+		_, _, err := s.Client.ImportServerComponentFirmwareSet(context.TODO(), &updated)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "403")
+
+		// importing a set that already matches doesn't change it
+		result, _, err := s.Client.ImportServerComponentFirmwareSet(context.TODO(), doc)
+		require.NoError(t, err)
+		assert.Equal(t, serverservice.ComponentFirmwareSetUnchanged, result.Status)
+	})
+
+	t.Run("updated", func(t *testing.T) {
+		updated := *doc
+		updated.Attributes = []serverservice.ComponentFirmwareSetDocumentAttribute{
+			{Namespace: "sh.hollow.firmware_set.labels", Data: map[string]interface{}{"vendor": "dell", "model": "r640", "channel": "stable"}},
+		}
+		updated.Firmware = doc.Firmware[1:]
+
+		result, _, err := s.Client.ImportServerComponentFirmwareSet(context.TODO(), &updated)
+		require.NoError(t, err)
+		assert.Equal(t, serverservice.ComponentFirmwareSetUpdated, result.Status)
+		assert.Equal(t, dbtools.FixtureFirmwareSetR640.ID, result.UUID)
+
+		fws, _, err := s.Client.GetServerComponentFirmwareSet(context.TODO(), uuid.MustParse(result.UUID))
+		require.NoError(t, err)
+		require.Len(t, fws.ComponentFirmware, 1)
+		assert.Equal(t, dbtools.FixtureDellR640BMC.ID, fws.ComponentFirmware[0].UUID.String())
+		require.Len(t, fws.Attributes, 1)
+		assert.JSONEq(t, `{"vendor": "dell", "model": "r640", "channel": "stable"}`, string(fws.Attributes[0].Data))
+	})
+
+	t.Run("created", func(t *testing.T) {
+		created := *doc
+		created.Name = "r640-copy"
+
+		result, _, err := s.Client.ImportServerComponentFirmwareSet(context.TODO(), &created)
+		require.NoError(t, err)
+		assert.Equal(t, serverservice.ComponentFirmwareSetCreated, result.Status)
+
+		fws, _, err := s.Client.GetServerComponentFirmwareSet(context.TODO(), uuid.MustParse(result.UUID))
+		require.NoError(t, err)
+		assert.Equal(t, "r640-copy", fws.Name)
+		assert.Len(t, fws.ComponentFirmware, 2)
+	})
+
+	t.Run("missing firmware", func(t *testing.T) {
+		missing := *doc
+		missing.Firmware = []serverservice.ComponentFirmwareReference{{Vendor: "Dell", Component: "bmc", Version: "9.99", Filename: "missing.EXE"}}
+
+		_, _, err := s.Client.ImportServerComponentFirmwareSet(context.TODO(), &missing)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "firmware not found: [Dell/bmc/9.99/missing.EXE]")
+	})
+}
@@ -0,0 +1,163 @@
+package serverservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"go.hollow.sh/serverservice/internal/dbtools"
+	"go.hollow.sh/serverservice/internal/models"
+)
+
+var errComponentFirmwareSetRevision = errors.New("invalid firmware set revision")
+
+func (r *Router) serverComponentFirmwareSetRevisionsList(c *gin.Context) {
+	u, err := r.parseUUID(c)
+	if err != nil {
+		return
+	}
+
+	firmwareSet, err := models.FindComponentFirmwareSet(c.Request.Context(), r.DB, u.String())
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	dbRevisions, err := models.ComponentFirmwareSetRevisions(
+		models.ComponentFirmwareSetRevisionWhere.FirmwareSetID.EQ(firmwareSet.ID),
+		qm.OrderBy(models.ComponentFirmwareSetRevisionColumns.Revision+" DESC"),
+	).All(c.Request.Context(), r.DB)
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	revisions := []ComponentFirmwareSetRevision{}
+
+	for _, dbR := range dbRevisions {
+		v := ComponentFirmwareSetRevision{}
+		if err := v.fromDBModel(dbR); err != nil {
+			failedConvertingToVersioned(c, err)
+			return
+		}
+
+		revisions = append(revisions, v)
+	}
+
+	itemResponse(c, revisions)
+}
+
+func (r *Router) serverComponentFirmwareSetRevisionGet(c *gin.Context) {
+	revision, err := strconv.ParseInt(c.Param("revision"), 10, 64)
+	if err != nil {
+		badRequestResponse(c, "invalid firmware set revision", err)
+		return
+	}
+
+	u, err := r.parseUUID(c)
+	if err != nil {
+		return
+	}
+
+	v, err := firmwareSetRevision(c.Request.Context(), r.DB, u.String(), revision)
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	itemResponse(c, v)
+}
+
+// serverComponentFirmwareSetDiff compares two revisions of the firmware set, by default
+// the latest revision and the one before it
+func (r *Router) serverComponentFirmwareSetDiff(c *gin.Context) {
+	var params componentFirmwareSetDiffParams
+
+	for name, value := range map[string]*int64{"from": &params.From, "to": &params.To} {
+		if s := c.Query(name); s != "" {
+			n, err := strconv.ParseInt(s, 10, 64)
+			if err != nil || n < 1 {
+				badRequestResponse(c, "invalid firmware set revision", fmt.Errorf("%w: %s=%s", errComponentFirmwareSetRevision, name, s))
+				return
+			}
+
+			*value = n
+		}
+	}
+
+	u, err := r.parseUUID(c)
+	if err != nil {
+		return
+	}
+
+	if params.To == 0 {
+		latest, err := firmwareSetRevision(c.Request.Context(), r.DB, u.String(), 0)
+		if err != nil {
+			dbErrorResponse(c, err)
+			return
+		}
+
+		params.To = latest.Revision
+	}
+
+	if params.From == 0 {
+		params.From = params.To - 1
+	}
+
+	if params.From < 1 {
+		badRequestResponse(c, "", fmt.Errorf("%w: revision %d has no previous revision", errComponentFirmwareSetRevision, params.To))
+		return
+	}
+
+	from, err := firmwareSetRevision(c.Request.Context(), r.DB, u.String(), params.From)
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	to, err := firmwareSetRevision(c.Request.Context(), r.DB, u.String(), params.To)
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	itemResponse(c, newComponentFirmwareSetDiff(from, to))
+}
+
+// firmwareSetRevision returns the revision of the firmware set, or the latest revision when it's 0
+func firmwareSetRevision(ctx context.Context, exec boil.ContextExecutor, firmwareSetID string, revision int64) (*ComponentFirmwareSetRevision, error) {
+	mods := []qm.QueryMod{
+		models.ComponentFirmwareSetRevisionWhere.FirmwareSetID.EQ(firmwareSetID),
+		qm.OrderBy(models.ComponentFirmwareSetRevisionColumns.Revision + " DESC"),
+	}
+
+	if revision != 0 {
+		mods = append(mods, models.ComponentFirmwareSetRevisionWhere.Revision.EQ(revision))
+	}
+
+	dbR, err := models.ComponentFirmwareSetRevisions(mods...).One(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+
+	v := &ComponentFirmwareSetRevision{}
+	if err := v.fromDBModel(dbR); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// recordFirmwareSetRevision records a revision of the firmware set when revisions are on
+func (r *Router) recordFirmwareSetRevision(ctx context.Context, exec boil.ContextExecutor, firmwareSetID string) error {
+	if !r.FirmwareSetRevisions {
+		return nil
+	}
+
+	return dbtools.RecordFirmwareSetRevision(ctx, exec, firmwareSetID)
+}
@@ -3,6 +3,7 @@ package serverservice_test
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"testing"
 
 	"github.com/google/uuid"
@@ -814,3 +815,208 @@ func TestIntegrationServerComponentFirmwareSetRemoveFirmware(t *testing.T) {
 		})
 	}
 }
+
+func TestIntegrationServerComponentFirmwareSetConflicts(t *testing.T) {
+	s := serverTest(t)
+	s.Client.SetToken(validToken(adminScopes))
+
+	// a second BIOS for the R640
+	biosID, _, err := s.Client.CreateServerComponentFirmware(context.TODO(), serverservice.ComponentFirmwareVersion{
+		UUID:          uuid.New(),
+		Vendor:        "Dell",
+		Model:         []string{"R640"},
+		Filename:      "BIOS_R640_2.5.0.EXE",
+		Version:       "2.5.0",
+		Component:     "bios",
+		Checksum:      "foobar",
+		UpstreamURL:   "https://vendor.com/firmwares/BIOS_R640_2.5.0.EXE",
+		RepositoryURL: "https://example-firmware-bucket.s3.amazonaws.com/firmware/dell/r640/bios/BIOS_R640_2.5.0.EXE",
+	})
+	require.NoError(t, err)
+
+	_, _, err = s.Client.CreateServerComponentFirmwareSet(context.TODO(), serverservice.ComponentFirmwareSetRequest{
+		Name:                   "r640-conflicting",
+		ComponentFirmwareUUIDs: []string{dbtools.FixtureDellR640BIOS.ID, biosID.String()},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "are both for the Dell bios of model R640")
+
+	_, err = s.Client.UpdateComponentFirmwareSetRequest(context.TODO(), uuid.MustParse(dbtools.FixtureFirmwareSetR640.ID), serverservice.ComponentFirmwareSetRequest{
+		ID:                     uuid.MustParse(dbtools.FixtureFirmwareSetR640.ID),
+		ComponentFirmwareUUIDs: []string{biosID.String()},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "are both for the Dell bios of model R640")
+}
+
+func TestIntegrationServerComponentFirmwareSetCoverage(t *testing.T) {
+	s := serverTest(t)
+
+	firmwareSetID := uuid.MustParse(dbtools.FixtureFirmwareSetR640.ID)
+
+	realClientTests(t, func(ctx context.Context, authToken string, respCode int, expectError bool) error {
+		s.Client.SetToken(authToken)
+
+		coverage, _, err := s.Client.GetServerComponentFirmwareSetCoverage(ctx, firmwareSetID, "R640", nil)
+		if !expectError {
+			require.NoError(t, err)
+			assert.Equal(t, &serverservice.ComponentFirmwareSetCoverage{
+				Model:    "R640",
+				Expected: []string{"bios", "bmc", "cpld"},
+				Covered:  []string{"bios", "bmc"},
+				Missing:  []string{"cpld"},
+			}, coverage)
+		}
+
+		return err
+	})
+
+	s.Client.SetToken(validToken(adminScopes))
+
+	coverage, _, err := s.Client.GetServerComponentFirmwareSetCoverage(context.TODO(), firmwareSetID, "R640", []string{"bmc", "nic"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bmc"}, coverage.Covered)
+	assert.Equal(t, []string{"nic"}, coverage.Missing)
+
+	_, _, err = s.Client.GetServerComponentFirmwareSetCoverage(context.TODO(), firmwareSetID, "", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "expected a hardware model, got none")
+}
+
+func TestIntegrationServerComponentFirmwareSetClone(t *testing.T) {
+	s := serverTest(t)
+	s.Client.SetToken(validToken(adminScopes))
+
+	sourceID := uuid.MustParse(dbtools.FixtureFirmwareSetR640.ID)
+
+	// a newer BIOS for the R640
+	biosID, _, err := s.Client.CreateServerComponentFirmware(context.TODO(), serverservice.ComponentFirmwareVersion{
+		UUID:          uuid.New(),
+		Vendor:        "Dell",
+		Model:         []string{"R640"},
+		Filename:      "BIOS_R640_2.5.0.EXE",
+		Version:       "2.5.0",
+		Component:     "bios",
+		Checksum:      "foobar",
+		UpstreamURL:   "https://vendor.com/firmwares/BIOS_R640_2.5.0.EXE",
+		RepositoryURL: "https://example-firmware-bucket.s3.amazonaws.com/firmware/dell/r640/bios/BIOS_R640_2.5.0.EXE",
+	})
+	require.NoError(t, err)
+
+	var cloneNum int
+
+	realClientTests(t, func(ctx context.Context, authToken string, respCode int, expectError bool) error {
+		s.Client.SetToken(authToken)
+
+		cloneNum++
+
+		id, _, err := s.Client.CloneServerComponentFirmwareSet(ctx, sourceID, serverservice.ComponentFirmwareSetCloneRequest{
+			Name:                   fmt.Sprintf("r640-clone-%d", cloneNum),
+			ComponentFirmwareUUIDs: []string{biosID.String(), dbtools.FixtureDellR640CPLD.ID},
+		})
+		if !expectError {
+			require.NoError(t, err)
+
+			clone, _, err := s.Client.GetServerComponentFirmwareSet(ctx, *id)
+			require.NoError(t, err)
+
+			versions := []string{}
+			for _, f := range clone.ComponentFirmware {
+				versions = append(versions, f.Version)
+			}
+
+			assert.ElementsMatch(t, []string{"5.10.00.00", "2.5.0", "1.0.1"}, versions)
+			require.Len(t, clone.Attributes, 1)
+			assert.Equal(t, "sh.hollow.firmware_set.labels", clone.Attributes[0].Namespace)
+
+			source, _, err := s.Client.GetServerComponentFirmwareSet(ctx, sourceID)
+			require.NoError(t, err)
+			assert.Len(t, source.ComponentFirmware, 2, "the source set is unchanged")
+
+			revisions, _, err := s.Client.ListServerComponentFirmwareSetRevisions(ctx, *id)
+			require.NoError(t, err)
+			require.Len(t, revisions, 1)
+			assert.Equal(t, int64(1), revisions[0].Revision)
+		}
+
+		return err
+	})
+
+	s.Client.SetToken(validToken(adminScopes))
+
+	_, _, err = s.Client.CloneServerComponentFirmwareSet(context.TODO(), sourceID, serverservice.ComponentFirmwareSetCloneRequest{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "400")
+
+	_, _, err = s.Client.CloneServerComponentFirmwareSet(context.TODO(), sourceID, serverservice.ComponentFirmwareSetCloneRequest{
+		Name: dbtools.FixtureFirmwareSetR640.Name,
+	})
+	require.Error(t, err, "the name of a firmware set is unique")
+}
+
+func TestIntegrationServerComponentFirmwareSetRevisions(t *testing.T) {
+	s := serverTest(t)
+	s.Client.SetToken(validToken(adminScopes))
+
+	ctx := context.TODO()
+
+	id, _, err := s.Client.CreateServerComponentFirmwareSet(ctx, serverservice.ComponentFirmwareSetRequest{
+		Name:                   "r640-revisions",
+		ComponentFirmwareUUIDs: []string{dbtools.FixtureDellR640BMC.ID},
+	})
+	require.NoError(t, err)
+
+	_, err = s.Client.UpdateComponentFirmwareSetRequest(ctx, *id, serverservice.ComponentFirmwareSetRequest{
+		ID:                     *id,
+		ComponentFirmwareUUIDs: []string{dbtools.FixtureDellR640CPLD.ID},
+	})
+	require.NoError(t, err)
+
+	_, err = s.Client.RemoveServerComponentFirmwareSetFirmware(ctx, *id, serverservice.ComponentFirmwareSetRequest{
+		ID:                     *id,
+		ComponentFirmwareUUIDs: []string{dbtools.FixtureDellR640BMC.ID},
+	})
+	require.NoError(t, err)
+
+	realClientTests(t, func(ctx context.Context, authToken string, respCode int, expectError bool) error {
+		s.Client.SetToken(authToken)
+
+		revisions, _, err := s.Client.ListServerComponentFirmwareSetRevisions(ctx, *id)
+		if !expectError {
+			require.NoError(t, err)
+			require.Len(t, revisions, 3)
+			assert.Equal(t, int64(3), revisions[0].Revision, "the latest revision is first")
+		}
+
+		return err
+	})
+
+	s.Client.SetToken(validToken(adminScopes))
+
+	first, _, err := s.Client.GetServerComponentFirmwareSetRevision(ctx, *id, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "r640-revisions", first.Document.Name)
+	require.Len(t, first.Document.Firmware, 1)
+	assert.Equal(t, "5.10.00.00", first.Document.Firmware[0].Version)
+	assert.Equal(t, dbtools.FixtureDellR640BMC.ID, first.Document.Firmware[0].UUID)
+	assert.Equal(t, dbtools.FixtureDellR640BMC.Checksum, first.Document.Firmware[0].Checksum)
+
+	// the latest revision compared with the one before it
+	diff, _, err := s.Client.DiffServerComponentFirmwareSetRevisions(ctx, *id, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), diff.From)
+	assert.Equal(t, int64(3), diff.To)
+	assert.Empty(t, diff.Added)
+	require.Len(t, diff.Removed, 1)
+	assert.Equal(t, "5.10.00.00", diff.Removed[0].Version)
+
+	diff, _, err = s.Client.DiffServerComponentFirmwareSetRevisions(ctx, *id, 1, 3)
+	require.NoError(t, err)
+	require.Len(t, diff.Added, 1)
+	assert.Equal(t, "1.0.1", diff.Added[0].Version)
+	require.Len(t, diff.Removed, 1)
+
+	_, _, err = s.Client.GetServerComponentFirmwareSetRevision(ctx, *id, 4)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "404")
+}
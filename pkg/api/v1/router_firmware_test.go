@@ -4,12 +4,17 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/types"
 
 	"go.hollow.sh/serverservice/internal/dbtools"
+	"go.hollow.sh/serverservice/internal/models"
 	serverservice "go.hollow.sh/serverservice/pkg/api/v1"
 )
 
@@ -129,6 +134,122 @@ func TestIntegrationFirmwareList(t *testing.T) {
 	}
 }
 
+func TestIntegrationFirmwareListVersions(t *testing.T) {
+	s := serverTest(t)
+	s.Client.SetToken(validToken(adminScopes))
+
+	var created []string
+
+	for _, version := range []string{"2.9.3", "2.14.1", "2.14.1-rc.1"} {
+		id, _, err := s.Client.CreateServerComponentFirmware(context.TODO(), serverservice.ComponentFirmwareVersion{
+			UUID:      uuid.New(),
+			Vendor:    "dell",
+			Model:     []string{"R6515"},
+			Filename:  "BIOS_" + version + ".EXE",
+			Version:   version,
+			Component: "bios",
+			Checksum:  "foobar",
+		})
+		require.NoError(t, err)
+
+		created = append(created, id.String())
+	}
+
+	var testCases = []struct {
+		testName      string
+		params        *serverservice.ComponentFirmwareVersionListParams
+		expectedUUIDs []string
+	}{
+		{
+			"version greater than",
+			&serverservice.ComponentFirmwareVersionListParams{
+				Vendor:    "dell",
+				VersionGT: "2.9.3",
+			},
+			[]string{created[1], created[2]},
+		},
+		{
+			"version less than",
+			&serverservice.ComponentFirmwareVersionListParams{
+				Vendor:    "dell",
+				VersionLT: "2.14.1",
+			},
+			[]string{created[0], created[2]},
+		},
+		{
+			"version between",
+			&serverservice.ComponentFirmwareVersionListParams{
+				Vendor:    "dell",
+				VersionGT: "2.9.3",
+				VersionLT: "2.14.1",
+			},
+			[]string{created[2]},
+		},
+		{
+			"latest by vendor",
+			&serverservice.ComponentFirmwareVersionListParams{
+				Vendor: "dell",
+				Latest: true,
+			},
+			[]string{created[1]},
+		},
+		{
+			"latest below a version",
+			&serverservice.ComponentFirmwareVersionListParams{
+				Vendor:    "dell",
+				VersionLT: "2.14.1",
+				Latest:    true,
+			},
+			[]string{created[2]},
+		},
+		{
+			"latest with a filename",
+			&serverservice.ComponentFirmwareVersionListParams{
+				Vendor:   "dell",
+				Filename: "BIOS_2.9.3.EXE",
+				Latest:   true,
+			},
+			[]string{created[0]},
+		},
+		{
+			"latest matching a query",
+			&serverservice.ComponentFirmwareVersionListParams{
+				Vendor: "dell",
+				Query:  "rc",
+				Latest: true,
+			},
+			[]string{created[2]},
+		},
+		{
+			"latest by model",
+			&serverservice.ComponentFirmwareVersionListParams{
+				Model:  []string{"R640"},
+				Latest: true,
+			},
+			[]string{
+				dbtools.FixtureDellR640BMC.ID,
+				dbtools.FixtureDellR640BIOS.ID,
+				dbtools.FixtureDellR640CPLD.ID,
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.testName, func(t *testing.T) {
+			r, _, err := s.Client.ListServerComponentFirmware(context.TODO(), tt.params)
+			require.NoError(t, err)
+
+			var actual []string
+
+			for _, fw := range r {
+				actual = append(actual, fw.UUID.String())
+			}
+
+			assert.ElementsMatch(t, tt.expectedUUIDs, actual)
+		})
+	}
+}
+
 func TestIntegrationFirmwareGet(t *testing.T) {
 	s := serverTest(t)
 
@@ -271,10 +392,106 @@ func TestIntegrationServerComponentFirmwareDelete(t *testing.T) {
 
 	realClientTests(t, func(ctx context.Context, authToken string, respCode int, expectError bool) error {
 		s.Client.SetToken(authToken)
-		_, err := s.Client.DeleteServerComponentFirmware(ctx, serverservice.ComponentFirmwareVersion{UUID: uuid.MustParse(dbtools.FixtureDellR640BMC.ID)})
+		_, err := s.Client.DeleteServerComponentFirmware(ctx, serverservice.ComponentFirmwareVersion{UUID: uuid.MustParse(dbtools.FixtureDellR640CPLD.ID)})
+
+		return err
+	})
+}
+
+func TestIntegrationServerComponentFirmwareUsage(t *testing.T) {
+	s := serverTest(t)
+
+	ctx := context.TODO()
+	db := dbtools.DatabaseTest(t)
+
+	componentType := &models.ServerComponentType{Name: "bmc", Slug: "bmc"}
+	require.NoError(t, componentType.Insert(ctx, db, boil.Infer()))
+
+	reports := []struct {
+		server    *models.Server
+		model     string
+		installed string
+	}{
+		// versions are compared like firmware versions, the v prefix doesn't matter
+		{dbtools.FixtureNemo, "R640", "v5.10.00.00"},
+		{dbtools.FixtureDory, "r640", "4.40.00.00"},
+		// the firmware isn't for the model of the server
+		{dbtools.FixtureMarlin, "R6515", "5.10.00.00"},
+	}
+
+	for _, report := range reports {
+		server, installed := report.server, report.installed
+
+		require.NoError(t, server.AddAttributes(ctx, db, true, &models.Attribute{
+			Namespace: serverservice.ServerVendorAttributesNamespace,
+			Data:      types.JSON(`{"vendor":"dell","model":"` + report.model + `"}`),
+		}))
+
+		component := &models.ServerComponent{
+			ServerComponentTypeID: componentType.ID,
+			Name:                  null.StringFrom("iDRAC"),
+			Vendor:                null.StringFrom("dell"),
+			Serial:                null.StringFrom("bmc-" + server.Name.String),
+		}
+		require.NoError(t, server.AddServerComponents(ctx, db, true, component))
+
+		// only the latest report counts, every component had the firmware installed before
+		require.NoError(t, component.AddVersionedAttributes(ctx, db, true, &models.VersionedAttribute{
+			Namespace: serverservice.FirmwareInstalledNamespace,
+			Data:      types.JSON(`{"firmware":{"installed":"5.10.00.00"}}`),
+			CreatedAt: null.TimeFrom(time.Now().Add(-time.Hour)),
+		}))
+
+		require.NoError(t, component.AddVersionedAttributes(ctx, db, true, &models.VersionedAttribute{
+			Namespace: serverservice.FirmwareInstalledNamespace,
+			Data:      types.JSON(`{"firmware":{"installed":"` + installed + `"}}`),
+		}))
+	}
+
+	bmc := serverservice.ComponentFirmwareVersion{UUID: uuid.MustParse(dbtools.FixtureDellR640BMC.ID)}
+
+	realClientTests(t, func(ctx context.Context, authToken string, respCode int, expectError bool) error {
+		s.Client.SetToken(authToken)
+
+		usage, _, err := s.Client.GetServerComponentFirmwareUsage(ctx, bmc.UUID, "")
+		if !expectError {
+			require.NoError(t, err)
+			assert.Equal(t, bmc.UUID, usage.FirmwareUUID)
+			require.Len(t, usage.FirmwareSets, 1)
+			assert.Equal(t, dbtools.FixtureFirmwareSetR640.ID, usage.FirmwareSets[0].UUID.String())
+			require.Len(t, usage.Servers, 1)
+			assert.Equal(t, dbtools.FixtureNemo.ID, usage.Servers[0].ServerUUID.String())
+			assert.Equal(t, "Nemo", usage.Servers[0].ServerName)
+			assert.Equal(t, "bmc-Nemo", usage.Servers[0].ComponentSerial)
+		}
 
 		return err
 	})
+
+	s.Client.SetToken(validToken(adminScopes))
+
+	// firmware in use isn't deleted without force
+	_, err := s.Client.DeleteServerComponentFirmware(ctx, bmc)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "409")
+
+	_, _, err = s.Client.GetServerComponentFirmware(ctx, bmc.UUID)
+	require.NoError(t, err)
+
+	_, err = s.Client.ForceDeleteServerComponentFirmware(ctx, bmc)
+	require.NoError(t, err)
+
+	_, _, err = s.Client.GetServerComponentFirmware(ctx, bmc.UUID)
+	require.Error(t, err)
+
+	// the firmware set has a revision without the deleted firmware
+	revisions, _, err := s.Client.ListServerComponentFirmwareSetRevisions(ctx, uuid.MustParse(dbtools.FixtureFirmwareSetR640.ID))
+	require.NoError(t, err)
+	require.NotEmpty(t, revisions)
+
+	for _, f := range revisions[0].Document.Firmware {
+		assert.NotEqual(t, dbtools.FixtureDellR640BMC.Filename, f.Filename)
+	}
 }
 
 func TestIntegrationServerComponentFirmwareUpdate(t *testing.T) {
@@ -306,4 +523,193 @@ func TestIntegrationServerComponentFirmwareUpdate(t *testing.T) {
 
 		return err
 	})
+
+	s.Client.SetToken(validToken(adminScopes))
+
+	// the firmware set including the firmware has a revision with the new filename
+	revisions, _, err := s.Client.ListServerComponentFirmwareSetRevisions(context.TODO(), uuid.MustParse(dbtools.FixtureFirmwareSetR640.ID))
+	require.NoError(t, err)
+	require.NotEmpty(t, revisions)
+
+	filenames := []string{}
+	for _, f := range revisions[0].Document.Firmware {
+		filenames = append(filenames, f.Filename)
+	}
+
+	assert.Contains(t, filenames, "foobarino")
+}
+
+func TestIntegrationServerComponentFirmwareStatus(t *testing.T) {
+	s := serverTest(t)
+
+	fwUUID := uuid.MustParse(dbtools.FixtureDellR640BMC.ID)
+
+	realClientTests(t, func(ctx context.Context, authToken string, respCode int, expectError bool) error {
+		s.Client.SetToken(authToken)
+
+		_, err := s.Client.SetServerComponentFirmwareStatus(ctx, fwUUID, serverservice.ComponentFirmwareStatus{
+			Status: serverservice.FirmwareStatusRecalled,
+			Reason: "bricks the BMC",
+		})
+		if !expectError {
+			require.NoError(t, err)
+		}
+
+		return err
+	})
+
+	s.Client.SetToken(validToken(adminScopes))
+
+	fw, _, err := s.Client.GetServerComponentFirmware(context.TODO(), fwUUID)
+	require.NoError(t, err)
+	assert.Equal(t, serverservice.FirmwareStatusRecalled, fw.Status)
+	assert.Equal(t, "bricks the BMC", fw.StatusReason)
+
+	// recalled firmware is excluded unless it's asked for
+	r, _, err := s.Client.ListServerComponentFirmware(context.TODO(), &serverservice.ComponentFirmwareVersionListParams{Vendor: "Dell"})
+	require.NoError(t, err)
+	assert.Len(t, r, 4)
+
+	r, _, err = s.Client.ListServerComponentFirmware(context.TODO(), &serverservice.ComponentFirmwareVersionListParams{
+		Status: []string{serverservice.FirmwareStatusRecalled},
+	})
+	require.NoError(t, err)
+	require.Len(t, r, 1)
+	assert.Equal(t, fwUUID, r[0].UUID)
+
+	fws, _, err := s.Client.GetServerComponentFirmwareSet(context.TODO(), uuid.MustParse(dbtools.FixtureFirmwareSetR640.ID))
+	require.NoError(t, err)
+	assert.True(t, fws.Recalled)
+	assert.False(t, fws.Deprecated)
+
+	_, err = s.Client.SetServerComponentFirmwareStatus(context.TODO(), fwUUID, serverservice.ComponentFirmwareStatus{
+		Status: serverservice.FirmwareStatusDeprecated,
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "a reason is required for deprecated firmware")
+
+	_, err = s.Client.SetServerComponentFirmwareStatus(context.TODO(), fwUUID, serverservice.ComponentFirmwareStatus{
+		Status: serverservice.FirmwareStatusActive,
+	})
+	require.NoError(t, err)
+
+	fw, _, err = s.Client.GetServerComponentFirmware(context.TODO(), fwUUID)
+	require.NoError(t, err)
+	assert.Equal(t, serverservice.FirmwareStatusActive, fw.Status)
+	assert.Empty(t, fw.StatusReason)
+}
+
+func TestIntegrationFirmwareListVerification(t *testing.T) {
+	s := serverTest(t)
+	s.Client.SetToken(validToken(adminScopes))
+
+	ctx := context.TODO()
+	db := dbtools.DatabaseTest(t)
+
+	_, err := models.ComponentFirmwareVersions(
+		models.ComponentFirmwareVersionWhere.ID.EQ(dbtools.FixtureDellR640BIOS.ID),
+	).UpdateAll(ctx, db, models.M{"verification_status": serverservice.FirmwareMismatch, "verified_at": time.Now()})
+	require.NoError(t, err)
+
+	mismatched, _, err := s.Client.ListServerComponentFirmware(ctx, &serverservice.ComponentFirmwareVersionListParams{
+		VerificationStatus: []string{serverservice.FirmwareMismatch},
+	})
+	require.NoError(t, err)
+	require.Len(t, mismatched, 1)
+	assert.Equal(t, dbtools.FixtureDellR640BIOS.ID, mismatched[0].UUID.String())
+	assert.Equal(t, serverservice.FirmwareMismatch, mismatched[0].VerificationStatus)
+	assert.NotNil(t, mismatched[0].VerifiedAt)
+
+	unverified, _, err := s.Client.ListServerComponentFirmware(ctx, &serverservice.ComponentFirmwareVersionListParams{
+		VerificationStatus: []string{serverservice.FirmwareUnverified},
+	})
+	require.NoError(t, err)
+	assert.Len(t, unverified, 5)
+
+	// a new checksum clears the verification
+	fw := mismatched[0]
+	fw.Checksum = "sha256:0123"
+
+	_, err = s.Client.UpdateServerComponentFirmware(ctx, fw.UUID, fw)
+	require.NoError(t, err)
+
+	updated, _, err := s.Client.GetServerComponentFirmware(ctx, fw.UUID)
+	require.NoError(t, err)
+	assert.Empty(t, updated.VerificationStatus)
+	assert.Nil(t, updated.VerifiedAt)
+}
+
+func TestIntegrationFirmwareListFilters(t *testing.T) {
+	s := serverTest(t)
+
+	realClientTests(t, func(ctx context.Context, authToken string, respCode int, expectError bool) error {
+		s.Client.SetToken(authToken)
+
+		r, _, err := s.Client.ListServerComponentFirmware(ctx, &serverservice.ComponentFirmwareVersionListParams{Component: "bios"})
+		if !expectError {
+			require.NoError(t, err)
+			assert.Len(t, r, 2)
+		}
+
+		return err
+	})
+
+	s.Client.SetToken(validToken(adminScopes))
+
+	testCases := []struct {
+		testName string
+		params   serverservice.ComponentFirmwareVersionListParams
+		expected []string
+	}{
+		{
+			"model prefix ignoring case",
+			serverservice.ComponentFirmwareVersionListParams{ModelLike: "r65"},
+			[]string{dbtools.FixtureDellR6515BIOS.ID, dbtools.FixtureDellR6515BMC.ID},
+		},
+		{
+			"model pattern",
+			serverservice.ComponentFirmwareVersionListParams{ModelLike: "%dph%", Component: "bmc"},
+			[]string{dbtools.FixtureSuperMicroX11DPHTBMC.ID},
+		},
+		{
+			"free text in the version",
+			serverservice.ComponentFirmwareVersionListParams{Query: "5.10.00"},
+			[]string{dbtools.FixtureDellR640BMC.ID},
+		},
+		{
+			"free text in a model",
+			serverservice.ComponentFirmwareVersionListParams{Query: "x11dph"},
+			[]string{dbtools.FixtureSuperMicroX11DPHTBMC.ID},
+		},
+		{
+			"updated since",
+			serverservice.ComponentFirmwareVersionListParams{UpdatedSince: time.Now().Add(time.Hour)},
+			[]string{},
+		},
+		{
+			"firmware set attributes",
+			serverservice.ComponentFirmwareVersionListParams{
+				FirmwareSetAttributeListParams: []serverservice.AttributeListParams{
+					{Namespace: "sh.hollow.firmware_set.labels", Keys: []string{"model"}, Operator: serverservice.OperatorEqual, Value: "r640"},
+				},
+			},
+			[]string{dbtools.FixtureDellR640BIOS.ID, dbtools.FixtureDellR640BMC.ID},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			params := tc.params
+
+			r, _, err := s.Client.ListServerComponentFirmware(context.TODO(), &params)
+			require.NoError(t, err)
+
+			ids := []string{}
+			for _, f := range r {
+				ids = append(ids, f.UUID.String())
+			}
+
+			assert.ElementsMatch(t, tc.expected, ids)
+		})
+	}
 }
@@ -0,0 +1,130 @@
+package serverservice
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/types"
+
+	"go.hollow.sh/serverservice/internal/fwversion"
+	"go.hollow.sh/serverservice/internal/models"
+)
+
+// serverComponentFirmwareUsage lists the firmware sets that include the firmware and the
+// server components that report it as installed
+func (r *Router) serverComponentFirmwareUsage(c *gin.Context) {
+	dbFirmware, err := r.loadComponentFirmwareVersionFromParams(c)
+	if err != nil {
+		return
+	}
+
+	usage, err := firmwareUsage(c.Request.Context(), r.DB, dbFirmware, c.DefaultQuery("namespace", FirmwareInstalledNamespace))
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	itemResponse(c, usage)
+}
+
+// firmwareUsage returns the firmware sets that include the firmware and the components of
+// servers whose latest versioned attributes in the namespace report the firmware version.
+// A component matches on its component type, vendor and the hardware model in the server
+// vendor attributes, like in a firmware plan. Versions are compared with fwversion, so
+// v2.6.6 is the same as 2.6.6.
+func firmwareUsage(ctx context.Context, exec boil.ContextExecutor, dbFirmware *models.ComponentFirmwareVersion, namespace string) (*ComponentFirmwareUsage, error) {
+	sets, err := models.ComponentFirmwareSets(
+		qm.InnerJoin("component_firmware_set_map m ON m.firmware_set_id = component_firmware_set.id"),
+		qm.Where("m.firmware_id = ?", dbFirmware.ID),
+		qm.OrderBy("component_firmware_set.name"),
+	).All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(dbFirmware.Model) == 0 {
+		return newComponentFirmwareUsage(dbFirmware.ID, sets, nil)
+	}
+
+	hwModels := make([]string, 0, len(dbFirmware.Model))
+	for _, m := range dbFirmware.Model {
+		hwModels = append(hwModels, strings.ToLower(m))
+	}
+
+	// the latest report of each component, the DISTINCT ON keeps the first row of each
+	// component in the order, which is its newest versioned attributes
+	var candidates []*installedComponent
+
+	err = models.ServerComponents(
+		qm.Select(
+			"DISTINCT ON (server_components.server_id, server_components.serial, server_components.id) server_components.*",
+			"s.name AS server_name",
+			"va.data->'firmware'->>'installed' AS installed_version",
+		),
+		qm.InnerJoin("server_component_types t ON t.id = server_components.server_component_type_id"),
+		qm.InnerJoin("servers s ON s.id = server_components.server_id"),
+		qm.InnerJoin("attributes sa ON sa.server_id = s.id AND sa.namespace = ?", ServerVendorAttributesNamespace),
+		qm.InnerJoin("versioned_attributes va ON va.server_component_id = server_components.id AND va.namespace = ?", namespace),
+		qm.Where("lower(t.slug) = lower(?)", dbFirmware.Component),
+		qm.Where("(server_components.vendor IS NULL OR server_components.vendor = '' OR lower(server_components.vendor) = lower(?))", dbFirmware.Vendor),
+		qm.Where("s.deleted_at IS NULL"),
+		qm.Where("lower(trim(sa.data->>'model')) = ANY(?)", types.StringArray(hwModels)),
+		qm.OrderBy("server_components.server_id, server_components.serial, server_components.id, va.created_at DESC"),
+	).Bind(ctx, exec, &candidates)
+	if err != nil {
+		return nil, err
+	}
+
+	components := models.ServerComponentSlice{}
+
+	for _, ic := range candidates {
+		version := strings.TrimSpace(ic.InstalledVersion.String)
+		if version == "" || fwversion.Compare(version, dbFirmware.Version) != 0 {
+			continue
+		}
+
+		dbC := ic.ServerComponent
+		dbC.R = dbC.R.NewStruct()
+		dbC.R.Server = &models.Server{ID: dbC.ServerID, Name: ic.ServerName}
+
+		components = append(components, &dbC)
+	}
+
+	return newComponentFirmwareUsage(dbFirmware.ID, sets, components)
+}
+
+// installedComponent is a server component along with the name of its server and the
+// firmware version installed in its latest versioned attributes
+type installedComponent struct {
+	models.ServerComponent `boil:",bind"`
+	ServerName             null.String `boil:"server_name"`
+	InstalledVersion       null.String `boil:"installed_version"`
+}
+
+// serverComponentFirmwareDeleteTx deletes the firmware and records a revision of the
+// firmware sets it's removed from
+func (r *Router) serverComponentFirmwareDeleteTx(ctx context.Context, dbFirmware *models.ComponentFirmwareVersion, firmwareSets []ComponentFirmwareUsageSet) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	// nolint:errcheck // rollback is a no-op once the transaction is committed
+	defer tx.Rollback()
+
+	if _, err := dbFirmware.Delete(ctx, tx); err != nil {
+		return err
+	}
+
+	for _, s := range firmwareSets {
+		if err := r.recordFirmwareSetRevision(ctx, tx, s.UUID.String()); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
@@ -40,7 +40,8 @@ func serverTest(t *testing.T) *integrationServer {
 			JWKSURI:    jwksURI,
 			RolesClaim: "userPerms",
 		},
-		SecretsKeeper: dbtools.TestSecretKeeper(t),
+		Keyring:              dbtools.TestKeyring(t),
+		FirmwareSetRevisions: true,
 	}
 	s := hs.NewServer()
 
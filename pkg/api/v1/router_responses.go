@@ -65,7 +65,12 @@ func badRequestResponse(c *gin.Context, message string, err error) {
 }
 
 func createdResponse(c *gin.Context, slug string) {
-	uri := fmt.Sprintf("%s/%s", uriWithoutQueryParams(c), slug)
+	createdResponseAt(c, slug, fmt.Sprintf("%s/%s", uriWithoutQueryParams(c), slug))
+}
+
+// createdResponseAt writes a 201 response for a resource created at a location other
+// than below the request URI
+func createdResponseAt(c *gin.Context, slug, uri string) {
 	r := &ServerResponse{
 		Message: "resource created",
 		Slug:    slug,
@@ -94,6 +99,11 @@ func updatedResponse(c *gin.Context, slug string) {
 	c.JSON(http.StatusOK, r)
 }
 
+// conflictResponse writes a 409 response with the given message
+func conflictResponse(c *gin.Context, message string) {
+	c.JSON(http.StatusConflict, &ServerResponse{Message: message})
+}
+
 func dbErrorResponse(c *gin.Context, err error) {
 	if strings.Contains(err.Error(), "duplicate key value violates unique constraint") {
 		badRequestResponse(c, "", err)
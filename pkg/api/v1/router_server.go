@@ -17,23 +17,11 @@ import (
 func (r *Router) serverList(c *gin.Context) {
 	pager := parsePagination(c)
 
-	var params ServerListParams
-	if err := c.ShouldBindQuery(&params); err != nil {
-		badRequestResponse(c, "invalid filter", err)
+	params, ok := bindServerListParams(c)
+	if !ok {
 		return
 	}
 
-	params.AttributeListParams = parseQueryAttributesListParams(c, "attr")
-	params.VersionedAttributeListParams = parseQueryAttributesListParams(c, "ver_attr")
-
-	sclp, err := parseQueryServerComponentsListParams(c)
-	if err != nil {
-		badRequestResponse(c, "invalid server component list params", err)
-		return
-	}
-
-	params.ComponentListParams = sclp
-
 	params.PaginationParams = &pager
 
 	dbSRV, count, err := r.getServers(c, params)
@@ -0,0 +1,70 @@
+package serverservice
+
+import (
+	"database/sql"
+
+	"github.com/gin-gonic/gin"
+)
+
+// serverAggregate counts the servers matching the same filters as serverList grouped
+// by the fields in the group_by query param
+func (r *Router) serverAggregate(c *gin.Context) {
+	groupBy, err := parseGroupBy(c.QueryArray("group_by"))
+	if err != nil {
+		badRequestResponse(c, "invalid group by", err)
+		return
+	}
+
+	params, ok := bindServerListParams(c)
+	if !ok {
+		return
+	}
+
+	stmt, args, err := aggregateSQL(params, groupBy)
+	if err != nil {
+		badRequestResponse(c, "invalid group by", err)
+		return
+	}
+
+	rows, err := r.DB.QueryContext(c.Request.Context(), stmt, args...)
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	defer rows.Close()
+
+	agg := ServerAggregate{GroupBy: groupBy, Groups: []ServerAggregateGroup{}}
+
+	for rows.Next() {
+		values := make([]sql.NullString, len(groupBy))
+		group := ServerAggregateGroup{Values: make(map[string]*string, len(groupBy))}
+
+		dest := make([]interface{}, 0, len(groupBy)+1)
+		for i := range values {
+			dest = append(dest, &values[i])
+		}
+
+		if err := rows.Scan(append(dest, &group.Count)...); err != nil {
+			dbErrorResponse(c, err)
+			return
+		}
+
+		for i, field := range groupBy {
+			if values[i].Valid {
+				group.Values[field] = &values[i].String
+			} else {
+				group.Values[field] = nil
+			}
+		}
+
+		agg.Groups = append(agg.Groups, group)
+	}
+
+	if err := rows.Err(); err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	itemResponse(c, agg)
+}
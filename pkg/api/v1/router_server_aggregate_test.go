@@ -0,0 +1,137 @@
+package serverservice_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.hollow.sh/serverservice/internal/dbtools"
+	serverservice "go.hollow.sh/serverservice/pkg/api/v1"
+)
+
+func TestIntegrationServerAggregate(t *testing.T) {
+	s := serverTest(t)
+
+	realClientTests(t, func(ctx context.Context, authToken string, respCode int, expectError bool) error {
+		s.Client.SetToken(authToken)
+
+		agg, _, err := s.Client.Aggregate(ctx, &serverservice.ServerAggregateParams{GroupBy: []string{"facility_code"}})
+		if !expectError {
+			require.NoError(t, err)
+			assert.Equal(t, []string{"facility_code"}, agg.GroupBy)
+			assert.Equal(t, []map[string]string{
+				{"facility_code": "Ocean", "count": "2"},
+				{"facility_code": "Sydney", "count": "1"},
+			}, aggregateGroups(agg))
+		}
+
+		return err
+	})
+
+	s.Client.SetToken(validToken(adminScopes))
+
+	var testCases = []struct {
+		testName       string
+		params         *serverservice.ServerAggregateParams
+		expectedGroups []map[string]string
+		errorMsg       string
+	}{
+		{
+			"group by an attribute",
+			&serverservice.ServerAggregateParams{GroupBy: []string{"attr:" + dbtools.FixtureNamespaceOtherdata + ".type"}},
+			[]map[string]string{
+				{"attr:hollow.other_data.type": "clown", "count": "2"},
+				{"attr:hollow.other_data.type": "blue-tang", "count": "1"},
+			},
+			"",
+		},
+		{
+			"group by facility and attribute with a filter",
+			&serverservice.ServerAggregateParams{
+				GroupBy: []string{"facility_code", "attr:" + dbtools.FixtureNamespaceMetadata + ".location"},
+				Filter:  &serverservice.ServerListParams{FacilityCode: "Ocean"},
+			},
+			[]map[string]string{
+				{"facility_code": "Ocean", "attr:hollow.metadata.location": "East Australian Current", "count": "2"},
+			},
+			"",
+		},
+		{
+			"group by component model including deleted servers",
+			&serverservice.ServerAggregateParams{
+				GroupBy: []string{"facility_code", "component.model"},
+				Filter: &serverservice.ServerListParams{
+					IncludeDeleted: true,
+					AttributeListParams: []serverservice.AttributeListParams{
+						{
+							Namespace: dbtools.FixtureNamespaceMetadata,
+							Keys:      []string{"age"},
+							Operator:  serverservice.OperatorLessThan,
+							Value:     "7",
+						},
+					},
+				},
+			},
+			[]map[string]string{
+				{"facility_code": "Aquarium", "component.model": "Belly", "count": "1"},
+				{"facility_code": "Sydney", "component.model": "A Lucky Fin", "count": "1"},
+				{"facility_code": "Sydney", "component.model": "Normal Fin", "count": "1"},
+			},
+			"",
+		},
+		{
+			"unknown field",
+			&serverservice.ServerAggregateParams{GroupBy: []string{"rack"}},
+			nil,
+			"unknown field",
+		},
+		{
+			"attribute without a key",
+			&serverservice.ServerAggregateParams{GroupBy: []string{"attr:hollow"}},
+			nil,
+			"must be attr:<namespace>.<key>",
+		},
+		{
+			"no fields",
+			&serverservice.ServerAggregateParams{},
+			nil,
+			"at least one field is required",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.testName, func(t *testing.T) {
+			agg, _, err := s.Client.Aggregate(context.TODO(), tt.params)
+			if tt.errorMsg != "" {
+				assert.ErrorContains(t, err, tt.errorMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedGroups, aggregateGroups(agg))
+		})
+	}
+}
+
+// aggregateGroups flattens the groups of an aggregate for comparison
+func aggregateGroups(agg *serverservice.ServerAggregate) []map[string]string {
+	groups := []map[string]string{}
+
+	for _, g := range agg.Groups {
+		m := map[string]string{"count": fmt.Sprint(g.Count)}
+
+		for k, v := range g.Values {
+			m[k] = "<nil>"
+			if v != nil {
+				m[k] = *v
+			}
+		}
+
+		groups = append(groups, m)
+	}
+
+	return groups
+}
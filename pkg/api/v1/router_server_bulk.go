@@ -0,0 +1,599 @@
+package serverservice
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/types"
+
+	"go.hollow.sh/serverservice/internal/dbtools"
+	"go.hollow.sh/serverservice/internal/models"
+)
+
+// bulkRecordTimeout is how long each record of a bulk import has to be read, and
+// to be written once they are all read
+const bulkRecordTimeout = 30 * time.Second
+
+// serverBulkImport upserts the servers described by the NDJSON records in the request
+// body. Every record is validated before anything is written. Records of deleted servers
+// fail unless restore is set.
+func (r *Router) serverBulkImport(c *gin.Context) {
+	mode := ServerBulkMode(c.DefaultQuery("mode", string(ServerBulkAtomic)))
+	if mode != ServerBulkAtomic && mode != ServerBulkPerRecord {
+		badRequestResponse(c, "invalid bulk mode", fmt.Errorf("%w: unknown mode %q", errBulkRequest, mode))
+		return
+	}
+
+	restore, _ := strconv.ParseBool(c.Query("restore"))
+
+	// the read deadline is extended as the body arrives, so only its size is limited
+	body := &deadlineReader{
+		Reader: http.MaxBytesReader(c.Writer, c.Request.Body, maxBulkBodySize),
+		extend: func() {
+			if r.ExtendReadDeadline != nil {
+				r.ExtendReadDeadline(c.Request, bulkRecordTimeout)
+			}
+		},
+	}
+
+	records, err := readBulkRecords(body)
+	if err != nil {
+		if errors.Is(err, errBulkTooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, &ServerResponse{Message: "bulk request too large", Error: err.Error()})
+			return
+		}
+
+		badRequestResponse(c, "invalid bulk records", err)
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	known, err := r.loadBulkTypes(ctx)
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	seen := map[uuid.UUID]int{}
+	withCredentials := false
+
+	for _, rec := range records {
+		if rec.err != nil {
+			continue
+		}
+
+		if rec.err = known.validate(&rec.record); rec.err != nil {
+			continue
+		}
+
+		if line, ok := seen[rec.record.UUID]; ok {
+			rec.err = fmt.Errorf("%w: server %s is also on line %d", errBulkRecord, rec.record.UUID, line)
+			continue
+		}
+
+		seen[rec.record.UUID] = rec.line
+
+		if len(rec.record.Credentials) != 0 {
+			withCredentials = true
+		}
+	}
+
+	// credentials need the same scope as setting them one at a time, the middleware
+	// writes the response when it's missing
+	if withCredentials && r.AuthMW != nil {
+		if r.authMiddleware().RequiredScopes([]string{"write:server:credentials"})(c); c.IsAborted() {
+			return
+		}
+	}
+
+	existing, err := r.loadBulkServers(ctx, records)
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	for _, rec := range records {
+		if dbS, ok := existing[rec.record.UUID.String()]; ok && rec.err == nil && dbS.DeletedAt.Valid && !restore {
+			rec.err = fmt.Errorf("%w: server %s is deleted, set restore=true to restore it", errBulkRecord, rec.record.UUID)
+		}
+	}
+
+	if r.AuthMW != nil {
+		if !r.requireBulkScopes(c, records, existing) {
+			return
+		}
+	}
+
+	result := &ServerBulkResult{Mode: mode, Records: make([]ServerBulkRecordResult, 0, len(records))}
+
+	if mode == ServerBulkAtomic {
+		r.serverBulkImportAtomic(c, records, result, restore)
+		return
+	}
+
+	for _, rec := range records {
+		created := false
+
+		if rec.err == nil {
+			r.extendBulkWriteDeadline(c)
+
+			created, rec.err = r.serverBulkWriteTx(c, &rec.record, restore)
+			if rec.err == nil && created {
+				r.publishBulkCreated(ctx, &rec.record)
+			}
+		}
+
+		result.add(rec, created)
+	}
+
+	itemResponse(c, result)
+}
+
+// loadBulkServers returns the servers of the valid records that already exist, deleted
+// or not, by id
+func (r *Router) loadBulkServers(ctx context.Context, records []*bulkRecord) (map[string]*models.Server, error) {
+	ids := []string{}
+
+	for _, rec := range records {
+		if rec.err == nil {
+			ids = append(ids, rec.record.UUID.String())
+		}
+	}
+
+	existing := map[string]*models.Server{}
+
+	if len(ids) == 0 {
+		return existing, nil
+	}
+
+	dbServers, err := models.Servers(
+		models.ServerWhere.ID.IN(ids),
+		qm.Select(models.ServerColumns.ID, models.ServerColumns.DeletedAt),
+		qm.WithDeleted(),
+	).All(ctx, r.DB)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dbS := range dbServers {
+		existing[dbS.ID] = dbS
+	}
+
+	return existing, nil
+}
+
+// requireBulkScopes checks the caller can write the records the same as writing them one
+// at a time. Existing servers need the update scopes, and the relations a record carries need
+// their own scope: create for new servers and versioned attributes, update for the components
+// and attributes of existing servers. It returns false when the response was written because
+// a scope is missing.
+func (r *Router) requireBulkScopes(c *gin.Context, records []*bulkRecord, existing map[string]*models.Server) bool {
+	scopes := [][]string{}
+	required := map[string]bool{}
+
+	require := func(s []string) {
+		if key := s[len(s)-1]; !required[key] {
+			required[key] = true
+
+			scopes = append(scopes, s)
+		}
+	}
+
+	for _, rec := range records {
+		if rec.err != nil {
+			continue
+		}
+
+		// the route already requires the create scopes of the server
+		write := createScopes
+		if _, ok := existing[rec.record.UUID.String()]; ok {
+			write = updateScopes
+
+			require(updateScopes("server"))
+		}
+
+		if len(rec.record.Components) != 0 {
+			require(write("server:component"))
+		}
+
+		if len(rec.record.Attributes) != 0 {
+			require(write("server:attributes"))
+		}
+
+		// versioned attributes are only ever added
+		if len(rec.record.VersionedAttributes) != 0 {
+			require(createScopes("server:versioned-attributes"))
+		}
+	}
+
+	amw := r.authMiddleware()
+
+	for _, s := range scopes {
+		if amw.RequiredScopes(s)(c); c.IsAborted() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// serverBulkImportAtomic writes all the records in one transaction when they are all valid
+func (r *Router) serverBulkImportAtomic(c *gin.Context, records []*bulkRecord, result *ServerBulkResult, restore bool) {
+	ctx := c.Request.Context()
+
+	for _, rec := range records {
+		if rec.err != nil {
+			bulkFailedResponse(c, http.StatusBadRequest, "invalid bulk records", records, rec, result)
+			return
+		}
+	}
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	// nolint:errcheck // rollback is a no-op once the transaction is committed
+	defer tx.Rollback()
+
+	created := make([]bool, len(records))
+
+	for i, rec := range records {
+		r.extendBulkWriteDeadline(c)
+
+		if created[i], rec.err = r.serverBulkWrite(c, tx, &rec.record, restore); rec.err != nil {
+			bulkFailedResponse(c, http.StatusInternalServerError, "failed writing bulk records", records, rec, result)
+			return
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	for i, rec := range records {
+		if created[i] {
+			r.publishBulkCreated(ctx, &rec.record)
+		}
+
+		result.add(rec, created[i])
+	}
+
+	itemResponse(c, result)
+}
+
+// bulkFailedResponse reports the failed record of an atomic import, the other records
+// are reported as skipped
+func bulkFailedResponse(c *gin.Context, status int, message string, records []*bulkRecord, failed *bulkRecord, result *ServerBulkResult) {
+	for _, rec := range records {
+		if rec.err == nil {
+			result.Records = append(result.Records, ServerBulkRecordResult{Line: rec.line, UUID: rec.record.UUID, Status: ServerBulkSkipped})
+			continue
+		}
+
+		result.add(rec, false)
+	}
+
+	c.JSON(status, &ServerResponse{
+		Message: message,
+		Error:   fmt.Sprintf("line %d: %s", failed.line, failed.err),
+		Record:  result,
+	})
+}
+
+func (res *ServerBulkResult) add(rec *bulkRecord, created bool) {
+	result := ServerBulkRecordResult{Line: rec.line, UUID: rec.record.UUID}
+
+	switch {
+	case rec.err != nil:
+		result.Status = ServerBulkFailed
+		result.Error = rec.err.Error()
+		res.Failed++
+	case created:
+		result.Status = ServerBulkCreated
+		res.Created++
+	default:
+		result.Status = ServerBulkUpdated
+		res.Updated++
+	}
+
+	res.Records = append(res.Records, result)
+}
+
+func (r *Router) loadBulkTypes(ctx context.Context) (*bulkTypes, error) {
+	t := &bulkTypes{
+		componentTypeIDs:   map[string]bool{},
+		componentTypeSlugs: map[string]string{},
+		credentialTypes:    map[string]string{},
+	}
+
+	componentTypes, err := models.ServerComponentTypes().All(ctx, r.DB)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ct := range componentTypes {
+		t.componentTypeIDs[ct.ID] = true
+		t.componentTypeSlugs[ct.Slug] = ct.ID
+	}
+
+	credentialTypes, err := models.ServerCredentialTypes().All(ctx, r.DB)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ct := range credentialTypes {
+		t.credentialTypes[ct.Slug] = ct.ID
+	}
+
+	return t, nil
+}
+
+func (r *Router) publishBulkCreated(ctx context.Context, rec *ServerBulkRecord) {
+	r.publishCreateServerMessage(ctx, &models.Server{
+		ID:           rec.UUID.String(),
+		Name:         null.StringFrom(rec.Name),
+		FacilityCode: null.StringFrom(rec.FacilityCode),
+	})
+}
+
+// extendBulkWriteDeadline gives the next record of a bulk import bulkRecordTimeout to be
+// written before the response times out
+func (r *Router) extendBulkWriteDeadline(c *gin.Context) {
+	if r.ExtendWriteDeadline != nil {
+		r.ExtendWriteDeadline(c.Request, bulkRecordTimeout)
+	}
+}
+
+// deadlineReader calls extend before each read
+type deadlineReader struct {
+	io.Reader
+	extend func()
+}
+
+func (d *deadlineReader) Read(p []byte) (int, error) {
+	d.extend()
+
+	return d.Reader.Read(p)
+}
+
+// serverBulkWriteTx writes a record in its own transaction
+func (r *Router) serverBulkWriteTx(c *gin.Context, rec *ServerBulkRecord, restore bool) (bool, error) {
+	tx, err := r.DB.BeginTx(c.Request.Context(), nil)
+	if err != nil {
+		return false, err
+	}
+
+	// nolint:errcheck // rollback is a no-op once the transaction is committed
+	defer tx.Rollback()
+
+	created, err := r.serverBulkWrite(c, tx, rec, restore)
+	if err != nil {
+		return false, err
+	}
+
+	return created, tx.Commit()
+}
+
+// serverBulkWrite upserts the server of a record along with its components, attributes
+// and credentials. Components, attributes and credentials that aren't in the record
+// are left as they are. It returns true when the server was created.
+func (r *Router) serverBulkWrite(c *gin.Context, exec boil.ContextExecutor, rec *ServerBulkRecord, restore bool) (bool, error) {
+	ctx := c.Request.Context()
+
+	created, err := upsertBulkServer(ctx, exec, rec, restore)
+	if err != nil {
+		return false, err
+	}
+
+	srvID := rec.UUID.String()
+
+	for _, attr := range rec.Attributes {
+		if err := upsertAttributes(ctx, exec, models.AttributeWhere.ServerID.EQ(null.StringFrom(srvID)), &models.Attribute{
+			ServerID:  null.StringFrom(srvID),
+			Namespace: attr.Namespace,
+			Data:      types.JSON(attr.Data),
+		}); err != nil {
+			return false, err
+		}
+	}
+
+	for _, va := range rec.VersionedAttributes {
+		dbVA := va.toDBModel()
+		dbVA.ServerID = null.StringFrom(srvID)
+
+		if err := addVersionedAttributes(ctx, exec, models.VersionedAttributeWhere.ServerID.EQ(null.StringFrom(srvID)), dbVA); err != nil {
+			return false, err
+		}
+	}
+
+	for i := range rec.Components {
+		if err := upsertBulkComponent(ctx, exec, srvID, &rec.Components[i]); err != nil {
+			return false, err
+		}
+	}
+
+	for _, cred := range rec.Credentials {
+		if err := r.upsertBulkCredential(c, exec, srvID, cred); err != nil {
+			return false, err
+		}
+	}
+
+	return created, nil
+}
+
+// upsertBulkServer creates the server or updates it. A deleted server is restored when
+// restore is set, otherwise it fails.
+func upsertBulkServer(ctx context.Context, exec boil.ContextExecutor, rec *ServerBulkRecord, restore bool) (bool, error) {
+	dbS, err := models.Servers(models.ServerWhere.ID.EQ(rec.UUID.String()), qm.WithDeleted()).One(ctx, exec)
+	if errors.Is(err, sql.ErrNoRows) {
+		dbS = &models.Server{
+			ID:           rec.UUID.String(),
+			Name:         null.StringFrom(rec.Name),
+			FacilityCode: null.StringFrom(rec.FacilityCode),
+		}
+
+		return true, dbS.Insert(ctx, exec, boil.Infer())
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	// the server may have been deleted since the records were validated
+	if dbS.DeletedAt.Valid && !restore {
+		return false, fmt.Errorf("%w: server %s is deleted, set restore=true to restore it", errBulkRecord, rec.UUID)
+	}
+
+	dbS.Name = null.StringFrom(rec.Name)
+	dbS.FacilityCode = null.StringFrom(rec.FacilityCode)
+	dbS.DeletedAt = null.Time{}
+
+	_, err = dbS.Update(ctx, exec, boil.Whitelist(
+		models.ServerColumns.Name,
+		models.ServerColumns.FacilityCode,
+		models.ServerColumns.DeletedAt,
+		models.ServerColumns.UpdatedAt,
+	))
+
+	return false, err
+}
+
+// upsertBulkComponent creates the component or updates the component of the server
+// with the same serial and type
+func upsertBulkComponent(ctx context.Context, exec boil.ContextExecutor, srvID string, sc *ServerComponent) error {
+	dbC, err := models.ServerComponents(
+		models.ServerComponentWhere.ServerID.EQ(srvID),
+		models.ServerComponentWhere.Serial.EQ(null.StringFrom(sc.Serial)),
+		models.ServerComponentWhere.ServerComponentTypeID.EQ(sc.ComponentTypeID),
+	).One(ctx, exec)
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		dbC = sc.toDBModel(srvID)
+
+		// the id is needed for the attributes before the insert returns
+		if dbC.ID == uuid.Nil.String() {
+			dbC.ID = uuid.New().String()
+		}
+
+		if err := dbC.Insert(ctx, exec, boil.Infer()); err != nil {
+			return err
+		}
+	case err != nil:
+		return err
+	default:
+		dbC.Name = null.StringFrom(sc.Name)
+		dbC.Vendor = null.StringFrom(sc.Vendor)
+		dbC.Model = null.StringFrom(sc.Model)
+
+		if _, err := dbC.Update(ctx, exec, boil.Whitelist(
+			models.ServerComponentColumns.Name,
+			models.ServerComponentColumns.Vendor,
+			models.ServerComponentColumns.Model,
+			models.ServerComponentColumns.UpdatedAt,
+		)); err != nil {
+			return err
+		}
+	}
+
+	for _, attr := range sc.Attributes {
+		if err := upsertAttributes(ctx, exec, models.AttributeWhere.ServerComponentID.EQ(null.StringFrom(dbC.ID)), &models.Attribute{
+			ServerComponentID: null.StringFrom(dbC.ID),
+			Namespace:         attr.Namespace,
+			Data:              types.JSON(attr.Data),
+		}); err != nil {
+			return err
+		}
+	}
+
+	for _, va := range sc.VersionedAttributes {
+		dbVA := va.toDBModel()
+		dbVA.ServerComponentID = null.StringFrom(dbC.ID)
+
+		if err := addVersionedAttributes(ctx, exec, models.VersionedAttributeWhere.ServerComponentID.EQ(null.StringFrom(dbC.ID)), dbVA); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *Router) upsertBulkCredential(c *gin.Context, exec boil.ContextExecutor, srvID string, cred ServerBulkCredential) error {
+	ctx := c.Request.Context()
+
+	secretType, err := models.ServerCredentialTypes(models.ServerCredentialTypeWhere.Slug.EQ(cred.SecretType)).One(ctx, exec)
+	if err != nil {
+		return err
+	}
+
+	encryptedValue, err := dbtools.Encrypt(ctx, r.Keyring, cred.Password)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.upsertServerCredential(ctx, exec, &models.ServerCredential{
+		ServerCredentialTypeID: secretType.ID,
+		ServerID:               srvID,
+		Password:               encryptedValue,
+		Username:               cred.Username,
+	}, requestSubject(c))
+
+	return err
+}
+
+// upsertAttributes replaces the data of the attributes in the namespace of the owner
+// selected by where or inserts them
+func upsertAttributes(ctx context.Context, exec boil.ContextExecutor, where qm.QueryMod, attr *models.Attribute) error {
+	cur, err := models.Attributes(where, models.AttributeWhere.Namespace.EQ(attr.Namespace)).One(ctx, exec)
+	if errors.Is(err, sql.ErrNoRows) {
+		return attr.Insert(ctx, exec, boil.Infer())
+	}
+
+	if err != nil {
+		return err
+	}
+
+	cur.Data = attr.Data
+
+	_, err = cur.Update(ctx, exec, boil.Whitelist(models.AttributeColumns.Data, models.AttributeColumns.UpdatedAt))
+
+	return err
+}
+
+// addVersionedAttributes adds a new version of the attributes for the owner selected
+// by where, the tally of the latest version is incremented when the data hasn't changed
+func addVersionedAttributes(ctx context.Context, exec boil.ContextExecutor, where qm.QueryMod, va *models.VersionedAttribute) error {
+	cur, err := models.VersionedAttributes(
+		where,
+		models.VersionedAttributeWhere.Namespace.EQ(va.Namespace),
+		qm.OrderBy(models.VersionedAttributeColumns.CreatedAt+" DESC"),
+	).One(ctx, exec)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	if cur != nil && areEqualJSON(va.Data, cur.Data) {
+		cur.Tally++
+
+		_, err := cur.Update(ctx, exec, boil.Whitelist(models.VersionedAttributeColumns.Tally, models.VersionedAttributeColumns.UpdatedAt))
+
+		return err
+	}
+
+	return va.Insert(ctx, exec, boil.Infer())
+}
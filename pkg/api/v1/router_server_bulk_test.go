@@ -0,0 +1,213 @@
+package serverservice_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.hollow.sh/serverservice/internal/dbtools"
+	serverservice "go.hollow.sh/serverservice/pkg/api/v1"
+)
+
+func TestIntegrationServerBulkImport(t *testing.T) {
+	s := serverTest(t)
+
+	realClientTests(t, func(ctx context.Context, authToken string, respCode int, expectError bool) error {
+		s.Client.SetToken(authToken)
+
+		rec := serverservice.ServerBulkRecord{Server: serverservice.Server{Name: "bulk-auth", FacilityCode: "Reef"}}
+
+		_, _, err := s.Client.BulkImport(ctx, serverservice.ServerBulkAtomic, []serverservice.ServerBulkRecord{rec})
+
+		return err
+	})
+
+	s.Client.SetToken(validToken(adminScopes))
+
+	ctx := context.TODO()
+	nemo := uuid.MustParse(dbtools.FixtureNemo.ID)
+	bruce := uuid.New()
+
+	t.Run("atomic import creates and updates servers", func(t *testing.T) {
+		records := []serverservice.ServerBulkRecord{
+			{
+				Server: serverservice.Server{
+					UUID:         bruce,
+					Name:         "Bruce",
+					FacilityCode: "Reef",
+					Attributes:   []serverservice.Attributes{{Namespace: "hollow.metadata", Data: json.RawMessage(`{"age":30}`)}},
+					Components: []serverservice.ServerComponent{
+						{Name: "fin", Serial: "bruce-left", Vendor: "Shark", ComponentTypeSlug: dbtools.FixtureFinType.Slug},
+					},
+				},
+				Credentials: []serverservice.ServerBulkCredential{
+					{SecretType: serverservice.ServerCredentialTypeBMC, Username: "root", Password: "fish-are-friends"},
+				},
+			},
+			{
+				Server: serverservice.Server{
+					UUID:         nemo,
+					Name:         "Nemo",
+					FacilityCode: "Reef",
+					Attributes:   []serverservice.Attributes{{Namespace: dbtools.FixtureNamespaceMetadata, Data: json.RawMessage(`{"age":7}`)}},
+				},
+			},
+		}
+
+		result, _, err := s.Client.BulkImport(ctx, serverservice.ServerBulkAtomic, records)
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, result.Created)
+		assert.Equal(t, 1, result.Updated)
+		assert.Equal(t, serverservice.ServerBulkCreated, result.Records[0].Status)
+		assert.Equal(t, serverservice.ServerBulkUpdated, result.Records[1].Status)
+
+		srv, _, err := s.Client.Get(ctx, bruce)
+		require.NoError(t, err)
+		assert.Equal(t, "Reef", srv.FacilityCode)
+		require.Len(t, srv.Components, 1)
+		assert.Equal(t, "Shark", srv.Components[0].Vendor)
+
+		cred, _, err := s.Client.GetCredential(ctx, bruce, serverservice.ServerCredentialTypeBMC)
+		require.NoError(t, err)
+		assert.Equal(t, "fish-are-friends", cred.Password)
+
+		attr, _, err := s.Client.GetAttributes(ctx, nemo, dbtools.FixtureNamespaceMetadata)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"age":7}`, string(attr.Data))
+
+		srv, _, err = s.Client.Get(ctx, nemo)
+		require.NoError(t, err)
+		assert.Equal(t, "Reef", srv.FacilityCode)
+		// components that aren't in the record are kept
+		assert.Len(t, srv.Components, 2)
+	})
+
+	t.Run("atomic import writes nothing when a record is invalid", func(t *testing.T) {
+		marlin := uuid.MustParse(dbtools.FixtureMarlin.ID)
+
+		records := []serverservice.ServerBulkRecord{
+			{Server: serverservice.Server{UUID: marlin, Name: "Marlin", FacilityCode: "Reef"}},
+			{Server: serverservice.Server{Name: "Squirt", Components: []serverservice.ServerComponent{
+				{Name: "shell", Serial: "1", ComponentTypeSlug: "shells"},
+			}}},
+		}
+
+		_, _, err := s.Client.BulkImport(ctx, serverservice.ServerBulkAtomic, records)
+		assert.ErrorContains(t, err, `line 2: invalid bulk record: component 0: unknown component type "shells"`)
+
+		srv, _, err := s.Client.Get(ctx, marlin)
+		require.NoError(t, err)
+		assert.Equal(t, dbtools.FixtureMarlin.FacilityCode.String, srv.FacilityCode)
+	})
+
+	t.Run("per record import reports each record", func(t *testing.T) {
+		crush := uuid.New()
+
+		records := []serverservice.ServerBulkRecord{
+			{Server: serverservice.Server{UUID: crush, Name: "Crush", FacilityCode: "EAC"}},
+			{Credentials: []serverservice.ServerBulkCredential{{SecretType: "unknown"}}},
+			{Server: serverservice.Server{UUID: crush, Name: "Crush again"}},
+		}
+
+		result, _, err := s.Client.BulkImport(ctx, serverservice.ServerBulkPerRecord, records)
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, result.Created)
+		assert.Equal(t, 2, result.Failed)
+		assert.Equal(t, serverservice.ServerBulkCreated, result.Records[0].Status)
+		assert.Equal(t, serverservice.ServerBulkFailed, result.Records[1].Status)
+		assert.Contains(t, result.Records[1].Error, `unknown credential type "unknown"`)
+		assert.Contains(t, result.Records[2].Error, "is also on line 1")
+
+		srv, _, err := s.Client.Get(ctx, crush)
+		require.NoError(t, err)
+		assert.Equal(t, "Crush", srv.Name)
+	})
+
+	t.Run("deleted servers are only restored with restore", func(t *testing.T) {
+		chuckles := uuid.MustParse(dbtools.FixtureChuckles.ID)
+
+		records := []serverservice.ServerBulkRecord{
+			{Server: serverservice.Server{UUID: chuckles, Name: "Chuckles", FacilityCode: "Reef"}},
+		}
+
+		_, _, err := s.Client.BulkImport(ctx, serverservice.ServerBulkAtomic, records)
+		assert.ErrorContains(t, err, "is deleted, set restore=true to restore it")
+
+		srv, _, err := s.Client.Get(ctx, chuckles)
+		require.NoError(t, err)
+		assert.NotNil(t, srv.DeletedAt)
+
+		result, _, err := s.Client.BulkRestore(ctx, serverservice.ServerBulkAtomic, records)
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Updated)
+
+		srv, _, err = s.Client.Get(ctx, chuckles)
+		require.NoError(t, err)
+		assert.Nil(t, srv.DeletedAt)
+	})
+
+	t.Run("updating existing servers needs the update scopes", func(t *testing.T) {
+		s.Client.SetToken(validToken([]string{"create:server"}))
+		defer s.Client.SetToken(validToken(adminScopes))
+
+		records := []serverservice.ServerBulkRecord{
+			{Server: serverservice.Server{UUID: nemo, Name: "Nemo", FacilityCode: "Drop-off"}},
+		}
+
+		_, _, err := s.Client.BulkImport(ctx, serverservice.ServerBulkPerRecord, records)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "403")
+
+		srv, _, err := s.Client.Get(ctx, nemo)
+		require.NoError(t, err)
+		assert.Equal(t, "Reef", srv.FacilityCode)
+	})
+
+	t.Run("relations need their own scopes", func(t *testing.T) {
+		defer s.Client.SetToken(validToken(adminScopes))
+
+		created := []serverservice.ServerBulkRecord{
+			{Server: serverservice.Server{
+				Name:         "Squirt",
+				FacilityCode: "Current",
+				Components: []serverservice.ServerComponent{
+					{Name: "fin", Serial: "squirt-left", Vendor: "Turtle", ComponentTypeSlug: dbtools.FixtureFinType.Slug},
+				},
+			}},
+		}
+
+		s.Client.SetToken(validToken([]string{"create:server"}))
+
+		_, _, err := s.Client.BulkImport(ctx, serverservice.ServerBulkAtomic, created)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "403")
+
+		s.Client.SetToken(validToken([]string{"create:server", "create:server:component"}))
+
+		_, _, err = s.Client.BulkImport(ctx, serverservice.ServerBulkAtomic, created)
+		require.NoError(t, err)
+
+		updated := []serverservice.ServerBulkRecord{
+			{Server: serverservice.Server{
+				UUID:         nemo,
+				Name:         "Nemo",
+				FacilityCode: "Reef",
+				VersionedAttributes: []serverservice.VersionedAttributes{
+					{Namespace: "hollow.bulk.scopes", Data: json.RawMessage(`{"fins":2}`)},
+				},
+			}},
+		}
+
+		s.Client.SetToken(validToken([]string{"create:server", "update:server"}))
+
+		_, _, err = s.Client.BulkImport(ctx, serverservice.ServerBulkAtomic, updated)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "403")
+	})
+}
@@ -1,12 +1,38 @@
 package serverservice
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
 	"github.com/gin-gonic/gin"
 	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 
 	"go.hollow.sh/serverservice/internal/models"
 )
 
+var (
+	errServerComponentTypeName      = errors.New("server component type name is required")
+	errServerComponentTypeMergeSelf = errors.New("server component type can't be merged into itself")
+)
+
+// serverComponentTypeMergeConflict lists the server components of the merged type that
+// have the same serial as a component of the into type on the same server
+type serverComponentTypeMergeConflict struct {
+	components models.ServerComponentSlice
+}
+
+func (e *serverComponentTypeMergeConflict) Error() string {
+	conflicts := make([]string, 0, len(e.components))
+	for _, sc := range e.components {
+		conflicts = append(conflicts, fmt.Sprintf("server %s serial %s", sc.ServerID, sc.Serial.String))
+	}
+
+	return "server components of both types have the same serial on a server: " + strings.Join(conflicts, ", ")
+}
+
 func (r *Router) serverComponentTypeCreate(c *gin.Context) {
 	var t ServerComponentType
 	if err := c.ShouldBindJSON(&t); err != nil {
@@ -67,3 +93,161 @@ func (r *Router) serverComponentTypeList(c *gin.Context) {
 
 	listResponse(c, types, pd)
 }
+
+func (r *Router) serverComponentTypeUpdate(c *gin.Context) {
+	dbT, err := models.ServerComponentTypes(models.ServerComponentTypeWhere.Slug.EQ(c.Param("slug"))).One(c.Request.Context(), r.DB)
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	var t ServerComponentType
+	if err := c.ShouldBindJSON(&t); err != nil {
+		badRequestResponse(c, "invalid server component type", err)
+		return
+	}
+
+	if t.Name == "" {
+		badRequestResponse(c, "invalid server component type", errServerComponentTypeName)
+		return
+	}
+
+	// an empty slug is generated from the new name by the update hook
+	dbT.Name = t.Name
+	dbT.Slug = t.Slug
+
+	if _, err := dbT.Update(c.Request.Context(), r.DB, boil.Infer()); err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	updatedResponse(c, dbT.Slug)
+}
+
+func (r *Router) serverComponentTypeDelete(c *gin.Context) {
+	dbT, err := models.ServerComponentTypes(models.ServerComponentTypeWhere.Slug.EQ(c.Param("slug"))).One(c.Request.Context(), r.DB)
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	refs, err := models.ServerComponents(models.ServerComponentWhere.ServerComponentTypeID.EQ(dbT.ID)).Count(c.Request.Context(), r.DB)
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	if refs > 0 && !forceParam(c) {
+		conflictResponse(c, "server component type is in use by server components, use force to delete them")
+		return
+	}
+
+	if err := r.serverComponentTypeDeleteTx(c.Request.Context(), dbT); err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	deletedResponse(c)
+}
+
+// serverComponentTypeDeleteTx deletes the component type and any server components of that type
+func (r *Router) serverComponentTypeDeleteTx(ctx context.Context, dbT *models.ServerComponentType) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	// nolint:errcheck // rollback is a no-op once the transaction is committed
+	defer tx.Rollback()
+
+	if _, err := models.ServerComponents(models.ServerComponentWhere.ServerComponentTypeID.EQ(dbT.ID)).DeleteAll(ctx, tx); err != nil {
+		return err
+	}
+
+	if _, err := dbT.Delete(ctx, tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *Router) serverComponentTypeMerge(c *gin.Context) {
+	dbT, err := models.ServerComponentTypes(models.ServerComponentTypeWhere.Slug.EQ(c.Param("slug"))).One(c.Request.Context(), r.DB)
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	var req ServerComponentTypeMergeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequestResponse(c, "invalid server component type merge request", err)
+		return
+	}
+
+	if req.Into == dbT.Slug {
+		badRequestResponse(c, "invalid server component type merge request", errServerComponentTypeMergeSelf)
+		return
+	}
+
+	into, err := models.ServerComponentTypes(models.ServerComponentTypeWhere.Slug.EQ(req.Into)).One(c.Request.Context(), r.DB)
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	if err := r.serverComponentTypeMergeTx(c.Request.Context(), dbT, into); err != nil {
+		var conflict *serverComponentTypeMergeConflict
+		if errors.As(err, &conflict) {
+			conflictResponse(c, conflict.Error()+", merge or delete them before merging the types")
+			return
+		}
+
+		dbErrorResponse(c, err)
+		return
+	}
+
+	updatedResponse(c, into.Slug)
+}
+
+// serverComponentTypeMergeTx moves all the server components of the type to the
+// into type and deletes the type. It returns a serverComponentTypeMergeConflict when a
+// server has components of both types with the same serial, they can't be told apart
+// once they have the same type.
+func (r *Router) serverComponentTypeMergeTx(ctx context.Context, dbT, into *models.ServerComponentType) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	// nolint:errcheck // rollback is a no-op once the transaction is committed
+	defer tx.Rollback()
+
+	conflicts, err := models.ServerComponents(
+		models.ServerComponentWhere.ServerComponentTypeID.EQ(dbT.ID),
+		qm.Where(`EXISTS (SELECT 1 FROM server_components AS sc WHERE sc.server_id = server_components.server_id
+			AND sc.serial = server_components.serial AND sc.server_component_type_id = ?)`, into.ID),
+		qm.OrderBy(models.ServerComponentColumns.ServerID+", "+models.ServerComponentColumns.Serial),
+	).All(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	if len(conflicts) != 0 {
+		return &serverComponentTypeMergeConflict{components: conflicts}
+	}
+
+	_, err = models.ServerComponents(models.ServerComponentWhere.ServerComponentTypeID.EQ(dbT.ID)).UpdateAll(
+		ctx,
+		tx,
+		models.M{models.ServerComponentColumns.ServerComponentTypeID: into.ID},
+	)
+	if err != nil {
+		return err
+	}
+
+	if _, err := dbT.Delete(ctx, tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -52,3 +53,131 @@ func TestIntegrationListServerComponentTypes(t *testing.T) {
 		return err
 	})
 }
+
+func TestIntegrationUpdateServerComponentType(t *testing.T) {
+	s := serverTest(t)
+
+	realClientTests(t, func(ctx context.Context, authToken string, respCode int, expectError bool) error {
+		s.Client.SetToken(authToken)
+
+		hct := serverservice.ServerComponentType{Name: "Fins"}
+
+		resp, err := s.Client.UpdateServerComponentType(ctx, dbtools.FixtureFinType.Slug, hct)
+		if !expectError {
+			require.NoError(t, err)
+			assert.Equal(t, "fins", resp.Slug)
+
+			r, _, err := s.Client.ListServerComponentTypes(ctx, nil)
+			require.NoError(t, err)
+			assert.Len(t, r, 1)
+			assert.Equal(t, "Fins", r[0].Name)
+			assert.Equal(t, "fins", r[0].Slug)
+		}
+
+		return err
+	})
+}
+
+func TestIntegrationDeleteServerComponentType(t *testing.T) {
+	s := serverTest(t)
+	s.Client.SetToken(validToken(adminScopes))
+
+	ctx := context.TODO()
+
+	t.Run("type in use is refused without force", func(t *testing.T) {
+		_, err := s.Client.DeleteServerComponentType(ctx, dbtools.FixtureFinType.Slug, false)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "409")
+	})
+
+	realClientTests(t, func(ctx context.Context, authToken string, respCode int, expectError bool) error {
+		s.Client.SetToken(authToken)
+
+		_, err := s.Client.DeleteServerComponentType(ctx, dbtools.FixtureFinType.Slug, true)
+		if !expectError {
+			require.NoError(t, err)
+
+			r, _, err := s.Client.ListServerComponentTypes(ctx, nil)
+			require.NoError(t, err)
+			assert.Len(t, r, 0)
+
+			c, _, err := s.Client.GetComponents(ctx, uuid.MustParse(dbtools.FixtureNemo.ID), nil)
+			require.NoError(t, err)
+			assert.Len(t, c, 0)
+		}
+
+		return err
+	})
+}
+
+func TestIntegrationMergeServerComponentType(t *testing.T) {
+	s := serverTest(t)
+	s.Client.SetToken(validToken(adminScopes))
+
+	_, err := s.Client.CreateServerComponentType(context.TODO(), serverservice.ServerComponentType{Name: "Fins"})
+	require.NoError(t, err)
+
+	realClientTests(t, func(ctx context.Context, authToken string, respCode int, expectError bool) error {
+		s.Client.SetToken(authToken)
+
+		resp, err := s.Client.MergeServerComponentType(ctx, dbtools.FixtureFinType.Slug, "fins")
+		if !expectError {
+			require.NoError(t, err)
+			assert.Equal(t, "fins", resp.Slug)
+
+			r, _, err := s.Client.ListServerComponentTypes(ctx, nil)
+			require.NoError(t, err)
+			assert.Len(t, r, 1)
+			assert.Equal(t, "fins", r[0].Slug)
+
+			c, _, err := s.Client.GetComponents(ctx, uuid.MustParse(dbtools.FixtureNemo.ID), nil)
+			require.NoError(t, err)
+			assert.NotEmpty(t, c)
+
+			for _, sc := range c {
+				assert.Equal(t, "fins", sc.ComponentTypeSlug)
+			}
+		}
+
+		return err
+	})
+}
+
+func TestIntegrationMergeServerComponentTypeConflict(t *testing.T) {
+	s := serverTest(t)
+	s.Client.SetToken(validToken(adminScopes))
+
+	ctx := context.TODO()
+	nemo := uuid.MustParse(dbtools.FixtureNemo.ID)
+
+	_, err := s.Client.CreateServerComponentType(ctx, serverservice.ServerComponentType{Name: "Fins"})
+	require.NoError(t, err)
+
+	types, _, err := s.Client.ListServerComponentTypes(ctx, nil)
+	require.NoError(t, err)
+
+	fins := types.BySlug("fins")
+	require.NotNil(t, fins)
+
+	// a duplicate of the left fin recorded with the other type
+	_, err = s.Client.CreateComponents(ctx, nemo, serverservice.ServerComponentSlice{
+		{
+			ServerUUID:        nemo,
+			Name:              "Normal Fin",
+			Serial:            dbtools.FixtureNemoLeftFin.Serial.String,
+			ComponentTypeID:   fins.ID,
+			ComponentTypeName: fins.Name,
+			ComponentTypeSlug: fins.Slug,
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = s.Client.MergeServerComponentType(ctx, dbtools.FixtureFinType.Slug, "fins")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "409")
+	assert.Contains(t, err.Error(), "serial "+dbtools.FixtureNemoLeftFin.Serial.String)
+
+	r, _, err := s.Client.ListServerComponentTypes(ctx, nil)
+	require.NoError(t, err)
+	assert.Len(t, r, 2, "nothing is merged when components conflict")
+}
@@ -0,0 +1,161 @@
+package serverservice
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/types"
+	"go.uber.org/zap"
+
+	"go.hollow.sh/serverservice/internal/models"
+)
+
+const (
+	// exportBatchSize is the number of servers read from the cursor before they
+	// are loaded with their relations and written to the response
+	exportBatchSize = 500
+	// exportWriteTimeout is how long each batch of an export has to be written
+	exportWriteTimeout = 30 * time.Second
+)
+
+// serverExport streams the servers matching the same filters as serverList. The server
+// ids are read from a cursor and written in batches so the full set is never in memory.
+func (r *Router) serverExport(c *gin.Context) {
+	format := ServerExportFormat(c.DefaultQuery("format", string(ServerExportNDJSON)))
+	if format != ServerExportNDJSON && format != ServerExportCSV {
+		badRequestResponse(c, "invalid export format", fmt.Errorf("%w: %q", errInvalidExportFormat, format))
+		return
+	}
+
+	components, attributes, err := parseExportInclude(c.Query("include"))
+	if err != nil {
+		badRequestResponse(c, "invalid export include", err)
+		return
+	}
+
+	params, ok := bindServerListParams(c)
+	if !ok {
+		return
+	}
+
+	filterSQL, args := queries.BuildQuery(models.Servers(params.queryMods()...).Query)
+	stmt := fmt.Sprintf("SELECT f.id FROM (%s) AS f ORDER BY f.created_at, f.id", strings.TrimSuffix(filterSQL, ";"))
+
+	ctx := c.Request.Context()
+
+	rows, err := r.DB.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	defer rows.Close()
+
+	c.Header("Content-Type", format.contentType())
+	c.Header("Trailer", ExportErrorTrailer)
+	c.Status(http.StatusOK)
+
+	w, err := newServerExportWriter(format, c.Writer, components, attributes)
+	if err != nil {
+		r.exportFailed(c, err)
+		return
+	}
+
+	ids := make([]string, 0, exportBatchSize)
+
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			r.exportFailed(c, err)
+			return
+		}
+
+		ids = append(ids, id)
+
+		if len(ids) == exportBatchSize {
+			if err := r.exportBatch(c, w, ids, components, attributes); err != nil {
+				r.exportFailed(c, err)
+				return
+			}
+
+			ids = ids[:0]
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		r.exportFailed(c, err)
+		return
+	}
+
+	if err := r.exportBatch(c, w, ids, components, attributes); err != nil {
+		r.exportFailed(c, err)
+	}
+}
+
+// exportBatch loads the servers with the requested relations and writes them in the
+// order of the ids
+func (r *Router) exportBatch(c *gin.Context, w serverExportWriter, ids []string, components, attributes bool) error {
+	if r.ExtendWriteDeadline != nil {
+		r.ExtendWriteDeadline(c.Request, exportWriteTimeout)
+	}
+
+	if len(ids) != 0 {
+		mods := []qm.QueryMod{
+			models.ServerWhere.ID.IN(ids),
+			qm.OrderBy(models.ServerTableColumns.CreatedAt + ", " + models.ServerTableColumns.ID),
+			qm.WithDeleted(),
+		}
+
+		if components {
+			mods = append(mods,
+				qm.Load("ServerComponents.Attributes"),
+				qm.Load("ServerComponents.ServerComponentType"),
+			)
+		}
+
+		if attributes {
+			mods = append(mods,
+				qm.Load("Attributes"),
+				// the latest versions are only looked up for the servers of the batch, not the whole table
+				qm.Load("VersionedAttributes", qm.Where(`(server_id, namespace, created_at) IN (select server_id, namespace, max(created_at)
+					from versioned_attributes where server_id = ANY(?::UUID[]) group by server_id, namespace)`, types.StringArray(ids))),
+			)
+		}
+
+		dbSRV, err := models.Servers(mods...).All(c.Request.Context(), r.DB)
+		if err != nil {
+			return err
+		}
+
+		for _, dbS := range dbSRV {
+			s := Server{}
+			if err := s.fromDBModel(dbS); err != nil {
+				return err
+			}
+
+			if err := w.Write(&s); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	c.Writer.Flush()
+
+	return nil
+}
+
+// exportFailed reports an error after the export response has started in the trailer
+func (r *Router) exportFailed(c *gin.Context, err error) {
+	r.Logger.Error("server export failed", zap.Error(err))
+
+	c.Writer.Header().Set(ExportErrorTrailer, err.Error())
+}
@@ -0,0 +1,110 @@
+package serverservice_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.hollow.sh/serverservice/internal/dbtools"
+	serverservice "go.hollow.sh/serverservice/pkg/api/v1"
+)
+
+func TestIntegrationServerExport(t *testing.T) {
+	s := serverTest(t)
+
+	realClientTests(t, func(ctx context.Context, authToken string, respCode int, expectError bool) error {
+		s.Client.SetToken(authToken)
+
+		r, err := s.Client.Export(ctx, nil)
+		if err != nil {
+			return err
+		}
+
+		defer r.Close()
+
+		servers := []serverservice.Server{}
+		scanner := bufio.NewScanner(r)
+
+		for scanner.Scan() {
+			var srv serverservice.Server
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &srv))
+
+			servers = append(servers, srv)
+		}
+
+		require.NoError(t, scanner.Err())
+		assert.Len(t, servers, 3)
+
+		for _, srv := range servers {
+			assert.Empty(t, srv.Components)
+			assert.Empty(t, srv.Attributes)
+		}
+
+		return nil
+	})
+
+	s.Client.SetToken(validToken(adminScopes))
+
+	t.Run("ndjson with components and attributes", func(t *testing.T) {
+		r, err := s.Client.Export(context.TODO(), &serverservice.ServerExportParams{
+			Format:            serverservice.ServerExportNDJSON,
+			IncludeComponents: true,
+			IncludeAttributes: true,
+			Filter:            &serverservice.ServerListParams{FacilityCode: "Sydney"},
+		})
+		require.NoError(t, err)
+
+		defer r.Close()
+
+		var srv serverservice.Server
+
+		dec := json.NewDecoder(r)
+		require.NoError(t, dec.Decode(&srv))
+		assert.Equal(t, dbtools.FixtureNemo.ID, srv.UUID.String())
+		assert.Len(t, srv.Components, 2)
+		assert.Len(t, srv.Attributes, 2)
+		assert.NotEmpty(t, srv.VersionedAttributes)
+
+		assert.ErrorIs(t, dec.Decode(&srv), io.EOF)
+	})
+
+	t.Run("csv including deleted servers", func(t *testing.T) {
+		r, err := s.Client.Export(context.TODO(), &serverservice.ServerExportParams{
+			Format:            serverservice.ServerExportCSV,
+			IncludeComponents: true,
+			Filter:            &serverservice.ServerListParams{IncludeDeleted: true},
+		})
+		require.NoError(t, err)
+
+		defer r.Close()
+
+		records, err := csv.NewReader(r).ReadAll()
+		require.NoError(t, err)
+
+		require.Len(t, records, 5)
+		assert.Equal(t, []string{"uuid", "name", "facility", "created_at", "updated_at", "deleted_at", "components"}, records[0])
+
+		deleted := 0
+
+		for _, rec := range records[1:] {
+			if rec[5] != "" {
+				deleted++
+
+				assert.Equal(t, dbtools.FixtureChuckles.ID, rec[0])
+			}
+		}
+
+		assert.Equal(t, 1, deleted)
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		_, err := s.Client.Export(context.TODO(), &serverservice.ServerExportParams{Format: "xml"})
+		assert.ErrorContains(t, err, "invalid export format")
+	})
+}
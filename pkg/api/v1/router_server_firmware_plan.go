@@ -0,0 +1,115 @@
+package serverservice
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"go.hollow.sh/serverservice/internal/dbtools"
+	"go.hollow.sh/serverservice/internal/models"
+)
+
+var errServerFirmwarePlan = errors.New("invalid firmware plan request")
+
+// serverFirmwarePlan returns the firmware to install on the components of the server to
+// bring them to the versions in the firmware set of the firmware_set query param. The
+// firmware is for the hardware model of the model query param, or of the server vendor
+// attributes when it's empty.
+func (r *Router) serverFirmwarePlan(c *gin.Context) {
+	fwSetID, err := uuid.Parse(c.Query("firmware_set"))
+	if err != nil {
+		badRequestResponse(c, "", fmt.Errorf("%w: expected a firmware set UUID: %s", errServerFirmwarePlan, err))
+		return
+	}
+
+	namespace := c.DefaultQuery("namespace", FirmwareInstalledNamespace)
+
+	srv, err := r.loadServerFromParams(c)
+	if err != nil {
+		if errors.Is(err, ErrUUIDParse) {
+			badRequestResponse(c, "", err)
+			return
+		}
+
+		dbErrorResponse(c, err)
+
+		return
+	}
+
+	model := c.Query("model")
+	if model == "" {
+		model, err = r.serverHardwareModel(c.Request.Context(), srv.ID)
+		if err != nil {
+			if errors.Is(err, errServerFirmwarePlan) {
+				badRequestResponse(c, "", err)
+				return
+			}
+
+			dbErrorResponse(c, err)
+
+			return
+		}
+	}
+
+	firmwareSet, err := models.FindComponentFirmwareSet(c.Request.Context(), r.DB, fwSetID.String())
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	firmwares, err := dbtools.FirmwareSetFirmware(c.Request.Context(), r.DB, firmwareSet.ID)
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	components, err := srv.ServerComponents(
+		qm.Load(models.ServerComponentRels.VersionedAttributes, models.VersionedAttributeWhere.Namespace.EQ(namespace)),
+		qm.Load(models.ServerComponentRels.ServerComponentType),
+	).All(c.Request.Context(), r.DB)
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	plan, err := newServerFirmwarePlan(srv, firmwareSet, model, components, firmwares, namespace)
+	if err != nil {
+		if errors.Is(err, errServerFirmwarePlan) {
+			badRequestResponse(c, "", err)
+			return
+		}
+
+		failedConvertingToVersioned(c, err)
+
+		return
+	}
+
+	itemResponse(c, plan)
+}
+
+// serverHardwareModel returns the hardware model in the vendor attributes of the server
+func (r *Router) serverHardwareModel(ctx context.Context, srvID string) (string, error) {
+	attrs, err := models.Attributes(
+		models.AttributeWhere.ServerID.EQ(null.StringFrom(srvID)),
+		models.AttributeWhere.Namespace.EQ(ServerVendorAttributesNamespace),
+	).One(ctx, r.DB)
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+	case err != nil:
+		return "", err
+	default:
+		if model := serverHardwareModel(attrs); model != "" {
+			return model, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: the server has no model in its %s attributes, expected a model param",
+		errServerFirmwarePlan, ServerVendorAttributesNamespace)
+}
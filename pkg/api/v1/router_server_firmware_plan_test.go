@@ -0,0 +1,109 @@
+package serverservice_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/types"
+
+	"go.hollow.sh/serverservice/internal/dbtools"
+	"go.hollow.sh/serverservice/internal/models"
+	serverservice "go.hollow.sh/serverservice/pkg/api/v1"
+)
+
+func TestIntegrationServerFirmwarePlan(t *testing.T) {
+	s := serverTest(t)
+
+	ctx := context.TODO()
+	db := dbtools.DatabaseTest(t)
+
+	for slug, installed := range map[string]string{"bmc": "4.40.00.00", "bios": "2.4.4"} {
+		componentType := &models.ServerComponentType{Name: slug, Slug: slug}
+		require.NoError(t, componentType.Insert(ctx, db, boil.Infer()))
+
+		component := &models.ServerComponent{
+			ServerComponentTypeID: componentType.ID,
+			Name:                  null.StringFrom(slug),
+			Vendor:                null.StringFrom("dell"),
+			Serial:                null.StringFrom(slug + "-serial"),
+		}
+		require.NoError(t, dbtools.FixtureDory.AddServerComponents(ctx, db, true, component))
+
+		require.NoError(t, component.AddVersionedAttributes(ctx, db, true, &models.VersionedAttribute{
+			Namespace: serverservice.FirmwareInstalledNamespace,
+			Data:      types.JSON(`{"firmware":{"installed":"` + installed + `"}}`),
+		}))
+	}
+
+	require.NoError(t, dbtools.FixtureDory.AddAttributes(ctx, db, true, &models.Attribute{
+		Namespace: serverservice.ServerVendorAttributesNamespace,
+		Data:      types.JSON(`{"vendor":"dell","model":"r640"}`),
+	}))
+
+	serverID := uuid.MustParse(dbtools.FixtureDory.ID)
+	firmwareSetID := uuid.MustParse(dbtools.FixtureFirmwareSetR640.ID)
+
+	realClientTests(t, func(ctx context.Context, authToken string, respCode int, expectError bool) error {
+		s.Client.SetToken(authToken)
+
+		plan, _, err := s.Client.GetServerFirmwarePlan(ctx, serverID, firmwareSetID, "", "")
+		if !expectError {
+			require.NoError(t, err)
+			assert.Equal(t, serverID, plan.ServerUUID)
+			assert.Equal(t, firmwareSetID, plan.FirmwareSetUUID)
+			assert.Equal(t, "r640", plan.Model, "the model is read from the server vendor attributes")
+
+			require.Len(t, plan.Steps, 1)
+			assert.Equal(t, "bmc", plan.Steps[0].ComponentSlug)
+			assert.Equal(t, "4.40.00.00", plan.Steps[0].Installed)
+			assert.Equal(t, dbtools.FixtureDellR640BMC.ID, plan.Steps[0].FirmwareUUID.String())
+			assert.Equal(t, dbtools.FixtureDellR640BMC.Version, plan.Steps[0].Version)
+			assert.Equal(t, dbtools.FixtureDellR640BMC.RepositoryURL, plan.Steps[0].RepositoryURL)
+			assert.Equal(t, dbtools.FixtureDellR640BMC.Checksum, plan.Steps[0].Checksum)
+
+			require.Len(t, plan.Skipped, 1)
+			assert.Equal(t, "bios", plan.Skipped[0].ComponentSlug)
+			assert.Equal(t, serverservice.FirmwarePlanSkipUpToDate, plan.Skipped[0].Reason)
+		}
+
+		return err
+	})
+
+	s.Client.SetToken(validToken(adminScopes))
+
+	// nothing is reported in another namespace, so both components are updated
+	plan, _, err := s.Client.GetServerFirmwarePlan(ctx, serverID, firmwareSetID, "", "sh.hollow.alloy.inband.status")
+	require.NoError(t, err)
+	require.Len(t, plan.Steps, 2)
+	assert.Equal(t, "bmc", plan.Steps[0].ComponentSlug)
+	assert.Equal(t, "bios", plan.Steps[1].ComponentSlug)
+	assert.Empty(t, plan.Steps[1].Installed)
+
+	_, _, err = s.Client.GetServerFirmwarePlan(ctx, serverID, uuid.New(), "", "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "404")
+
+	// the set has no firmware for the model
+	_, _, err = s.Client.GetServerFirmwarePlan(ctx, serverID, firmwareSetID, "R6515", "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "400")
+
+	// the server has no vendor attributes and no model is given
+	_, _, err = s.Client.GetServerFirmwarePlan(ctx, uuid.MustParse(dbtools.FixtureMarlin.ID), firmwareSetID, "", "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "expected a model param")
+
+	plan, _, err = s.Client.GetServerFirmwarePlan(ctx, uuid.MustParse(dbtools.FixtureMarlin.ID), firmwareSetID, "R640", "")
+	require.NoError(t, err)
+	assert.Empty(t, plan.Steps, "the fins of the server have no firmware in the set")
+	assert.NotEmpty(t, plan.Unplanned)
+
+	for _, u := range plan.Unplanned {
+		assert.Equal(t, serverservice.FirmwarePlanUnplannedNoFirmware, u.Reason)
+	}
+}
@@ -1,12 +1,21 @@
 package serverservice
 
 import (
+	"context"
+	"errors"
+
 	"github.com/gin-gonic/gin"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 
 	"go.hollow.sh/serverservice/internal/models"
 )
 
+var (
+	errServerCredentialTypeName    = errors.New("server credential type name is required")
+	errServerCredentialTypeBuiltin = errors.New("builtin server credential types can't be renamed or deleted")
+	errServerCredentialTypeMaxAge  = errors.New("server credential type max age can't be negative")
+)
+
 func (r *Router) serverCredentialTypesList(c *gin.Context) {
 	pager := parsePagination(c)
 
@@ -26,7 +35,10 @@ func (r *Router) serverCredentialTypesList(c *gin.Context) {
 
 	for _, dbType := range dbTypes {
 		t := ServerCredentialType{}
-		t.fromDBModel(dbType)
+		if err := t.fromDBModel(dbType); err != nil {
+			failedConvertingToVersioned(c, err)
+			return
+		}
 
 		types = append(types, t)
 	}
@@ -41,8 +53,26 @@ func (r *Router) serverCredentialTypesList(c *gin.Context) {
 }
 
 func (r *Router) serverCredentialTypesCreate(c *gin.Context) {
-	var sType models.ServerCredentialType
-	if err := c.ShouldBindJSON(&sType); err != nil {
+	var t ServerCredentialType
+	if err := c.ShouldBindJSON(&t); err != nil {
+		badRequestResponse(c, "invalid server secret type", err)
+		return
+	}
+
+	if t.MaxAge < 0 {
+		badRequestResponse(c, "invalid server secret type", errServerCredentialTypeMaxAge)
+		return
+	}
+
+	if t.PasswordPolicy != nil {
+		if err := t.PasswordPolicy.Validate(); err != nil {
+			badRequestResponse(c, "invalid server secret type password policy", err)
+			return
+		}
+	}
+
+	sType, err := t.toDBModel()
+	if err != nil {
 		badRequestResponse(c, "invalid server secret type", err)
 		return
 	}
@@ -60,3 +90,114 @@ func (r *Router) serverCredentialTypesCreate(c *gin.Context) {
 
 	createdResponse(c, sType.Slug)
 }
+
+func (r *Router) serverCredentialTypesUpdate(c *gin.Context) {
+	sType, err := models.ServerCredentialTypes(models.ServerCredentialTypeWhere.Slug.EQ(c.Param("slug"))).One(c.Request.Context(), r.DB)
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	var t ServerCredentialType
+	if err := c.ShouldBindJSON(&t); err != nil {
+		badRequestResponse(c, "invalid server secret type", err)
+		return
+	}
+
+	if t.Name == "" {
+		badRequestResponse(c, "invalid server secret type", errServerCredentialTypeName)
+		return
+	}
+
+	if t.MaxAge < 0 {
+		badRequestResponse(c, "invalid server secret type", errServerCredentialTypeMaxAge)
+		return
+	}
+
+	if t.PasswordPolicy != nil {
+		if err := t.PasswordPolicy.Validate(); err != nil {
+			badRequestResponse(c, "invalid server secret type password policy", err)
+			return
+		}
+	}
+
+	// builtin types are referenced by slug in the application, only the password policy and max age can change
+	if sType.Builtin && (t.Name != sType.Name || (t.Slug != "" && t.Slug != sType.Slug)) {
+		badRequestResponse(c, "invalid server secret type", errServerCredentialTypeBuiltin)
+		return
+	}
+
+	update, err := t.toDBModel()
+	if err != nil {
+		badRequestResponse(c, "invalid server secret type", err)
+		return
+	}
+
+	// an empty slug is generated from the new name by the update hook
+	sType.Name = update.Name
+	sType.PasswordPolicy = update.PasswordPolicy
+	sType.MaxAge = update.MaxAge
+
+	if !sType.Builtin {
+		sType.Slug = update.Slug
+	}
+
+	if _, err := sType.Update(c.Request.Context(), r.DB, boil.Infer()); err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	updatedResponse(c, sType.Slug)
+}
+
+func (r *Router) serverCredentialTypesDelete(c *gin.Context) {
+	sType, err := models.ServerCredentialTypes(models.ServerCredentialTypeWhere.Slug.EQ(c.Param("slug"))).One(c.Request.Context(), r.DB)
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	if sType.Builtin {
+		badRequestResponse(c, "invalid server secret type", errServerCredentialTypeBuiltin)
+		return
+	}
+
+	refs, err := models.ServerCredentials(models.ServerCredentialWhere.ServerCredentialTypeID.EQ(sType.ID)).Count(c.Request.Context(), r.DB)
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	if refs > 0 && !forceParam(c) {
+		conflictResponse(c, "server secret type is in use by server secrets, use force to delete them")
+		return
+	}
+
+	if err := r.serverCredentialTypeDeleteTx(c.Request.Context(), sType); err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	deletedResponse(c)
+}
+
+// serverCredentialTypeDeleteTx deletes the credential type and any server credentials of that type
+func (r *Router) serverCredentialTypeDeleteTx(ctx context.Context, sType *models.ServerCredentialType) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	// nolint:errcheck // rollback is a no-op once the transaction is committed
+	defer tx.Rollback()
+
+	if _, err := models.ServerCredentials(models.ServerCredentialWhere.ServerCredentialTypeID.EQ(sType.ID)).DeleteAll(ctx, tx); err != nil {
+		return err
+	}
+
+	if _, err := sType.Delete(ctx, tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
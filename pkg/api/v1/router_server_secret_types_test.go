@@ -4,9 +4,11 @@ import (
 	"context"
 	"testing"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"go.hollow.sh/serverservice/internal/dbtools"
 	serverservice "go.hollow.sh/serverservice/pkg/api/v1"
 )
 
@@ -61,3 +63,89 @@ func TestIntegrationServerCredentialTypesCreate(t *testing.T) {
 		require.Contains(t, err.Error(), "duplicate key")
 	})
 }
+
+func TestIntegrationServerCredentialTypesUpdate(t *testing.T) {
+	s := serverTest(t)
+	s.Client.SetToken(validToken(adminScopes))
+
+	_, err := s.Client.CreateServerCredentialType(context.TODO(), &serverservice.ServerCredentialType{Name: "Test Tpye"})
+	require.NoError(t, err)
+
+	realClientTests(t, func(ctx context.Context, authToken string, respCode int, expectError bool) error {
+		s.Client.SetToken(authToken)
+
+		resp, err := s.Client.UpdateServerCredentialType(ctx, "test-tpye", &serverservice.ServerCredentialType{Name: "Test Type"})
+		if !expectError {
+			require.NoError(t, err)
+			assert.Equal(t, "test-type", resp.Slug)
+		}
+
+		return err
+	})
+
+	s.Client.SetToken(validToken(adminScopes))
+
+	t.Run("builtin types can't be renamed", func(t *testing.T) {
+		_, err := s.Client.UpdateServerCredentialType(context.TODO(), serverservice.ServerCredentialTypeBMC, &serverservice.ServerCredentialType{Name: "Renamed"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "builtin")
+	})
+
+	t.Run("builtin types can update the password policy", func(t *testing.T) {
+		r, _, err := s.Client.ListServerCredentialTypes(context.TODO(), nil)
+		require.NoError(t, err)
+
+		var bmc serverservice.ServerCredentialType
+
+		for _, st := range r {
+			if st.Slug == serverservice.ServerCredentialTypeBMC {
+				bmc = st
+			}
+		}
+
+		bmc.PasswordPolicy = &serverservice.PasswordPolicy{Length: 16, Lowercase: true, Digits: true}
+
+		resp, err := s.Client.UpdateServerCredentialType(context.TODO(), serverservice.ServerCredentialTypeBMC, &bmc)
+		require.NoError(t, err)
+		assert.Equal(t, serverservice.ServerCredentialTypeBMC, resp.Slug)
+	})
+}
+
+func TestIntegrationServerCredentialTypesDelete(t *testing.T) {
+	ctx := context.TODO()
+	s := serverTest(t)
+	s.Client.SetToken(validToken(adminScopes))
+
+	_, err := s.Client.CreateServerCredentialType(ctx, &serverservice.ServerCredentialType{Name: "Test Type"})
+	require.NoError(t, err)
+
+	_, err = s.Client.SetCredential(ctx, uuid.MustParse(dbtools.FixtureNemo.ID), "test-type", "admin", "super-secret")
+	require.NoError(t, err)
+
+	t.Run("builtin types can't be deleted", func(t *testing.T) {
+		_, err := s.Client.DeleteServerCredentialType(ctx, serverservice.ServerCredentialTypeBMC, true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "builtin")
+	})
+
+	t.Run("type in use is refused without force", func(t *testing.T) {
+		_, err := s.Client.DeleteServerCredentialType(ctx, "test-type", false)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "409")
+	})
+
+	realClientTests(t, func(ctx context.Context, authToken string, respCode int, expectError bool) error {
+		s.Client.SetToken(authToken)
+
+		_, err := s.Client.DeleteServerCredentialType(ctx, "test-type", true)
+		if !expectError {
+			require.NoError(t, err)
+
+			r, _, err := s.Client.ListServerCredentialTypes(ctx, nil)
+			require.NoError(t, err)
+			assert.Len(t, r, 1)
+		}
+
+		return err
+	})
+}
@@ -1,11 +1,17 @@
 package serverservice
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 
@@ -13,18 +19,12 @@ import (
 	"go.hollow.sh/serverservice/internal/models"
 )
 
+// DefaultCredentialVersionsRetained is the number of previous values kept for each
+// credential when the router doesn't set CredentialVersionsRetained
+const DefaultCredentialVersionsRetained = 10
+
 func (r *Router) serverCredentialGet(c *gin.Context) {
-	mods := []qm.QueryMod{
-		models.ServerCredentialWhere.ServerID.EQ(c.Param("uuid")),
-		qm.InnerJoin(fmt.Sprintf("%s as t on t.%s = %s.%s",
-			models.TableNames.ServerCredentialTypes,
-			models.ServerCredentialTypeColumns.ID,
-			models.TableNames.ServerCredentials,
-			models.ServerCredentialColumns.ServerCredentialTypeID,
-		)),
-		qm.Where(fmt.Sprintf("t.%s=?", models.ServerCredentialTypeColumns.Slug), c.Param("slug")),
-		qm.Load(models.ServerCredentialRels.ServerCredentialType),
-	}
+	mods := append(serverCredentialQueryMods(c), qm.Load(models.ServerCredentialRels.ServerCredentialType))
 
 	dbS, err := models.ServerCredentials(mods...).One(c.Request.Context(), r.DB)
 	if err != nil {
@@ -32,7 +32,7 @@ func (r *Router) serverCredentialGet(c *gin.Context) {
 		return
 	}
 
-	decryptedValue, err := dbtools.Decrypt(c.Request.Context(), r.SecretsKeeper, dbS.Password)
+	decryptedValue, err := dbtools.Decrypt(c.Request.Context(), r.Keyring, dbS.Password)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, &ServerResponse{Message: "error decrypting value", Error: err.Error()})
 		return
@@ -44,6 +44,11 @@ func (r *Router) serverCredentialGet(c *gin.Context) {
 		return
 	}
 
+	if err := recordCredentialAccess(c.Request.Context(), r.DB, c, dbS.ServerID, c.Param("slug"), CredentialAccessActionRead); err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
 	secret := &ServerCredential{
 		ServerID:   sID,
 		SecretType: dbS.R.ServerCredentialType.Slug,
@@ -57,18 +62,7 @@ func (r *Router) serverCredentialGet(c *gin.Context) {
 }
 
 func (r *Router) serverCredentialDelete(c *gin.Context) {
-	mods := []qm.QueryMod{
-		models.ServerCredentialWhere.ServerID.EQ(c.Param("uuid")),
-		qm.InnerJoin(fmt.Sprintf("%s as t on t.%s = %s.%s",
-			models.TableNames.ServerCredentialTypes,
-			models.ServerCredentialTypeColumns.ID,
-			models.TableNames.ServerCredentials,
-			models.ServerCredentialColumns.ServerCredentialTypeID,
-		)),
-		qm.Where(fmt.Sprintf("t.%s=?", models.ServerCredentialTypeColumns.Slug), c.Param("slug")),
-	}
-
-	dbS, err := models.ServerCredentials(mods...).One(c.Request.Context(), r.DB)
+	dbS, err := models.ServerCredentials(serverCredentialQueryMods(c)...).One(c.Request.Context(), r.DB)
 	if err != nil {
 		dbErrorResponse(c, err)
 		return
@@ -113,7 +107,7 @@ func (r *Router) serverCredentialUpsert(c *gin.Context) {
 		return
 	}
 
-	encryptedValue, err := dbtools.Encrypt(c.Request.Context(), r.SecretsKeeper, newValue.Password)
+	encryptedValue, err := dbtools.Encrypt(c.Request.Context(), r.Keyring, newValue.Password)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, &ServerResponse{Message: "error encrypting secret value", Error: err.Error()})
 		return
@@ -126,17 +120,147 @@ func (r *Router) serverCredentialUpsert(c *gin.Context) {
 		Username:               newValue.Username,
 	}
 
-	err = secret.Upsert(
-		c.Request.Context(),
-		r.DB,
+	if _, err := r.serverCredentialUpsertTx(c.Request.Context(), &secret, requestSubject(c)); err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	updatedResponse(c, secretSlug)
+}
+
+func (r *Router) serverCredentialGenerate(c *gin.Context) {
+	srvUUID, err := r.parseUUID(c)
+	if err != nil {
+		return
+	}
+
+	secretSlug := c.Param("slug")
+
+	exists, err := models.ServerExists(c.Request.Context(), r.DB, srvUUID.String())
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	if !exists {
+		notFoundResponse(c, "server not found")
+		return
+	}
+
+	dbType, err := models.ServerCredentialTypes(models.ServerCredentialTypeWhere.Slug.EQ(secretSlug)).One(c.Request.Context(), r.DB)
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	var secretType ServerCredentialType
+	if err := secretType.fromDBModel(dbType); err != nil {
+		failedConvertingToVersioned(c, err)
+		return
+	}
+
+	// the username is optional, when it isn't given the current username is kept
+	var newValue serverCredentialValues
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&newValue); err != nil {
+			badRequestResponse(c, "invalid server secret value", err)
+			return
+		}
+	}
+
+	if newValue.Username == "" {
+		current, err := models.ServerCredentials(
+			models.ServerCredentialWhere.ServerID.EQ(srvUUID.String()),
+			models.ServerCredentialWhere.ServerCredentialTypeID.EQ(dbType.ID),
+		).One(c.Request.Context(), r.DB)
+
+		switch {
+		case err == nil:
+			newValue.Username = current.Username
+		case !errors.Is(err, sql.ErrNoRows):
+			dbErrorResponse(c, err)
+			return
+		}
+	}
+
+	policy := secretType.passwordPolicy()
+
+	password, err := policy.Generate()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, &ServerResponse{Message: "error generating secret value", Error: err.Error()})
+		return
+	}
+
+	encryptedValue, err := dbtools.Encrypt(c.Request.Context(), r.Keyring, password)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, &ServerResponse{Message: "error encrypting secret value", Error: err.Error()})
+		return
+	}
+
+	secret := models.ServerCredential{
+		ServerCredentialTypeID: dbType.ID,
+		ServerID:               srvUUID.String(),
+		Password:               encryptedValue,
+		Username:               newValue.Username,
+	}
+
+	dbS, err := r.serverCredentialUpsertTx(c.Request.Context(), &secret, requestSubject(c))
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	// the generated password is only ever returned by this request
+	if err := recordCredentialAccess(c.Request.Context(), r.DB, c, dbS.ServerID, secretSlug, CredentialAccessActionGenerate); err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	itemResponse(c, &ServerCredential{
+		ServerID:   srvUUID,
+		SecretType: secretSlug,
+		Username:   dbS.Username,
+		Password:   password,
+		CreatedAt:  dbS.CreatedAt,
+		UpdatedAt:  dbS.UpdatedAt,
+	})
+}
+
+// serverCredentialUpsertTx inserts or updates the credential and records the new
+// value in the credential history
+func (r *Router) serverCredentialUpsertTx(ctx context.Context, secret *models.ServerCredential, createdBy string) (*models.ServerCredential, error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// nolint:errcheck // rollback is a no-op once the transaction is committed
+	defer tx.Rollback()
+
+	dbS, err := r.upsertServerCredential(ctx, tx, secret, createdBy)
+	if err != nil {
+		return nil, err
+	}
+
+	return dbS, tx.Commit()
+}
+
+// upsertServerCredential inserts or updates the credential and records the new value
+// in the credential history using exec, which should be a transaction
+func (r *Router) upsertServerCredential(ctx context.Context, exec boil.ContextExecutor, secret *models.ServerCredential, createdBy string) (*models.ServerCredential, error) {
+	err := secret.Upsert(
+		ctx,
+		exec,
 		true,
 		// search for records by server id and type id to see if we need to update or insert
 		[]string{models.ServerCredentialColumns.ServerID, models.ServerCredentialColumns.ServerCredentialTypeID},
-		// For updates only set the new value and updated at
+		// For updates only set the new value and updated at, and clear the expiry
+		// notification so the new value is notified once it expires
 		boil.Whitelist(
 			models.ServerCredentialColumns.Username,
 			models.ServerCredentialColumns.Password,
-			models.ServerCredentialColumns.UpdatedAt),
+			models.ServerCredentialColumns.UpdatedAt,
+			models.ServerCredentialColumns.ExpiryNotifiedAt),
 		// For inserts set server id, type id and value
 		boil.Whitelist(
 			models.ServerCredentialColumns.ServerID,
@@ -147,10 +271,305 @@ func (r *Router) serverCredentialUpsert(c *gin.Context) {
 			models.ServerCredentialColumns.UpdatedAt,
 		),
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	// the upsert doesn't return the id on update, load the credential to record the new version
+	dbS, err := models.ServerCredentials(
+		models.ServerCredentialWhere.ServerID.EQ(secret.ServerID),
+		models.ServerCredentialWhere.ServerCredentialTypeID.EQ(secret.ServerCredentialTypeID),
+	).One(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.recordServerCredentialVersion(ctx, exec, dbS, createdBy, dbS.UpdatedAt); err != nil {
+		return nil, err
+	}
+
+	return dbS, nil
+}
+
+func (r *Router) serverCredentialVersionsList(c *gin.Context) {
+	dbS, err := models.ServerCredentials(serverCredentialQueryMods(c)...).One(c.Request.Context(), r.DB)
 	if err != nil {
 		dbErrorResponse(c, err)
 		return
 	}
 
-	updatedResponse(c, secretSlug)
+	dbVersions, err := models.ServerCredentialVersions(
+		models.ServerCredentialVersionWhere.ServerCredentialID.EQ(dbS.ID),
+		qm.OrderBy(models.ServerCredentialVersionColumns.Version+" DESC"),
+	).All(c.Request.Context(), r.DB)
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	versions := []ServerCredentialVersion{}
+
+	for _, dbV := range dbVersions {
+		v := ServerCredentialVersion{}
+		v.fromDBModel(dbV)
+
+		versions = append(versions, v)
+	}
+
+	itemResponse(c, versions)
+}
+
+func (r *Router) serverCredentialRollback(c *gin.Context) {
+	version, err := strconv.ParseInt(c.Param("version"), 10, 64)
+	if err != nil {
+		badRequestResponse(c, "invalid credential version", err)
+		return
+	}
+
+	tx, err := r.DB.BeginTx(c.Request.Context(), nil)
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	// nolint:errcheck // rollback is a no-op once the transaction is committed
+	defer tx.Rollback()
+
+	// the credential is locked so a concurrent update isn't overwritten by the restored value
+	mods := append(serverCredentialQueryMods(c), qm.For("UPDATE OF "+models.TableNames.ServerCredentials))
+
+	dbS, err := models.ServerCredentials(mods...).One(c.Request.Context(), tx)
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	dbV, err := models.ServerCredentialVersions(
+		models.ServerCredentialVersionWhere.ServerCredentialID.EQ(dbS.ID),
+		models.ServerCredentialVersionWhere.Version.EQ(version),
+	).One(c.Request.Context(), tx)
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	dbS.Username = dbV.Username
+	dbS.Password = dbV.Password
+	// the restored value keeps its age, a rollback isn't a rotation
+	dbS.UpdatedAt = dbV.CreatedAt
+	// the restored value is notified again once it expires
+	dbS.ExpiryNotifiedAt = null.Time{}
+
+	if _, err := dbS.Update(boil.SkipTimestamps(c.Request.Context()), tx, boil.Whitelist(
+		models.ServerCredentialColumns.Username,
+		models.ServerCredentialColumns.Password,
+		models.ServerCredentialColumns.UpdatedAt,
+		models.ServerCredentialColumns.ExpiryNotifiedAt,
+	)); err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	// a rollback is recorded as a new version so the history stays linear, with the
+	// time of the rollback rather than the age of the restored value
+	if err := r.recordServerCredentialVersion(c.Request.Context(), tx, dbS, requestSubject(c), time.Now()); err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	updatedResponse(c, c.Param("slug"))
+}
+
+func (r *Router) serverCredentialList(c *gin.Context) {
+	pager := parsePagination(c)
+
+	srvUUID, err := r.parseUUID(c)
+	if err != nil {
+		return
+	}
+
+	exists, err := models.ServerExists(c.Request.Context(), r.DB, srvUUID.String())
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	if !exists {
+		notFoundResponse(c, "server not found")
+		return
+	}
+
+	mods := []qm.QueryMod{
+		models.ServerCredentialWhere.ServerID.EQ(srvUUID.String()),
+	}
+
+	count, err := models.ServerCredentials(mods...).Count(c.Request.Context(), r.DB)
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	pager.OrderBy = models.ServerCredentialTableColumns.CreatedAt + " ASC"
+	mods = append(mods, pager.queryMods()...)
+	mods = append(mods, qm.Load(models.ServerCredentialRels.ServerCredentialType))
+
+	dbCreds, err := models.ServerCredentials(mods...).All(c.Request.Context(), r.DB)
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	creds := []ServerCredentialMetadata{}
+
+	for _, dbS := range dbCreds {
+		m := ServerCredentialMetadata{}
+		if err := m.fromDBModel(dbS); err != nil {
+			failedConvertingToVersioned(c, err)
+			return
+		}
+
+		creds = append(creds, m)
+	}
+
+	pd := paginationData{
+		pageCount:  len(creds),
+		totalCount: count,
+		pager:      pager,
+	}
+
+	listResponse(c, creds, pd)
+}
+
+// serverCredentialMissingList returns the servers that don't have a credential of
+// the type given by the slug query param, bmc by default
+func (r *Router) serverCredentialMissingList(c *gin.Context) {
+	pager := parsePagination(c)
+
+	slug := c.DefaultQuery("slug", ServerCredentialTypeBMC)
+
+	exists, err := models.ServerCredentialTypes(models.ServerCredentialTypeWhere.Slug.EQ(slug)).Exists(c.Request.Context(), r.DB)
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	if !exists {
+		notFoundResponse(c, "server credential type not found")
+		return
+	}
+
+	mods := []qm.QueryMod{
+		qm.Where(fmt.Sprintf("NOT EXISTS (SELECT 1 FROM %s AS sc INNER JOIN %s AS t ON t.%s = sc.%s WHERE sc.%s = %s AND t.%s = ?)",
+			models.TableNames.ServerCredentials,
+			models.TableNames.ServerCredentialTypes,
+			models.ServerCredentialTypeColumns.ID,
+			models.ServerCredentialColumns.ServerCredentialTypeID,
+			models.ServerCredentialColumns.ServerID,
+			models.ServerTableColumns.ID,
+			models.ServerCredentialTypeColumns.Slug,
+		), slug),
+	}
+
+	count, err := models.Servers(mods...).Count(c.Request.Context(), r.DB)
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	pager.OrderBy = models.ServerTableColumns.CreatedAt + " DESC"
+	mods = append(mods, pager.queryMods()...)
+
+	dbSRV, err := models.Servers(mods...).All(c.Request.Context(), r.DB)
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	srvs := []Server{}
+
+	for _, dbS := range dbSRV {
+		s := Server{}
+		if err := s.fromDBModel(dbS); err != nil {
+			failedConvertingToVersioned(c, err)
+			return
+		}
+
+		srvs = append(srvs, s)
+	}
+
+	pd := paginationData{
+		pageCount:  len(srvs),
+		totalCount: count,
+		pager:      pager,
+	}
+
+	listResponse(c, srvs, pd)
+}
+
+// serverCredentialQueryMods returns the query mods to select the credential
+// identified by the uuid and slug params
+func serverCredentialQueryMods(c *gin.Context) []qm.QueryMod {
+	return []qm.QueryMod{
+		models.ServerCredentialWhere.ServerID.EQ(c.Param("uuid")),
+		qm.InnerJoin(fmt.Sprintf("%s as t on t.%s = %s.%s",
+			models.TableNames.ServerCredentialTypes,
+			models.ServerCredentialTypeColumns.ID,
+			models.TableNames.ServerCredentials,
+			models.ServerCredentialColumns.ServerCredentialTypeID,
+		)),
+		qm.Where(fmt.Sprintf("t.%s=?", models.ServerCredentialTypeColumns.Slug), c.Param("slug")),
+	}
+}
+
+// recordServerCredentialVersion stores the current value of the credential as a
+// new version created at createdAt, and prunes the versions beyond the number
+// retained by the router
+func (r *Router) recordServerCredentialVersion(ctx context.Context, exec boil.ContextExecutor, dbS *models.ServerCredential, createdBy string, createdAt time.Time) error {
+	var next int64 = 1
+
+	latest, err := models.ServerCredentialVersions(
+		models.ServerCredentialVersionWhere.ServerCredentialID.EQ(dbS.ID),
+		qm.OrderBy(models.ServerCredentialVersionColumns.Version+" DESC"),
+	).One(ctx, exec)
+
+	switch {
+	case err == nil:
+		next = latest.Version + 1
+	case !errors.Is(err, sql.ErrNoRows):
+		return err
+	}
+
+	v := models.ServerCredentialVersion{
+		ServerCredentialID: dbS.ID,
+		Version:            next,
+		Username:           dbS.Username,
+		Password:           dbS.Password,
+		CreatedBy:          createdBy,
+		CreatedAt:          createdAt,
+	}
+
+	if err := v.Insert(ctx, exec, boil.Infer()); err != nil {
+		return err
+	}
+
+	_, err = models.ServerCredentialVersions(
+		models.ServerCredentialVersionWhere.ServerCredentialID.EQ(dbS.ID),
+		models.ServerCredentialVersionWhere.Version.LTE(next-r.credentialVersionsRetained()),
+	).DeleteAll(ctx, exec)
+
+	return err
+}
+
+// credentialVersionsRetained returns the number of previous values kept for each credential
+func (r *Router) credentialVersionsRetained() int64 {
+	if r.CredentialVersionsRetained <= 0 {
+		return DefaultCredentialVersionsRetained
+	}
+
+	return int64(r.CredentialVersionsRetained)
 }
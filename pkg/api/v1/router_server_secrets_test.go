@@ -3,13 +3,16 @@ package serverservice_test
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"go.hollow.sh/serverservice/internal/dbtools"
+	"go.hollow.sh/serverservice/internal/models"
 	serverservice "go.hollow.sh/serverservice/pkg/api/v1"
 )
 
@@ -118,3 +121,234 @@ func TestIntegrationServerSecretsDelete(t *testing.T) {
 		return err
 	})
 }
+
+func TestIntegrationServerCredentialVersions(t *testing.T) {
+	s := serverTest(t)
+
+	realClientTests(t, func(ctx context.Context, authToken string, respCode int, expectError bool) error {
+		s.Client.SetToken(authToken)
+
+		id := uuid.MustParse(dbtools.FixtureNemo.ID)
+		slug := serverservice.ServerCredentialTypeBMC
+
+		versions, _, err := s.Client.ListCredentialVersions(ctx, id, slug)
+		if !expectError {
+			require.NoError(t, err)
+			assert.NotEmpty(t, versions)
+		}
+
+		return err
+	})
+
+	s.Client.SetToken(validToken(adminScopes))
+
+	t.Run("records a version for each update and rolls back", func(t *testing.T) {
+		ctx := context.TODO()
+		id := uuid.MustParse(dbtools.FixtureMarlin.ID)
+		slug := serverservice.ServerCredentialTypeBMC
+
+		_, err := s.Client.SetCredential(ctx, id, slug, "first", "first-secret")
+		require.NoError(t, err)
+
+		_, err = s.Client.SetCredential(ctx, id, slug, "second", "second-secret")
+		require.NoError(t, err)
+
+		versions, _, err := s.Client.ListCredentialVersions(ctx, id, slug)
+		require.NoError(t, err)
+		require.Len(t, versions, 2)
+		// newest version is returned first
+		assert.Equal(t, int64(2), versions[0].Version)
+		assert.Equal(t, "second", versions[0].Username)
+		assert.Equal(t, int64(1), versions[1].Version)
+		assert.Equal(t, "first", versions[1].Username)
+
+		db := dbtools.DatabaseTest(t)
+
+		// a notified credential is notified again once the restored value expires
+		_, err = models.ServerCredentials(
+			models.ServerCredentialWhere.ServerID.EQ(id.String()),
+		).UpdateAll(ctx, db, models.M{models.ServerCredentialColumns.ExpiryNotifiedAt: time.Now()})
+		require.NoError(t, err)
+
+		rolledBack := time.Now()
+
+		_, err = s.Client.RollbackCredential(ctx, id, slug, 1)
+		require.NoError(t, err)
+
+		dbS, err := models.ServerCredentials(models.ServerCredentialWhere.ServerID.EQ(id.String())).One(ctx, db)
+		require.NoError(t, err)
+		assert.False(t, dbS.ExpiryNotifiedAt.Valid)
+		// the restored value keeps the age it had
+		assert.WithinDuration(t, versions[1].CreatedAt, dbS.UpdatedAt, time.Millisecond)
+
+		secret, _, err := s.Client.GetCredential(ctx, id, slug)
+		require.NoError(t, err)
+		assert.Equal(t, "first", secret.Username)
+		assert.Equal(t, "first-secret", secret.Password)
+
+		versions, _, err = s.Client.ListCredentialVersions(ctx, id, slug)
+		require.NoError(t, err)
+		require.Len(t, versions, 3)
+		assert.Equal(t, int64(3), versions[0].Version)
+		assert.Equal(t, "first", versions[0].Username)
+		// the history shows when the rollback happened
+		assert.WithinDuration(t, rolledBack, versions[0].CreatedAt, 5*time.Second)
+		assert.True(t, versions[0].CreatedAt.After(versions[1].CreatedAt))
+	})
+
+	t.Run("only keeps the most recent versions", func(t *testing.T) {
+		ctx := context.TODO()
+		id := uuid.MustParse(dbtools.FixtureDory.ID)
+		slug := serverservice.ServerCredentialTypeBMC
+
+		for i := 0; i < 15; i++ {
+			_, err := s.Client.SetCredential(ctx, id, slug, "user", fmt.Sprintf("secret-%d", i))
+			require.NoError(t, err)
+		}
+
+		versions, _, err := s.Client.ListCredentialVersions(ctx, id, slug)
+		require.NoError(t, err)
+		assert.Len(t, versions, 10)
+		assert.Equal(t, int64(15), versions[0].Version)
+	})
+
+	t.Run("rollback fails if version not found", func(t *testing.T) {
+		ctx := context.TODO()
+		id := uuid.MustParse(dbtools.FixtureNemo.ID)
+
+		_, err := s.Client.RollbackCredential(ctx, id, serverservice.ServerCredentialTypeBMC, 1000)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not found")
+	})
+}
+
+func TestIntegrationServerCredentialsList(t *testing.T) {
+	s := serverTest(t)
+
+	scopedRealClientTests(t, []string{"read:server:credentials:metadata"}, func(ctx context.Context, authToken string, respCode int, expectError bool) error {
+		s.Client.SetToken(authToken)
+
+		creds, _, err := s.Client.ListCredentials(ctx, uuid.MustParse(dbtools.FixtureNemo.ID), nil)
+		if !expectError {
+			require.NoError(t, err)
+			require.Len(t, creds, 1)
+			assert.Equal(t, serverservice.ServerCredentialTypeBMC, creds[0].SecretType)
+		}
+
+		return err
+	})
+
+	t.Run("doesn't allow reading the secret with the metadata scope", func(t *testing.T) {
+		s.Client.SetToken(validToken([]string{"read:server:credentials:metadata"}))
+
+		_, _, err := s.Client.GetCredential(context.TODO(), uuid.MustParse(dbtools.FixtureNemo.ID), serverservice.ServerCredentialTypeBMC)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "403")
+	})
+
+	t.Run("fails if server uuid not found", func(t *testing.T) {
+		s.Client.SetToken(validToken(adminScopes))
+
+		_, _, err := s.Client.ListCredentials(context.TODO(), uuid.New(), nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "server not found")
+	})
+}
+
+func TestIntegrationServersMissingCredential(t *testing.T) {
+	s := serverTest(t)
+
+	scopedRealClientTests(t, []string{"read:server:credentials:metadata"}, func(ctx context.Context, authToken string, respCode int, expectError bool) error {
+		s.Client.SetToken(authToken)
+
+		srvs, _, err := s.Client.ListServersMissingCredential(ctx, serverservice.ServerCredentialTypeBMC, nil)
+		if !expectError {
+			require.NoError(t, err)
+
+			var ids []string
+			for _, srv := range srvs {
+				ids = append(ids, srv.UUID.String())
+			}
+
+			assert.Contains(t, ids, dbtools.FixtureMarlin.ID)
+			assert.Contains(t, ids, dbtools.FixtureDory.ID)
+			assert.NotContains(t, ids, dbtools.FixtureNemo.ID)
+			// deleted servers are not reported
+			assert.NotContains(t, ids, dbtools.FixtureChuckles.ID)
+		}
+
+		return err
+	})
+
+	t.Run("fails if secret type slug not found", func(t *testing.T) {
+		s.Client.SetToken(validToken(adminScopes))
+
+		_, _, err := s.Client.ListServersMissingCredential(context.TODO(), "notfound", nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not found")
+	})
+}
+
+func TestIntegrationServerCredentialsGenerate(t *testing.T) {
+	s := serverTest(t)
+
+	realClientTests(t, func(ctx context.Context, authToken string, respCode int, expectError bool) error {
+		s.Client.SetToken(authToken)
+
+		id := uuid.MustParse(dbtools.FixtureDory.ID)
+
+		secret, _, err := s.Client.GenerateCredential(ctx, id, serverservice.ServerCredentialTypeBMC, "root")
+		if !expectError {
+			require.NoError(t, err)
+			assert.Equal(t, "root", secret.Username)
+			assert.Len(t, secret.Password, serverservice.DefaultPasswordPolicy.Length)
+		}
+
+		return err
+	})
+
+	s.Client.SetToken(validToken(adminScopes))
+
+	t.Run("stores the generated password and keeps the username", func(t *testing.T) {
+		ctx := context.TODO()
+		id := uuid.MustParse(dbtools.FixtureMarlin.ID)
+		slug := serverservice.ServerCredentialTypeBMC
+
+		_, err := s.Client.SetCredential(ctx, id, slug, "admin", "not-generated")
+		require.NoError(t, err)
+
+		generated, _, err := s.Client.GenerateCredential(ctx, id, slug, "")
+		require.NoError(t, err)
+		assert.Equal(t, "admin", generated.Username)
+		assert.NotEqual(t, "not-generated", generated.Password)
+
+		secret, _, err := s.Client.GetCredential(ctx, id, slug)
+		require.NoError(t, err)
+		assert.Equal(t, generated.Password, secret.Password)
+	})
+
+	t.Run("uses the password policy of the secret type", func(t *testing.T) {
+		ctx := context.TODO()
+		id := uuid.MustParse(dbtools.FixtureMarlin.ID)
+
+		_, err := s.Client.CreateServerCredentialType(ctx, &serverservice.ServerCredentialType{
+			Name:           "Pin Code",
+			PasswordPolicy: &serverservice.PasswordPolicy{Length: 8, Digits: true},
+		})
+		require.NoError(t, err)
+
+		generated, _, err := s.Client.GenerateCredential(ctx, id, "pin-code", "")
+		require.NoError(t, err)
+		assert.Len(t, generated.Password, 8)
+		assert.Empty(t, strings.Trim(generated.Password, "0123456789"))
+	})
+
+	t.Run("fails to create a secret type with an invalid policy", func(t *testing.T) {
+		_, err := s.Client.CreateServerCredentialType(context.TODO(), &serverservice.ServerCredentialType{
+			Name:           "Invalid Policy",
+			PasswordPolicy: &serverservice.PasswordPolicy{Length: 8},
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid password policy")
+	})
+}
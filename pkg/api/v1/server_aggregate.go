@@ -0,0 +1,186 @@
+package serverservice
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/volatiletech/sqlboiler/v4/queries"
+
+	"go.hollow.sh/serverservice/internal/models"
+)
+
+const (
+	// maxAggregateGroupBy is the most fields servers can be grouped by in one request
+	maxAggregateGroupBy = 5
+
+	groupByFacilityCode    = "facility_code"
+	groupByComponentPrefix = "component."
+	groupByAttributePrefix = "attr:"
+)
+
+var errInvalidGroupBy = errors.New("invalid group_by")
+
+// componentGroupByColumns are the expressions used for the component fields servers can be grouped by
+var componentGroupByColumns = map[string]string{
+	"vendor": "sc.vendor",
+	"model":  "sc.model",
+	"name":   "sc.name",
+	"type":   "sct.slug",
+}
+
+// ServerAggregateParams selects the fields the servers are grouped by and filters
+// the servers that are counted
+type ServerAggregateParams struct {
+	// GroupBy lists the fields to group by. Accepted fields are facility_code,
+	// component.vendor, component.model, component.name, component.type and
+	// attr:<namespace>.<key> for a key in the attributes of a namespace.
+	GroupBy []string
+	Filter  *ServerListParams
+}
+
+func (p *ServerAggregateParams) setQuery(q url.Values) {
+	if p == nil {
+		return
+	}
+
+	p.Filter.setQuery(q)
+
+	if len(p.GroupBy) != 0 {
+		q.Set("group_by", strings.Join(p.GroupBy, ","))
+	}
+}
+
+// ServerAggregate is the number of servers for each combination of the group by values
+type ServerAggregate struct {
+	GroupBy []string               `json:"group_by"`
+	Groups  []ServerAggregateGroup `json:"groups"`
+}
+
+// ServerAggregateGroup is the number of servers with the same values for the group by
+// fields. A value is nil when the server doesn't have the field, like a server without
+// components or without the attribute.
+type ServerAggregateGroup struct {
+	Values map[string]*string `json:"values"`
+	Count  int64              `json:"count"`
+}
+
+// aggregateQuery builds the sql that counts the servers matching the filters
+// grouped by the fields
+type aggregateQuery struct {
+	columns    []string
+	joins      []string
+	args       []interface{}
+	attrTables map[string]string
+}
+
+// parseGroupBy splits the comma separated group_by query values into fields
+func parseGroupBy(values []string) ([]string, error) {
+	fields := []string{}
+
+	for _, v := range values {
+		for _, f := range strings.Split(v, ",") {
+			if f = strings.TrimSpace(f); f != "" {
+				fields = append(fields, f)
+			}
+		}
+	}
+
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("%w: at least one field is required", errInvalidGroupBy)
+	}
+
+	if len(fields) > maxAggregateGroupBy {
+		return nil, fmt.Errorf("%w: at most %d fields are allowed", errInvalidGroupBy, maxAggregateGroupBy)
+	}
+
+	return fields, nil
+}
+
+// newAggregateQuery returns the query for the fields, argOffset is the number of
+// arguments used by the filter query the aggregate is built on
+func newAggregateQuery(groupBy []string, argOffset int) (*aggregateQuery, error) {
+	q := &aggregateQuery{attrTables: map[string]string{}}
+
+	componentJoined := false
+
+	for _, field := range groupBy {
+		switch {
+		case field == groupByFacilityCode:
+			q.columns = append(q.columns, "f.facility_code")
+		case strings.HasPrefix(field, groupByComponentPrefix):
+			col, ok := componentGroupByColumns[strings.TrimPrefix(field, groupByComponentPrefix)]
+			if !ok {
+				return nil, fmt.Errorf("%w: unknown component field %q", errInvalidGroupBy, field)
+			}
+
+			if !componentJoined {
+				// servers are counted once for each distinct group of their components
+				q.joins = append(q.joins,
+					"LEFT JOIN server_components AS sc ON sc.server_id = f.id",
+					"LEFT JOIN server_component_types AS sct ON sct.id = sc.server_component_type_id",
+				)
+				componentJoined = true
+			}
+
+			q.columns = append(q.columns, col)
+		case strings.HasPrefix(field, groupByAttributePrefix):
+			// the namespace can contain dots, so the key is what follows the last one
+			attr := strings.TrimPrefix(field, groupByAttributePrefix)
+
+			i := strings.LastIndex(attr, ".")
+			if i <= 0 || i == len(attr)-1 {
+				return nil, fmt.Errorf("%w: attribute field %q must be attr:<namespace>.<key>", errInvalidGroupBy, field)
+			}
+
+			ns, key := attr[:i], attr[i+1:]
+
+			tbl, ok := q.attrTables[ns]
+			if !ok {
+				tbl = fmt.Sprintf("group_attr_%d", len(q.attrTables))
+				q.attrTables[ns] = tbl
+				q.args = append(q.args, ns)
+				q.joins = append(q.joins, fmt.Sprintf("LEFT JOIN attributes AS %s ON %s.server_id = f.id AND %s.namespace = $%d", tbl, tbl, tbl, argOffset+len(q.args)))
+			}
+
+			// the key is passed as an argument since it comes from the user
+			q.args = append(q.args, key)
+			q.columns = append(q.columns, fmt.Sprintf("json_extract_path_text(%s.data::JSONB, $%d)", tbl, argOffset+len(q.args)))
+		default:
+			return nil, fmt.Errorf("%w: unknown field %q", errInvalidGroupBy, field)
+		}
+	}
+
+	return q, nil
+}
+
+// aggregateSQL returns the sql and arguments counting the servers matching the list params
+// grouped by the fields
+func aggregateSQL(params ServerListParams, groupBy []string) (string, []interface{}, error) {
+	filterSQL, filterArgs := queries.BuildQuery(models.Servers(params.queryMods()...).Query)
+
+	q, err := newAggregateQuery(groupBy, len(filterArgs))
+	if err != nil {
+		return "", nil, err
+	}
+
+	selects := make([]string, 0, len(q.columns))
+	ordinals := make([]string, 0, len(q.columns))
+
+	for i, col := range q.columns {
+		selects = append(selects, fmt.Sprintf("%s AS g%d", col, i))
+		ordinals = append(ordinals, strconv.Itoa(i+1))
+	}
+
+	stmt := fmt.Sprintf("WITH f AS (%s) SELECT %s, count(DISTINCT f.id) AS count FROM f %s GROUP BY %s ORDER BY count DESC, %s",
+		strings.TrimSuffix(filterSQL, ";"),
+		strings.Join(selects, ", "),
+		strings.Join(q.joins, " "),
+		strings.Join(ordinals, ", "),
+		strings.Join(ordinals, ", "),
+	)
+
+	return stmt, append(filterArgs, q.args...), nil
+}
@@ -0,0 +1,45 @@
+package serverservice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregateSQL(t *testing.T) {
+	params := ServerListParams{
+		FacilityCode: "Ocean",
+		AttributeListParams: []AttributeListParams{
+			{Namespace: "hollow.metadata", Keys: []string{"age"}, Operator: OperatorLessThan, Value: "7"},
+		},
+	}
+
+	stmt, args, err := aggregateSQL(params, []string{"component.vendor", "attr:hollow.other_data.type", "attr:hollow.other_data.enabled"})
+	require.NoError(t, err)
+
+	// the group by arguments are numbered after the filter arguments
+	require.Len(t, args, 7)
+	assert.Equal(t, []interface{}{"hollow.metadata", "age", "7", "hollow.other_data", "type", "enabled"}, args[1:])
+	assert.Contains(t, stmt, "$4")
+	assert.Contains(t, stmt, "LEFT JOIN attributes AS group_attr_0 ON group_attr_0.server_id = f.id AND group_attr_0.namespace = $5")
+	assert.Contains(t, stmt, "json_extract_path_text(group_attr_0.data::JSONB, $6) AS g1, json_extract_path_text(group_attr_0.data::JSONB, $7) AS g2")
+	assert.Contains(t, stmt, "sc.vendor AS g0")
+	assert.Contains(t, stmt, "GROUP BY 1, 2, 3")
+	assert.NotContains(t, stmt, ";")
+}
+
+func TestParseGroupBy(t *testing.T) {
+	fields, err := parseGroupBy([]string{"facility_code, component.vendor", "attr:ns.key"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"facility_code", "component.vendor", "attr:ns.key"}, fields)
+
+	_, err = parseGroupBy([]string{""})
+	assert.ErrorIs(t, err, errInvalidGroupBy)
+
+	_, err = parseGroupBy([]string{"a,b,c,d,e,f"})
+	assert.ErrorIs(t, err, errInvalidGroupBy)
+
+	_, err = newAggregateQuery([]string{"component.serial"}, 0)
+	assert.ErrorIs(t, err, errInvalidGroupBy)
+}
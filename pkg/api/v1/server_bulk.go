@@ -0,0 +1,256 @@
+package serverservice
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// ServerBulkMode controls what happens to the other records of a bulk import when a record fails
+type ServerBulkMode string
+
+const (
+	// ServerBulkAtomic writes all the records in one transaction, nothing is written
+	// when any record is invalid or fails to write
+	ServerBulkAtomic ServerBulkMode = "atomic"
+	// ServerBulkPerRecord writes each valid record in its own transaction and reports
+	// the result of every record
+	ServerBulkPerRecord ServerBulkMode = "record"
+
+	// ServerBulkCreated is the status of a record that created a server
+	ServerBulkCreated = "created"
+	// ServerBulkUpdated is the status of a record that updated an existing server
+	ServerBulkUpdated = "updated"
+	// ServerBulkFailed is the status of a record that is invalid or failed to write
+	ServerBulkFailed = "failed"
+	// ServerBulkSkipped is the status of a valid record that wasn't written because
+	// another record of an atomic import failed
+	ServerBulkSkipped = "skipped"
+
+	// maxBulkRecords is the most records accepted in one bulk import
+	maxBulkRecords = 10000
+	// maxBulkRecordSize is the longest line accepted for a record
+	maxBulkRecordSize = 4 * 1024 * 1024
+	// maxBulkBodySize is the largest request body accepted for a bulk import
+	maxBulkBodySize = 64 * 1024 * 1024
+)
+
+var (
+	errBulkRecord   = errors.New("invalid bulk record")
+	errBulkRequest  = errors.New("invalid bulk request")
+	errBulkTooLarge = errors.New("bulk request too large")
+)
+
+// ServerBulkRecord describes a server along with its components, attributes and
+// credentials. Records are upserted by the server UUID, a new server is created
+// when the UUID is empty.
+type ServerBulkRecord struct {
+	Server
+	Credentials []ServerBulkCredential `json:"credentials"`
+}
+
+// ServerBulkCredential is a credential of a server in a bulk import
+type ServerBulkCredential struct {
+	SecretType string `json:"secret_type"`
+	Username   string `json:"username"`
+	Password   string `json:"password"`
+}
+
+// ServerBulkResult is the outcome of a bulk import
+type ServerBulkResult struct {
+	Mode    ServerBulkMode           `json:"mode"`
+	Created int                      `json:"created"`
+	Updated int                      `json:"updated"`
+	Failed  int                      `json:"failed"`
+	Records []ServerBulkRecordResult `json:"records"`
+}
+
+// ServerBulkRecordResult is the outcome of the record on a line of a bulk import
+type ServerBulkRecordResult struct {
+	Line   int       `json:"line"`
+	UUID   uuid.UUID `json:"uuid"`
+	Status string    `json:"status"`
+	Error  string    `json:"error,omitempty"`
+}
+
+// bulkRecord is a record read from a bulk import along with its line number and
+// the error found when parsing or validating it
+type bulkRecord struct {
+	line   int
+	record ServerBulkRecord
+	err    error
+}
+
+// readBulkRecords reads the NDJSON records, blank lines are ignored. Records that
+// can't be decoded are returned with an error.
+func readBulkRecords(body io.Reader) ([]*bulkRecord, error) {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxBulkRecordSize)
+
+	records := []*bulkRecord{}
+	line := 0
+
+	for scanner.Scan() {
+		line++
+
+		data := strings.TrimSpace(scanner.Text())
+		if data == "" {
+			continue
+		}
+
+		if len(records) == maxBulkRecords {
+			return nil, fmt.Errorf("%w: more than %d records", errBulkRequest, maxBulkRecords)
+		}
+
+		rec := &bulkRecord{line: line}
+
+		if err := json.Unmarshal([]byte(data), &rec.record); err != nil {
+			rec.err = fmt.Errorf("%w: %s", errBulkRecord, err)
+		}
+
+		records = append(records, rec)
+	}
+
+	if err := scanner.Err(); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return nil, fmt.Errorf("%w: more than %d bytes", errBulkTooLarge, tooLarge.Limit)
+		}
+
+		return nil, fmt.Errorf("%w: %s", errBulkRequest, err)
+	}
+
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%w: no records", errBulkRequest)
+	}
+
+	return records, nil
+}
+
+// bulkTypes are the component and credential types known when the records are validated
+type bulkTypes struct {
+	componentTypeIDs   map[string]bool
+	componentTypeSlugs map[string]string
+	credentialTypes    map[string]string
+}
+
+// validate checks the record can be written, setting the server UUID when it's
+// empty and the component type ids from their slugs
+func (t *bulkTypes) validate(rec *ServerBulkRecord) error {
+	if rec.UUID == uuid.Nil {
+		rec.UUID = uuid.New()
+	}
+
+	if err := validateBulkAttributes("attributes", rec.Attributes); err != nil {
+		return err
+	}
+
+	if err := validateBulkVersionedAttributes("versioned attributes", rec.VersionedAttributes); err != nil {
+		return err
+	}
+
+	components := map[string]bool{}
+
+	for i := range rec.Components {
+		sc := &rec.Components[i]
+
+		if sc.Name == "" || sc.Serial == "" {
+			return fmt.Errorf("%w: component %d requires a name and serial", errBulkRecord, i)
+		}
+
+		switch {
+		case sc.ComponentTypeSlug != "":
+			id, ok := t.componentTypeSlugs[sc.ComponentTypeSlug]
+			if !ok {
+				return fmt.Errorf("%w: component %d: unknown component type %q", errBulkRecord, i, sc.ComponentTypeSlug)
+			}
+
+			sc.ComponentTypeID = id
+		case sc.ComponentTypeID != "":
+			if !t.componentTypeIDs[sc.ComponentTypeID] {
+				return fmt.Errorf("%w: component %d: unknown component type id %q", errBulkRecord, i, sc.ComponentTypeID)
+			}
+		default:
+			return fmt.Errorf("%w: component %d requires a component type", errBulkRecord, i)
+		}
+
+		key := sc.ComponentTypeID + "/" + sc.Serial
+		if components[key] {
+			return fmt.Errorf("%w: component %d: duplicate serial %q for the component type", errBulkRecord, i, sc.Serial)
+		}
+
+		components[key] = true
+
+		if err := validateBulkAttributes(fmt.Sprintf("component %d attributes", i), sc.Attributes); err != nil {
+			return err
+		}
+
+		if err := validateBulkVersionedAttributes(fmt.Sprintf("component %d versioned attributes", i), sc.VersionedAttributes); err != nil {
+			return err
+		}
+	}
+
+	credentials := map[string]bool{}
+
+	for _, cred := range rec.Credentials {
+		if _, ok := t.credentialTypes[cred.SecretType]; !ok {
+			return fmt.Errorf("%w: unknown credential type %q", errBulkRecord, cred.SecretType)
+		}
+
+		if credentials[cred.SecretType] {
+			return fmt.Errorf("%w: duplicate credential type %q", errBulkRecord, cred.SecretType)
+		}
+
+		credentials[cred.SecretType] = true
+	}
+
+	return nil
+}
+
+func validateBulkAttributes(name string, attrs []Attributes) error {
+	namespaces := map[string]bool{}
+
+	for _, a := range attrs {
+		if err := validateBulkNamespace(name, namespaces, a.Namespace, a.Data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateBulkVersionedAttributes(name string, attrs []VersionedAttributes) error {
+	namespaces := map[string]bool{}
+
+	for _, a := range attrs {
+		if err := validateBulkNamespace(name, namespaces, a.Namespace, a.Data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateBulkNamespace(name string, seen map[string]bool, ns string, data json.RawMessage) error {
+	if ns == "" {
+		return fmt.Errorf("%w: %s require a namespace", errBulkRecord, name)
+	}
+
+	if seen[ns] {
+		return fmt.Errorf("%w: %s: duplicate namespace %q", errBulkRecord, name, ns)
+	}
+
+	seen[ns] = true
+
+	if len(data) == 0 || !json.Valid(data) {
+		return fmt.Errorf("%w: %s: namespace %q requires JSON data", errBulkRecord, name, ns)
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package serverservice
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadBulkRecords(t *testing.T) {
+	body := `{"uuid":"ad1e1b1b-bd88-44dd-a2c8-5f8dd2d7cce6","name":"nemo"}
+
+{"uuid":"not-a-uuid"}
+{"name":"dory"}
+`
+
+	records, err := readBulkRecords(strings.NewReader(body))
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+
+	assert.Equal(t, 1, records[0].line)
+	assert.NoError(t, records[0].err)
+	assert.Equal(t, "nemo", records[0].record.Name)
+
+	assert.Equal(t, 3, records[1].line)
+	assert.ErrorIs(t, records[1].err, errBulkRecord)
+
+	assert.Equal(t, 4, records[2].line)
+	assert.Equal(t, uuid.Nil, records[2].record.UUID)
+
+	_, err = readBulkRecords(strings.NewReader("\n\n"))
+	assert.ErrorIs(t, err, errBulkRequest)
+
+	body = strings.Repeat(`{"name":"nemo"}`+"\n", 10)
+	_, err = readBulkRecords(http.MaxBytesReader(httptest.NewRecorder(), io.NopCloser(strings.NewReader(body)), 32))
+	assert.ErrorIs(t, err, errBulkTooLarge)
+}
+
+func TestBulkTypesValidate(t *testing.T) {
+	known := &bulkTypes{
+		componentTypeIDs:   map[string]bool{"fin-id": true},
+		componentTypeSlugs: map[string]string{"fins": "fin-id"},
+		credentialTypes:    map[string]string{"bmc": "bmc-id"},
+	}
+
+	fin := func(serial string) ServerComponent {
+		return ServerComponent{Name: "fin", Serial: serial, ComponentTypeSlug: "fins"}
+	}
+
+	testCases := []struct {
+		name     string
+		record   ServerBulkRecord
+		errorMsg string
+	}{
+		{
+			"valid record",
+			ServerBulkRecord{
+				Server: Server{
+					Components: []ServerComponent{fin("left"), fin("right")},
+					Attributes: []Attributes{{Namespace: "ns", Data: json.RawMessage(`{"a":1}`)}},
+				},
+				Credentials: []ServerBulkCredential{{SecretType: "bmc", Username: "root", Password: "hunter2"}},
+			},
+			"",
+		},
+		{
+			"unknown component type",
+			ServerBulkRecord{Server: Server{Components: []ServerComponent{{Name: "fin", Serial: "1", ComponentTypeSlug: "gills"}}}},
+			`unknown component type "gills"`,
+		},
+		{
+			"missing component serial",
+			ServerBulkRecord{Server: Server{Components: []ServerComponent{fin("")}}},
+			"requires a name and serial",
+		},
+		{
+			"duplicate component",
+			ServerBulkRecord{Server: Server{Components: []ServerComponent{fin("left"), fin("left")}}},
+			`duplicate serial "left"`,
+		},
+		{
+			"attributes without data",
+			ServerBulkRecord{Server: Server{Attributes: []Attributes{{Namespace: "ns"}}}},
+			`namespace "ns" requires JSON data`,
+		},
+		{
+			"duplicate versioned attributes",
+			ServerBulkRecord{Server: Server{VersionedAttributes: []VersionedAttributes{
+				{Namespace: "ns", Data: json.RawMessage(`{}`)},
+				{Namespace: "ns", Data: json.RawMessage(`{}`)},
+			}}},
+			`duplicate namespace "ns"`,
+		},
+		{
+			"unknown credential type",
+			ServerBulkRecord{Credentials: []ServerBulkCredential{{SecretType: "ipmi"}}},
+			`unknown credential type "ipmi"`,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := known.validate(&tt.record)
+			if tt.errorMsg != "" {
+				assert.ErrorIs(t, err, errBulkRecord)
+				assert.ErrorContains(t, err, tt.errorMsg)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.NotEqual(t, uuid.Nil, tt.record.UUID)
+			assert.Equal(t, "fin-id", tt.record.Components[0].ComponentTypeID)
+		})
+	}
+}
@@ -29,6 +29,13 @@ func (t *ServerComponentType) toDBModel() (*models.ServerComponentType, error) {
 	return dbT, nil
 }
 
+// ServerComponentTypeMergeRequest is the payload to merge a server component type
+// into another type
+type ServerComponentTypeMergeRequest struct {
+	// Into is the slug of the type the server components are moved to
+	Into string `json:"into" binding:"required"`
+}
+
 // ServerComponentTypeSlice is a slice of the ServerComponentType
 type ServerComponentTypeSlice []*ServerComponentType
 
@@ -2,6 +2,7 @@ package serverservice
 
 import (
 	"context"
+	"path"
 )
 
 const (
@@ -24,3 +25,25 @@ func (c *Client) ListServerComponentTypes(ctx context.Context, params *ServerCom
 
 	return *cts, &resp, nil
 }
+
+// UpdateServerComponentType will update the name and slug of the server component type with the given slug
+func (c *Client) UpdateServerComponentType(ctx context.Context, slug string, t ServerComponentType) (*ServerResponse, error) {
+	return c.put(ctx, path.Join(serverComponentTypeEndpoint, slug), t)
+}
+
+// DeleteServerComponentType will delete the server component type with the given slug. When force
+// is true any server components of that type are deleted with it.
+func (c *Client) DeleteServerComponentType(ctx context.Context, slug string, force bool) (*ServerResponse, error) {
+	p := path.Join(serverComponentTypeEndpoint, slug)
+	if force {
+		p += "?force=true"
+	}
+
+	return c.delete(ctx, p)
+}
+
+// MergeServerComponentType will move all the server components of the type with the given slug
+// to the type with the into slug and delete the merged type
+func (c *Client) MergeServerComponentType(ctx context.Context, slug, into string) (*ServerResponse, error) {
+	return c.post(ctx, path.Join(serverComponentTypeEndpoint, slug, "merge"), ServerComponentTypeMergeRequest{Into: into})
+}
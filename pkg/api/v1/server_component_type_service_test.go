@@ -41,3 +41,42 @@ func TestServerComponentTypeServiceList(t *testing.T) {
 		return err
 	})
 }
+
+func TestServerComponentTypeServiceUpdate(t *testing.T) {
+	mockClientTests(t, func(ctx context.Context, respCode int, expectError bool) error {
+		jsonResponse := json.RawMessage([]byte(`{"message": "resource updated", "slug":"slug-2"}`))
+
+		c := mockClient(string(jsonResponse), respCode)
+		resp, err := c.UpdateServerComponentType(ctx, "slug-1", hollow.ServerComponentType{Name: "slug 2"})
+		if !expectError {
+			assert.Equal(t, "slug-2", resp.Slug)
+		}
+
+		return err
+	})
+}
+
+func TestServerComponentTypeServiceDelete(t *testing.T) {
+	mockClientTests(t, func(ctx context.Context, respCode int, expectError bool) error {
+		jsonResponse := json.RawMessage([]byte(`{"message": "resource deleted"}`))
+
+		c := mockClient(string(jsonResponse), respCode)
+		_, err := c.DeleteServerComponentType(ctx, "slug-1", true)
+
+		return err
+	})
+}
+
+func TestServerComponentTypeServiceMerge(t *testing.T) {
+	mockClientTests(t, func(ctx context.Context, respCode int, expectError bool) error {
+		jsonResponse := json.RawMessage([]byte(`{"message": "resource updated", "slug":"slug-2"}`))
+
+		c := mockClient(string(jsonResponse), respCode)
+		resp, err := c.MergeServerComponentType(ctx, "slug-1", "slug-2")
+		if !expectError {
+			assert.Equal(t, "slug-2", resp.Slug)
+		}
+
+		return err
+	})
+}
@@ -0,0 +1,215 @@
+package serverservice
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ServerExportFormat is the encoding of a server export
+type ServerExportFormat string
+
+const (
+	// ServerExportNDJSON exports one JSON encoded server per line
+	ServerExportNDJSON ServerExportFormat = "ndjson"
+	// ServerExportCSV exports one server per row with a header row. Components and
+	// attributes are JSON encoded in their columns.
+	ServerExportCSV ServerExportFormat = "csv"
+
+	exportIncludeComponents = "components"
+	exportIncludeAttributes = "attributes"
+
+	// ExportErrorTrailer is the trailer set when an export fails after the response started
+	ExportErrorTrailer = "X-Export-Error"
+)
+
+var (
+	errInvalidExportFormat  = errors.New("invalid export format")
+	errInvalidExportInclude = errors.New("invalid export include")
+	// ErrExportIncomplete is returned when reading an export the server failed to finish
+	ErrExportIncomplete = errors.New("server export incomplete")
+)
+
+// ServerExportParams selects the format and filters of a server export
+type ServerExportParams struct {
+	Format ServerExportFormat
+	// IncludeComponents adds the components of each server along with their attributes
+	IncludeComponents bool
+	// IncludeAttributes adds the attributes and latest versioned attributes of each server
+	IncludeAttributes bool
+	Filter            *ServerListParams
+}
+
+func (p *ServerExportParams) setQuery(q url.Values) {
+	if p == nil {
+		return
+	}
+
+	p.Filter.setQuery(q)
+
+	if p.Format != "" {
+		q.Set("format", string(p.Format))
+	}
+
+	include := []string{}
+
+	if p.IncludeComponents {
+		include = append(include, exportIncludeComponents)
+	}
+
+	if p.IncludeAttributes {
+		include = append(include, exportIncludeAttributes)
+	}
+
+	if len(include) != 0 {
+		q.Set("include", strings.Join(include, ","))
+	}
+}
+
+// parseExportInclude returns if components and attributes are included from the
+// comma separated include query param
+func parseExportInclude(include string) (components, attributes bool, err error) {
+	for _, i := range strings.Split(include, ",") {
+		switch strings.TrimSpace(i) {
+		case "":
+		case exportIncludeComponents:
+			components = true
+		case exportIncludeAttributes:
+			attributes = true
+		default:
+			return false, false, fmt.Errorf("%w: %q", errInvalidExportInclude, i)
+		}
+	}
+
+	return components, attributes, nil
+}
+
+// serverExportWriter encodes servers to an export
+type serverExportWriter interface {
+	Write(srv *Server) error
+	// Flush writes any buffered data to the underlying writer
+	Flush() error
+}
+
+func newServerExportWriter(format ServerExportFormat, w io.Writer, components, attributes bool) (serverExportWriter, error) {
+	switch format {
+	case ServerExportNDJSON:
+		enc := json.NewEncoder(w)
+		enc.SetEscapeHTML(false)
+
+		return &ndjsonExportWriter{enc: enc}, nil
+	case ServerExportCSV:
+		cw := &csvExportWriter{w: csv.NewWriter(w), components: components, attributes: attributes}
+
+		return cw, cw.writeHeader()
+	default:
+		return nil, fmt.Errorf("%w: %q", errInvalidExportFormat, format)
+	}
+}
+
+// contentType returns the media type of the export format
+func (f ServerExportFormat) contentType() string {
+	if f == ServerExportCSV {
+		return "text/csv; charset=utf-8"
+	}
+
+	return "application/x-ndjson"
+}
+
+type ndjsonExportWriter struct {
+	enc *json.Encoder
+}
+
+func (w *ndjsonExportWriter) Write(srv *Server) error {
+	return w.enc.Encode(srv)
+}
+
+func (w *ndjsonExportWriter) Flush() error {
+	return nil
+}
+
+type csvExportWriter struct {
+	w          *csv.Writer
+	components bool
+	attributes bool
+}
+
+func (w *csvExportWriter) writeHeader() error {
+	header := []string{"uuid", "name", "facility", "created_at", "updated_at", "deleted_at"}
+
+	if w.components {
+		header = append(header, "components")
+	}
+
+	if w.attributes {
+		header = append(header, "attributes", "versioned_attributes")
+	}
+
+	return w.w.Write(header)
+}
+
+func (w *csvExportWriter) Write(srv *Server) error {
+	deletedAt := ""
+	if srv.DeletedAt != nil {
+		deletedAt = srv.DeletedAt.Format(time.RFC3339Nano)
+	}
+
+	record := []string{
+		srv.UUID.String(),
+		srv.Name,
+		srv.FacilityCode,
+		srv.CreatedAt.Format(time.RFC3339Nano),
+		srv.UpdatedAt.Format(time.RFC3339Nano),
+		deletedAt,
+	}
+
+	nested := []interface{}{}
+
+	if w.components {
+		nested = append(nested, srv.Components)
+	}
+
+	if w.attributes {
+		nested = append(nested, srv.Attributes, srv.VersionedAttributes)
+	}
+
+	for _, n := range nested {
+		b, err := json.Marshal(n)
+		if err != nil {
+			return err
+		}
+
+		record = append(record, string(b))
+	}
+
+	return w.w.Write(record)
+}
+
+func (w *csvExportWriter) Flush() error {
+	w.w.Flush()
+
+	return w.w.Error()
+}
+
+// exportReader returns ErrExportIncomplete at the end of the export when the server
+// reported an error in the trailer
+type exportReader struct {
+	io.ReadCloser
+	trailer func() string
+}
+
+func (r *exportReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if errors.Is(err, io.EOF) {
+		if msg := r.trailer(); msg != "" {
+			return n, fmt.Errorf("%w: %s", ErrExportIncomplete, msg)
+		}
+	}
+
+	return n, err
+}
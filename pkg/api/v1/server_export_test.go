@@ -0,0 +1,76 @@
+package serverservice
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCSVExportWriter(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	w, err := newServerExportWriter(ServerExportCSV, buf, true, false)
+	require.NoError(t, err)
+
+	created := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	srv := &Server{
+		UUID:         uuid.MustParse("ad1e1b1b-bd88-44dd-a2c8-5f8dd2d7cce6"),
+		Name:         "nemo",
+		FacilityCode: "Sydney, AU",
+		CreatedAt:    created,
+		UpdatedAt:    created,
+		Components:   []ServerComponent{{Name: "fin", Vendor: "Barracuda"}},
+	}
+
+	require.NoError(t, w.Write(srv))
+	require.NoError(t, w.Flush())
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	require.Len(t, lines, 2)
+	assert.Equal(t, "uuid,name,facility,created_at,updated_at,deleted_at,components", string(lines[0]))
+	assert.Contains(t, string(lines[1]), `ad1e1b1b-bd88-44dd-a2c8-5f8dd2d7cce6,nemo,"Sydney, AU",2023-01-02T03:04:05Z,2023-01-02T03:04:05Z,,`)
+	assert.Contains(t, string(lines[1]), `""vendor"":""Barracuda""`)
+
+	_, err = newServerExportWriter("xml", buf, false, false)
+	assert.ErrorIs(t, err, errInvalidExportFormat)
+}
+
+func TestParseExportInclude(t *testing.T) {
+	components, attributes, err := parseExportInclude("components, attributes")
+	require.NoError(t, err)
+	assert.True(t, components)
+	assert.True(t, attributes)
+
+	_, _, err = parseExportInclude("firmware")
+	assert.ErrorIs(t, err, errInvalidExportInclude)
+}
+
+func TestExportReaderTrailer(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Trailer", ExportErrorTrailer)
+		_, _ = w.Write([]byte("{}\n"))
+		w.(http.Flusher).Flush()
+		w.Header().Set(ExportErrorTrailer, "datastore error")
+	}))
+	defer ts.Close()
+
+	c, err := NewClientWithToken("token", ts.URL, nil)
+	require.NoError(t, err)
+
+	r, err := c.Export(context.TODO(), nil)
+	require.NoError(t, err)
+
+	defer r.Close()
+
+	data, err := io.ReadAll(r)
+	assert.Equal(t, "{}\n", string(data))
+	assert.ErrorIs(t, err, ErrExportIncomplete)
+}
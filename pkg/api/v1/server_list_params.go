@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/url"
 
+	"github.com/gin-gonic/gin"
 	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 
@@ -20,6 +21,29 @@ type ServerListParams struct {
 	PaginationParams             *PaginationParams
 }
 
+// bindServerListParams parses the server filters from the query params. When they are
+// invalid a bad request response is written and false is returned.
+func bindServerListParams(c *gin.Context) (ServerListParams, bool) {
+	var params ServerListParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		badRequestResponse(c, "invalid filter", err)
+		return params, false
+	}
+
+	params.AttributeListParams = parseQueryAttributesListParams(c, "attr")
+	params.VersionedAttributeListParams = parseQueryAttributesListParams(c, "ver_attr")
+
+	sclp, err := parseQueryServerComponentsListParams(c)
+	if err != nil {
+		badRequestResponse(c, "invalid server component list params", err)
+		return params, false
+	}
+
+	params.ComponentListParams = sclp
+
+	return params, true
+}
+
 func (p *ServerListParams) setQuery(q url.Values) {
 	if p == nil {
 		return
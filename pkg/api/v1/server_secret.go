@@ -1,9 +1,12 @@
 package serverservice
 
 import (
+	"net/url"
 	"time"
 
 	"github.com/google/uuid"
+
+	"go.hollow.sh/serverservice/internal/models"
 )
 
 // ServerCredential provides a way to encrypt secrets about a server in the database
@@ -16,7 +19,95 @@ type ServerCredential struct {
 	UpdatedAt  time.Time `json:"updated_at"`
 }
 
+// ServerCredentialMetadata describes a credential stored for a server without the secret value
+type ServerCredentialMetadata struct {
+	ServerID   uuid.UUID `json:"uuid,omitempty"`
+	SecretType string    `json:"secret_type"`
+	Username   string    `json:"username"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+func (m *ServerCredentialMetadata) fromDBModel(dbS *models.ServerCredential) error {
+	var err error
+
+	m.ServerID, err = uuid.Parse(dbS.ServerID)
+	if err != nil {
+		return err
+	}
+
+	if dbS.R != nil && dbS.R.ServerCredentialType != nil {
+		m.SecretType = dbS.R.ServerCredentialType.Slug
+	}
+
+	m.Username = dbS.Username
+	m.CreatedAt = dbS.CreatedAt
+	m.UpdatedAt = dbS.UpdatedAt
+
+	return nil
+}
+
+// ServerCredentialRotationDue describes a server credential that is older than the max age
+// of its credential type and is due to be rotated
+type ServerCredentialRotationDue struct {
+	ServerID   uuid.UUID `json:"uuid,omitempty"`
+	SecretType string    `json:"secret_type"`
+	Username   string    `json:"username"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	MaxAge     int64     `json:"max_age"`
+	DueAt      time.Time `json:"due_at"`
+}
+
+func (d *ServerCredentialRotationDue) fromDBModel(dbS *models.ServerCredential) error {
+	var err error
+
+	d.ServerID, err = uuid.Parse(dbS.ServerID)
+	if err != nil {
+		return err
+	}
+
+	if dbS.R != nil && dbS.R.ServerCredentialType != nil {
+		d.SecretType = dbS.R.ServerCredentialType.Slug
+		d.MaxAge = dbS.R.ServerCredentialType.MaxAge.Int64
+	}
+
+	d.Username = dbS.Username
+	d.UpdatedAt = dbS.UpdatedAt
+	d.DueAt = dbS.UpdatedAt.Add(time.Duration(d.MaxAge) * time.Second)
+
+	return nil
+}
+
 type serverCredentialValues struct {
 	Password string `json:"password"`
 	Username string `json:"username"`
 }
+
+// ServerCredentialVersion describes a previous value of a server credential. The
+// password is never returned, only who set it and when.
+type ServerCredentialVersion struct {
+	Version   int64     `json:"version"`
+	Username  string    `json:"username"`
+	CreatedBy string    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (v *ServerCredentialVersion) fromDBModel(dbV *models.ServerCredentialVersion) {
+	v.Version = dbV.Version
+	v.Username = dbV.Username
+	v.CreatedBy = dbV.CreatedBy
+	v.CreatedAt = dbV.CreatedAt
+}
+
+type credentialSlugParams struct {
+	Slug       string
+	Pagination *PaginationParams
+}
+
+func (p *credentialSlugParams) setQuery(q url.Values) {
+	if p.Slug != "" {
+		q.Set("slug", p.Slug)
+	}
+
+	p.Pagination.setQuery(q)
+}
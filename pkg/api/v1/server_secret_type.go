@@ -1,8 +1,11 @@
 package serverservice
 
 import (
+	"encoding/json"
 	"time"
 
+	"github.com/volatiletech/null/v8"
+
 	"go.hollow.sh/serverservice/internal/models"
 )
 
@@ -20,12 +23,62 @@ type ServerCredentialType struct {
 	Builtin   bool      `json:"builtin"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+	// PasswordPolicy is used to generate passwords for credentials of this type,
+	// the DefaultPasswordPolicy is used when it isn't set
+	PasswordPolicy *PasswordPolicy `json:"password_policy,omitempty"`
+	// MaxAge is the number of seconds a credential of this type can go without being
+	// changed before it is due for rotation, zero means the credentials never expire
+	MaxAge int64 `json:"max_age,omitempty"`
 }
 
-func (t *ServerCredentialType) fromDBModel(dbT *models.ServerCredentialType) {
+func (t *ServerCredentialType) fromDBModel(dbT *models.ServerCredentialType) error {
 	t.Name = dbT.Name
 	t.Slug = dbT.Slug
 	t.Builtin = dbT.Builtin
 	t.CreatedAt = dbT.CreatedAt
 	t.UpdatedAt = dbT.UpdatedAt
+	t.MaxAge = dbT.MaxAge.Int64
+
+	if dbT.PasswordPolicy.Valid {
+		t.PasswordPolicy = &PasswordPolicy{}
+		if err := json.Unmarshal(dbT.PasswordPolicy.JSON, t.PasswordPolicy); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (t *ServerCredentialType) toDBModel() (*models.ServerCredentialType, error) {
+	dbT := &models.ServerCredentialType{
+		Name:      t.Name,
+		Slug:      t.Slug,
+		Builtin:   t.Builtin,
+		CreatedAt: t.CreatedAt,
+		UpdatedAt: t.UpdatedAt,
+	}
+
+	if t.MaxAge != 0 {
+		dbT.MaxAge = null.Int64From(t.MaxAge)
+	}
+
+	if t.PasswordPolicy != nil {
+		policy, err := json.Marshal(t.PasswordPolicy)
+		if err != nil {
+			return nil, err
+		}
+
+		dbT.PasswordPolicy = null.JSONFrom(policy)
+	}
+
+	return dbT, nil
+}
+
+// passwordPolicy returns the policy used to generate passwords for the credential type
+func (t *ServerCredentialType) passwordPolicy() PasswordPolicy {
+	if t.PasswordPolicy == nil {
+		return DefaultPasswordPolicy
+	}
+
+	return *t.PasswordPolicy
 }
@@ -4,20 +4,29 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"path"
+	"strconv"
 
 	"github.com/google/uuid"
 )
 
 const (
 	serversEndpoint                     = "servers"
+	serversAggregateEndpoint            = "servers/aggregate"
+	serversExportEndpoint               = "servers/export"
+	serversBulkEndpoint                 = "servers/bulk"
 	serverAttributesEndpoint            = "attributes"
 	serverComponentsEndpoint            = "components"
 	serverVersionedAttributesEndpoint   = "versioned-attributes"
+	serverFirmwarePlanEndpoint          = "firmware-plan"
 	serverComponentFirmwaresEndpoint    = "server-component-firmwares"
 	serverCredentialsEndpoint           = "credentials"
+	serverCredentialVersionsEndpoint    = "versions"
 	serverCredentialTypeEndpoint        = "server-credential-types"
 	serverComponentFirmwareSetsEndpoint = "server-component-firmware-sets"
+	credentialAccessAuditEndpoint       = "audit/credential-access"
+	credentialRotationDueEndpoint       = "credentials/rotation-due"
 )
 
 // ClientInterface provides an interface for the expected calls to interact with a server service api
@@ -26,6 +35,10 @@ type ClientInterface interface {
 	Delete(context.Context, Server) (*ServerResponse, error)
 	Get(context.Context, uuid.UUID) (*Server, *ServerResponse, error)
 	List(context.Context, *ServerListParams) ([]Server, *ServerResponse, error)
+	Aggregate(context.Context, *ServerAggregateParams) (*ServerAggregate, *ServerResponse, error)
+	Export(context.Context, *ServerExportParams) (io.ReadCloser, error)
+	BulkImport(context.Context, ServerBulkMode, []ServerBulkRecord) (*ServerBulkResult, *ServerResponse, error)
+	BulkRestore(context.Context, ServerBulkMode, []ServerBulkRecord) (*ServerBulkResult, *ServerResponse, error)
 	Update(context.Context, uuid.UUID, Server) (*ServerResponse, error)
 	CreateAttributes(context.Context, uuid.UUID, Attributes) (*ServerResponse, error)
 	DeleteAttributes(ctx context.Context, u uuid.UUID, ns string) (*ServerResponse, error)
@@ -40,20 +53,40 @@ type ClientInterface interface {
 	CreateVersionedAttributes(context.Context, uuid.UUID, VersionedAttributes) (*ServerResponse, error)
 	GetVersionedAttributes(context.Context, uuid.UUID, string) ([]VersionedAttributes, *ServerResponse, error)
 	ListVersionedAttributes(context.Context, uuid.UUID) ([]VersionedAttributes, *ServerResponse, error)
+	GetServerFirmwarePlan(context.Context, uuid.UUID, uuid.UUID, string, string) (*ServerFirmwarePlan, *ServerResponse, error)
 	CreateServerComponentFirmware(context.Context, ComponentFirmwareVersion) (*uuid.UUID, *ServerResponse, error)
 	DeleteServerComponentFirmware(context.Context, ComponentFirmwareVersion) (*ServerResponse, error)
+	ForceDeleteServerComponentFirmware(context.Context, ComponentFirmwareVersion) (*ServerResponse, error)
+	GetServerComponentFirmwareUsage(context.Context, uuid.UUID, string) (*ComponentFirmwareUsage, *ServerResponse, error)
 	GetServerComponentFirmware(context.Context, uuid.UUID) (*ComponentFirmwareVersion, *ServerResponse, error)
 	ListServerComponentFirmware(context.Context, *ComponentFirmwareVersionListParams) ([]ComponentFirmwareVersion, *ServerResponse, error)
 	UpdateServerComponentFirmware(context.Context, uuid.UUID, ComponentFirmwareVersion) (*ServerResponse, error)
+	SetServerComponentFirmwareStatus(context.Context, uuid.UUID, ComponentFirmwareStatus) (*ServerResponse, error)
 	CreateServerComponentFirmwareSet(context.Context, ComponentFirmwareSetRequest) (*uuid.UUID, *ServerResponse, error)
 	UpdateComponentFirmwareSetRequest(context.Context, ComponentFirmwareSetRequest) (*uuid.UUID, *ServerResponse, error)
 	GetServerComponentFirmwareSet(context.Context, uuid.UUID) (*ComponentFirmwareSet, *ServerResponse, error)
 	ListServerComponentFirmwareSet(context.Context, *ComponentFirmwareSetListParams) ([]ComponentFirmwareSet, *ServerResponse, error)
 	DeleteServerComponentFirmwareSet(context.Context, uuid.UUID) (*ServerResponse, error)
+	ExportServerComponentFirmwareSet(context.Context, uuid.UUID, ComponentFirmwareSetDocumentFormat) ([]byte, error)
+	GetServerComponentFirmwareSetCoverage(context.Context, uuid.UUID, string, []string) (*ComponentFirmwareSetCoverage, *ServerResponse, error)
+	ImportServerComponentFirmwareSet(context.Context, *ComponentFirmwareSetDocument) (*ComponentFirmwareSetImportResult, *ServerResponse, error)
+	CloneServerComponentFirmwareSet(context.Context, uuid.UUID, ComponentFirmwareSetCloneRequest) (*uuid.UUID, *ServerResponse, error)
+	ListServerComponentFirmwareSetRevisions(context.Context, uuid.UUID) ([]ComponentFirmwareSetRevision, *ServerResponse, error)
+	GetServerComponentFirmwareSetRevision(context.Context, uuid.UUID, int64) (*ComponentFirmwareSetRevision, *ServerResponse, error)
+	DiffServerComponentFirmwareSetRevisions(context.Context, uuid.UUID, int64, int64) (*ComponentFirmwareSetDiff, *ServerResponse, error)
+	ListCredentials(context.Context, uuid.UUID, *PaginationParams) ([]ServerCredentialMetadata, *ServerResponse, error)
+	ListServersMissingCredential(context.Context, string, *PaginationParams) ([]Server, *ServerResponse, error)
+	ListCredentialsRotationDue(context.Context, string, *PaginationParams) ([]ServerCredentialRotationDue, *ServerResponse, error)
 	GetCredential(context.Context, uuid.UUID, string) (*ServerCredential, *ServerResponse, error)
 	SetCredential(context.Context, uuid.UUID, string, string) (*ServerResponse, error)
+	GenerateCredential(context.Context, uuid.UUID, string, string) (*ServerCredential, *ServerResponse, error)
 	DeleteCredential(context.Context, uuid.UUID, string) (*ServerResponse, error)
+	ListCredentialVersions(context.Context, uuid.UUID, string) ([]ServerCredentialVersion, *ServerResponse, error)
+	RollbackCredential(context.Context, uuid.UUID, string, int64) (*ServerResponse, error)
 	ListServerCredentialTypes(context.Context) (*ServerResponse, error)
+	UpdateServerCredentialType(context.Context, string, *ServerCredentialType) (*ServerResponse, error)
+	DeleteServerCredentialType(context.Context, string, bool) (*ServerResponse, error)
+	ListCredentialAccessEvents(context.Context, *CredentialAccessEventListParams) ([]CredentialAccessEvent, *ServerResponse, error)
 }
 
 // Create will attempt to create a server in Hollow and return the new server's UUID
@@ -101,6 +134,97 @@ func (c *Client) List(ctx context.Context, params *ServerListParams) ([]Server,
 	return *servers, &r, nil
 }
 
+// Aggregate will return the number of servers matching the filter for each group of
+// the group by fields
+func (c *Client) Aggregate(ctx context.Context, params *ServerAggregateParams) (*ServerAggregate, *ServerResponse, error) {
+	agg := &ServerAggregate{}
+	r := ServerResponse{Record: agg}
+
+	if err := c.list(ctx, serversAggregateEndpoint, params, &r); err != nil {
+		return nil, nil, err
+	}
+
+	return agg, &r, nil
+}
+
+// Export will return a reader streaming the servers matching the filter in the export
+// format. The caller must close the reader. Reading returns ErrExportIncomplete at the
+// end of the stream when the server failed to export every server.
+func (c *Client) Export(ctx context.Context, params *ServerExportParams) (io.ReadCloser, error) {
+	request, err := newGetRequest(ctx, c.url, serversExportEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	q := request.URL.Query()
+	params.setQuery(q)
+	request.URL.RawQuery = q.Encode()
+
+	request.Header.Set("Authorization", fmt.Sprintf("bearer %s", c.authToken))
+	request.Header.Set("User-Agent", userAgentString())
+
+	resp, err := c.httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ensureValidServerResponse(resp); err != nil {
+		return nil, err
+	}
+
+	return &exportReader{
+		ReadCloser: resp.Body,
+		trailer:    func() string { return resp.Trailer.Get(ExportErrorTrailer) },
+	}, nil
+}
+
+// BulkImport will upsert the servers in the records along with their components, attributes
+// and credentials. In ServerBulkAtomic mode nothing is written when any record fails and
+// an error is returned. In ServerBulkPerRecord mode the result reports each record.
+// Records of deleted servers fail, use BulkRestore to restore them.
+func (c *Client) BulkImport(ctx context.Context, mode ServerBulkMode, records []ServerBulkRecord) (*ServerBulkResult, *ServerResponse, error) {
+	return c.bulkImport(ctx, mode, false, records)
+}
+
+// BulkRestore works like BulkImport, and also restores the servers of the records
+// that were deleted
+func (c *Client) BulkRestore(ctx context.Context, mode ServerBulkMode, records []ServerBulkRecord) (*ServerBulkResult, *ServerResponse, error) {
+	return c.bulkImport(ctx, mode, true, records)
+}
+
+func (c *Client) bulkImport(ctx context.Context, mode ServerBulkMode, restore bool, records []ServerBulkRecord) (*ServerBulkResult, *ServerResponse, error) {
+	items := make([]interface{}, 0, len(records))
+	for _, rec := range records {
+		items = append(items, rec)
+	}
+
+	request, err := newNDJSONPostRequest(ctx, c.url, serversBulkEndpoint, items...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	q := request.URL.Query()
+
+	if mode != "" {
+		q.Set("mode", string(mode))
+	}
+
+	if restore {
+		q.Set("restore", "true")
+	}
+
+	request.URL.RawQuery = q.Encode()
+
+	result := &ServerBulkResult{}
+	r := ServerResponse{Record: result}
+
+	if err := c.do(request, &r); err != nil {
+		return nil, nil, err
+	}
+
+	return result, &r, nil
+}
+
 // Update will to update a server with the new values passed in
 func (c *Client) Update(ctx context.Context, srvUUID uuid.UUID, srv Server) (*ServerResponse, error) {
 	path := fmt.Sprintf("%s/%s", serversEndpoint, srvUUID)
@@ -227,6 +351,24 @@ func (c *Client) ListVersionedAttributes(ctx context.Context, srvUUID uuid.UUID)
 	return *val, &r, nil
 }
 
+// GetServerFirmwarePlan will return the firmware to install on the components of the server
+// to bring them to the versions in the firmware set, in the order to install it. The installed
+// firmware is read from the component versioned attributes in the namespace, which defaults
+// to FirmwareInstalledNamespace when empty. The firmware is for the hardware model, which
+// defaults to the model in the server ServerVendorAttributesNamespace attributes when empty.
+func (c *Client) GetServerFirmwarePlan(ctx context.Context, srvUUID, fwSetUUID uuid.UUID, model, namespace string) (*ServerFirmwarePlan, *ServerResponse, error) {
+	path := fmt.Sprintf("%s/%s/%s", serversEndpoint, srvUUID, serverFirmwarePlanEndpoint)
+	params := &serverFirmwarePlanParams{FirmwareSet: fwSetUUID, Model: model, Namespace: namespace}
+	plan := &ServerFirmwarePlan{}
+	r := ServerResponse{Record: plan}
+
+	if err := c.list(ctx, path, params, &r); err != nil {
+		return nil, nil, err
+	}
+
+	return plan, &r, nil
+}
+
 // CreateServerComponentFirmware will attempt to create a firmware in Hollow and return the firmware UUID
 func (c *Client) CreateServerComponentFirmware(ctx context.Context, firmware ComponentFirmwareVersion) (*uuid.UUID, *ServerResponse, error) {
 	resp, err := c.post(ctx, serverComponentFirmwaresEndpoint, firmware)
@@ -247,6 +389,28 @@ func (c *Client) DeleteServerComponentFirmware(ctx context.Context, firmware Com
 	return c.delete(ctx, fmt.Sprintf("%s/%s", serverComponentFirmwaresEndpoint, firmware.UUID))
 }
 
+// ForceDeleteServerComponentFirmware will delete a firmware even if it's in use, removing it
+// from the firmware sets that include it
+func (c *Client) ForceDeleteServerComponentFirmware(ctx context.Context, firmware ComponentFirmwareVersion) (*ServerResponse, error) {
+	return c.delete(ctx, fmt.Sprintf("%s/%s?force=true", serverComponentFirmwaresEndpoint, firmware.UUID))
+}
+
+// GetServerComponentFirmwareUsage will return the firmware sets that include the firmware
+// and the server components that report it as installed. The installed firmware is read
+// from the component versioned attributes in the namespace, which defaults to
+// FirmwareInstalledNamespace when empty.
+func (c *Client) GetServerComponentFirmwareUsage(ctx context.Context, fwUUID uuid.UUID, namespace string) (*ComponentFirmwareUsage, *ServerResponse, error) {
+	path := fmt.Sprintf("%s/%s/usage", serverComponentFirmwaresEndpoint, fwUUID)
+	usage := &ComponentFirmwareUsage{}
+	r := ServerResponse{Record: usage}
+
+	if err := c.list(ctx, path, &componentFirmwareUsageParams{Namespace: namespace}, &r); err != nil {
+		return nil, nil, err
+	}
+
+	return usage, &r, nil
+}
+
 // GetServerComponentFirmware will return a firmware by its UUID
 func (c *Client) GetServerComponentFirmware(ctx context.Context, fwUUID uuid.UUID) (*ComponentFirmwareVersion, *ServerResponse, error) {
 	path := fmt.Sprintf("%s/%s", serverComponentFirmwaresEndpoint, fwUUID)
@@ -278,6 +442,13 @@ func (c *Client) UpdateServerComponentFirmware(ctx context.Context, fwUUID uuid.
 	return c.put(ctx, path, firmware)
 }
 
+// SetServerComponentFirmwareStatus will set the status of a firmware to active, deprecated
+// or recalled. A reason is required for deprecated and recalled firmware.
+func (c *Client) SetServerComponentFirmwareStatus(ctx context.Context, fwUUID uuid.UUID, status ComponentFirmwareStatus) (*ServerResponse, error) {
+	path := fmt.Sprintf("%s/%s/status", serverComponentFirmwaresEndpoint, fwUUID)
+	return c.put(ctx, path, status)
+}
+
 // CreateServerComponentFirmwareSet will attempt to create a firmware set in Hollow and return the firmware UUID
 func (c *Client) CreateServerComponentFirmwareSet(ctx context.Context, set ComponentFirmwareSetRequest) (*uuid.UUID, *ServerResponse, error) {
 	resp, err := c.post(ctx, serverComponentFirmwareSetsEndpoint, set)
@@ -335,6 +506,171 @@ func (c *Client) RemoveServerComponentFirmwareSetFirmware(ctx context.Context, f
 	return c.post(ctx, path, firmwareSet)
 }
 
+// GetServerComponentFirmwareSetCoverage will return the components of the hardware model
+// the firmware set has firmware for and those it's missing. The expected components
+// default to those with firmware for the model.
+func (c *Client) GetServerComponentFirmwareSetCoverage(ctx context.Context, fwSetUUID uuid.UUID, model string, components []string) (*ComponentFirmwareSetCoverage, *ServerResponse, error) {
+	p := fmt.Sprintf("%s/%s/coverage", serverComponentFirmwareSetsEndpoint, fwSetUUID)
+	params := &componentFirmwareSetCoverageParams{Model: model, Components: components}
+	coverage := &ComponentFirmwareSetCoverage{}
+	r := ServerResponse{Record: coverage}
+
+	if err := c.list(ctx, p, params, &r); err != nil {
+		return nil, nil, err
+	}
+
+	return coverage, &r, nil
+}
+
+// CloneServerComponentFirmwareSet will create a firmware set with the attributes and firmware
+// of the given firmware set, the firmware in the request replaces the firmware for the same
+// vendor, component and model. The UUID of the new firmware set is returned.
+func (c *Client) CloneServerComponentFirmwareSet(ctx context.Context, fwSetUUID uuid.UUID, req ComponentFirmwareSetCloneRequest) (*uuid.UUID, *ServerResponse, error) {
+	resp, err := c.post(ctx, fmt.Sprintf("%s/%s/clone", serverComponentFirmwareSetsEndpoint, fwSetUUID), req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	u, err := uuid.Parse(resp.Slug)
+	if err != nil {
+		return nil, resp, nil
+	}
+
+	return &u, resp, nil
+}
+
+// ListServerComponentFirmwareSetRevisions will return the revisions of a firmware set, latest first
+func (c *Client) ListServerComponentFirmwareSetRevisions(ctx context.Context, fwSetUUID uuid.UUID) ([]ComponentFirmwareSetRevision, *ServerResponse, error) {
+	revisions := &[]ComponentFirmwareSetRevision{}
+	r := ServerResponse{Record: revisions}
+
+	if err := c.get(ctx, fmt.Sprintf("%s/%s/revisions", serverComponentFirmwareSetsEndpoint, fwSetUUID), &r); err != nil {
+		return nil, nil, err
+	}
+
+	return *revisions, &r, nil
+}
+
+// GetServerComponentFirmwareSetRevision will return the firmware set as of the given revision
+func (c *Client) GetServerComponentFirmwareSetRevision(ctx context.Context, fwSetUUID uuid.UUID, revision int64) (*ComponentFirmwareSetRevision, *ServerResponse, error) {
+	v := &ComponentFirmwareSetRevision{}
+	r := ServerResponse{Record: v}
+
+	if err := c.get(ctx, fmt.Sprintf("%s/%s/revisions/%d", serverComponentFirmwareSetsEndpoint, fwSetUUID, revision), &r); err != nil {
+		return nil, nil, err
+	}
+
+	return v, &r, nil
+}
+
+// DiffServerComponentFirmwareSetRevisions will return the changes to a firmware set between
+// two revisions. A zero to is the latest revision, a zero from is the revision before to.
+func (c *Client) DiffServerComponentFirmwareSetRevisions(ctx context.Context, fwSetUUID uuid.UUID, from, to int64) (*ComponentFirmwareSetDiff, *ServerResponse, error) {
+	diff := &ComponentFirmwareSetDiff{}
+	r := ServerResponse{Record: diff}
+	params := &componentFirmwareSetDiffParams{From: from, To: to}
+
+	if err := c.list(ctx, fmt.Sprintf("%s/%s/diff", serverComponentFirmwareSetsEndpoint, fwSetUUID), params, &r); err != nil {
+		return nil, nil, err
+	}
+
+	return diff, &r, nil
+}
+
+// ExportServerComponentFirmwareSet will return the firmware set as a YAML or JSON document
+// that references firmware by vendor, component, version and filename
+func (c *Client) ExportServerComponentFirmwareSet(ctx context.Context, fwSetUUID uuid.UUID, format ComponentFirmwareSetDocumentFormat) ([]byte, error) {
+	request, err := newGetRequest(ctx, c.url, fmt.Sprintf("%s/%s/export", serverComponentFirmwareSetsEndpoint, fwSetUUID))
+	if err != nil {
+		return nil, err
+	}
+
+	if format != "" {
+		q := request.URL.Query()
+		q.Set("format", string(format))
+		request.URL.RawQuery = q.Encode()
+	}
+
+	request.Header.Set("Authorization", fmt.Sprintf("bearer %s", c.authToken))
+	request.Header.Set("User-Agent", userAgentString())
+
+	resp, err := c.httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ensureValidServerResponse(resp); err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	return io.ReadAll(resp.Body)
+}
+
+// ImportServerComponentFirmwareSet will create the firmware set described by the document,
+// or update the firmware set with the same name to match it
+func (c *Client) ImportServerComponentFirmwareSet(ctx context.Context, doc *ComponentFirmwareSetDocument) (*ComponentFirmwareSetImportResult, *ServerResponse, error) {
+	request, err := newPostRequest(ctx, c.url, serverComponentFirmwareSetsEndpoint+"/import", doc)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := &ComponentFirmwareSetImportResult{}
+	r := ServerResponse{Record: result}
+
+	if err := c.do(request, &r); err != nil {
+		return nil, nil, err
+	}
+
+	return result, &r, nil
+}
+
+// ListCredentials will return the credentials stored for the given server UUID. The
+// secret values are not included.
+func (c *Client) ListCredentials(ctx context.Context, srvUUID uuid.UUID, params *PaginationParams) ([]ServerCredentialMetadata, *ServerResponse, error) {
+	p := path.Join(serversEndpoint, srvUUID.String(), serverCredentialsEndpoint)
+	creds := &[]ServerCredentialMetadata{}
+	r := ServerResponse{Records: creds}
+
+	if err := c.list(ctx, p, params, &r); err != nil {
+		return nil, nil, err
+	}
+
+	return *creds, &r, nil
+}
+
+// ListServersMissingCredential will return the servers that don't have a secret of the given
+// secret type
+func (c *Client) ListServersMissingCredential(ctx context.Context, secretSlug string, params *PaginationParams) ([]Server, *ServerResponse, error) {
+	p := path.Join(serversEndpoint, serverCredentialsEndpoint, "missing")
+	srvs := &[]Server{}
+	r := ServerResponse{Records: srvs}
+
+	q := &credentialSlugParams{Slug: secretSlug, Pagination: params}
+
+	if err := c.list(ctx, p, q, &r); err != nil {
+		return nil, nil, err
+	}
+
+	return *srvs, &r, nil
+}
+
+// ListCredentialsRotationDue will return the server secrets that are older than the max age
+// of their secret type. When secretSlug is set only secrets of that type are returned.
+func (c *Client) ListCredentialsRotationDue(ctx context.Context, secretSlug string, params *PaginationParams) ([]ServerCredentialRotationDue, *ServerResponse, error) {
+	due := &[]ServerCredentialRotationDue{}
+	r := ServerResponse{Records: due}
+
+	q := &credentialSlugParams{Slug: secretSlug, Pagination: params}
+
+	if err := c.list(ctx, credentialRotationDueEndpoint, q, &r); err != nil {
+		return nil, nil, err
+	}
+
+	return *due, &r, nil
+}
+
 // GetCredential will return the secret for the secret type for the given server UUID
 func (c *Client) GetCredential(ctx context.Context, srvUUID uuid.UUID, secretSlug string) (*ServerCredential, *ServerResponse, error) {
 	p := path.Join(serversEndpoint, srvUUID.String(), serverCredentialsEndpoint, secretSlug)
@@ -359,6 +695,26 @@ func (c *Client) SetCredential(ctx context.Context, srvUUID uuid.UUID, secretSlu
 	return c.put(ctx, p, secret)
 }
 
+// GenerateCredential will generate a new password for a given server UUID and secret type
+// using the password policy of the secret type. The generated password is only returned
+// by this call. An empty username keeps the current username of the secret.
+func (c *Client) GenerateCredential(ctx context.Context, srvUUID uuid.UUID, secretSlug, username string) (*ServerCredential, *ServerResponse, error) {
+	p := path.Join(serversEndpoint, srvUUID.String(), serverCredentialsEndpoint, secretSlug, "generate")
+	secret := &ServerCredential{}
+	r := ServerResponse{Record: secret}
+
+	request, err := newPostRequest(ctx, c.url, p, &serverCredentialValues{Username: username})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := c.do(request, &r); err != nil {
+		return nil, nil, err
+	}
+
+	return secret, &r, nil
+}
+
 // DeleteCredential will remove the secret for a given server UUID and secret type.
 func (c *Client) DeleteCredential(ctx context.Context, srvUUID uuid.UUID, secretSlug string) (*ServerResponse, error) {
 	p := path.Join(serversEndpoint, srvUUID.String(), serverCredentialsEndpoint, secretSlug)
@@ -366,6 +722,28 @@ func (c *Client) DeleteCredential(ctx context.Context, srvUUID uuid.UUID, secret
 	return c.delete(ctx, p)
 }
 
+// ListCredentialVersions will return the previous versions of the secret for the
+// given server UUID and secret type. Only the metadata of each version is returned.
+func (c *Client) ListCredentialVersions(ctx context.Context, srvUUID uuid.UUID, secretSlug string) ([]ServerCredentialVersion, *ServerResponse, error) {
+	p := path.Join(serversEndpoint, srvUUID.String(), serverCredentialsEndpoint, secretSlug, serverCredentialVersionsEndpoint)
+	versions := &[]ServerCredentialVersion{}
+	r := ServerResponse{Record: versions}
+
+	if err := c.get(ctx, p, &r); err != nil {
+		return nil, nil, err
+	}
+
+	return *versions, &r, nil
+}
+
+// RollbackCredential will restore the secret for a given server UUID and secret type
+// to the value it had at the given version.
+func (c *Client) RollbackCredential(ctx context.Context, srvUUID uuid.UUID, secretSlug string, version int64) (*ServerResponse, error) {
+	p := path.Join(serversEndpoint, srvUUID.String(), serverCredentialsEndpoint, secretSlug, serverCredentialVersionsEndpoint, strconv.FormatInt(version, 10), "rollback")
+
+	return c.post(ctx, p, nil)
+}
+
 // ListServerCredentialTypes will return all server secret types
 func (c *Client) ListServerCredentialTypes(ctx context.Context, params *PaginationParams) ([]ServerCredentialType, *ServerResponse, error) {
 	types := &[]ServerCredentialType{}
@@ -382,3 +760,31 @@ func (c *Client) ListServerCredentialTypes(ctx context.Context, params *Paginati
 func (c *Client) CreateServerCredentialType(ctx context.Context, sType *ServerCredentialType) (*ServerResponse, error) {
 	return c.post(ctx, serverCredentialTypeEndpoint, sType)
 }
+
+// UpdateServerCredentialType will update the server secret type with the given slug
+func (c *Client) UpdateServerCredentialType(ctx context.Context, slug string, sType *ServerCredentialType) (*ServerResponse, error) {
+	return c.put(ctx, path.Join(serverCredentialTypeEndpoint, slug), sType)
+}
+
+// DeleteServerCredentialType will delete the server secret type with the given slug. When force
+// is true any server secrets of that type are deleted with it.
+func (c *Client) DeleteServerCredentialType(ctx context.Context, slug string, force bool) (*ServerResponse, error) {
+	p := path.Join(serverCredentialTypeEndpoint, slug)
+	if force {
+		p += "?force=true"
+	}
+
+	return c.delete(ctx, p)
+}
+
+// ListCredentialAccessEvents will return the credential access audit log entries matching the params
+func (c *Client) ListCredentialAccessEvents(ctx context.Context, params *CredentialAccessEventListParams) ([]CredentialAccessEvent, *ServerResponse, error) {
+	events := &[]CredentialAccessEvent{}
+	r := ServerResponse{Records: events}
+
+	if err := c.list(ctx, credentialAccessAuditEndpoint, params, &r); err != nil {
+		return nil, nil, err
+	}
+
+	return *events, &r, nil
+}
@@ -3,6 +3,7 @@ package serverservice_test
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"testing"
 
 	"github.com/google/uuid"
@@ -69,6 +70,122 @@ func TestServerServiceList(t *testing.T) {
 	})
 }
 
+func TestServerServiceAggregate(t *testing.T) {
+	mockClientTests(t, func(ctx context.Context, respCode int, expectError bool) error {
+		facility := "Test1"
+		agg := hollow.ServerAggregate{
+			GroupBy: []string{"facility_code"},
+			Groups:  []hollow.ServerAggregateGroup{{Values: map[string]*string{"facility_code": &facility}, Count: 2}},
+		}
+		jsonResponse, err := json.Marshal(hollow.ServerResponse{Record: agg})
+		require.Nil(t, err)
+
+		c := mockClient(string(jsonResponse), respCode)
+		res, _, err := c.Aggregate(ctx, &hollow.ServerAggregateParams{GroupBy: []string{"facility_code"}})
+		if !expectError {
+			assert.Equal(t, &agg, res)
+		}
+
+		return err
+	})
+}
+
+func TestServerServiceExport(t *testing.T) {
+	mockClientTests(t, func(ctx context.Context, respCode int, expectError bool) error {
+		body := `{"uuid":"` + uuid.NewString() + `","facility":"Test1"}` + "\n"
+
+		c := mockClient(body, respCode)
+
+		r, err := c.Export(ctx, &hollow.ServerExportParams{Format: hollow.ServerExportNDJSON})
+		if err != nil {
+			return err
+		}
+
+		defer r.Close()
+
+		data, err := io.ReadAll(r)
+		if !expectError {
+			require.NoError(t, err)
+			assert.Equal(t, body, string(data))
+		}
+
+		return err
+	})
+}
+
+func TestServerServiceBulkImport(t *testing.T) {
+	mockClientTests(t, func(ctx context.Context, respCode int, expectError bool) error {
+		id := uuid.New()
+		result := hollow.ServerBulkResult{
+			Mode:    hollow.ServerBulkPerRecord,
+			Created: 1,
+			Records: []hollow.ServerBulkRecordResult{{Line: 1, UUID: id, Status: hollow.ServerBulkCreated}},
+		}
+		jsonResponse, err := json.Marshal(hollow.ServerResponse{Record: result})
+		require.Nil(t, err)
+
+		c := mockClient(string(jsonResponse), respCode)
+		res, _, err := c.BulkImport(ctx, hollow.ServerBulkPerRecord, []hollow.ServerBulkRecord{{Server: hollow.Server{UUID: id}}})
+		if !expectError {
+			assert.Equal(t, &result, res)
+		}
+
+		return err
+	})
+}
+
+func TestServerServiceExportServerComponentFirmwareSet(t *testing.T) {
+	mockClientTests(t, func(ctx context.Context, respCode int, expectError bool) error {
+		body := "name: r640\nfirmware: []\n"
+
+		c := mockClient(body, respCode)
+
+		data, err := c.ExportServerComponentFirmwareSet(ctx, uuid.New(), hollow.ComponentFirmwareSetDocumentYAML)
+		if !expectError {
+			assert.Equal(t, body, string(data))
+		}
+
+		return err
+	})
+}
+
+func TestServerServiceGetServerComponentFirmwareSetCoverage(t *testing.T) {
+	mockClientTests(t, func(ctx context.Context, respCode int, expectError bool) error {
+		coverage := hollow.ComponentFirmwareSetCoverage{
+			Model:    "R640",
+			Expected: []string{"bios", "bmc"},
+			Covered:  []string{"bios"},
+			Missing:  []string{"bmc"},
+		}
+		jsonResponse, err := json.Marshal(hollow.ServerResponse{Record: coverage})
+		require.Nil(t, err)
+
+		c := mockClient(string(jsonResponse), respCode)
+		res, _, err := c.GetServerComponentFirmwareSetCoverage(ctx, uuid.New(), "R640", nil)
+		if !expectError {
+			assert.Equal(t, &coverage, res)
+		}
+
+		return err
+	})
+}
+
+func TestServerServiceImportServerComponentFirmwareSet(t *testing.T) {
+	mockClientTests(t, func(ctx context.Context, respCode int, expectError bool) error {
+		result := hollow.ComponentFirmwareSetImportResult{UUID: uuid.NewString(), Name: "r640", Status: hollow.ComponentFirmwareSetCreated}
+		jsonResponse, err := json.Marshal(hollow.ServerResponse{Record: result})
+		require.Nil(t, err)
+
+		c := mockClient(string(jsonResponse), respCode)
+		res, _, err := c.ImportServerComponentFirmwareSet(ctx, &hollow.ComponentFirmwareSetDocument{Name: "r640"})
+		if !expectError {
+			assert.Equal(t, &result, res)
+		}
+
+		return err
+	})
+}
+
 func TestServerServiceUpdate(t *testing.T) {
 	mockClientTests(t, func(ctx context.Context, respCode int, expectError bool) error {
 		jsonResponse, err := json.Marshal(hollow.ServerResponse{Message: "resource updated"})
@@ -286,6 +403,36 @@ func TestServerServiceServerComponentFirmwareDelete(t *testing.T) {
 		return err
 	})
 }
+
+func TestServerServiceServerComponentFirmwareForceDelete(t *testing.T) {
+	mockClientTests(t, func(ctx context.Context, respCode int, expectError bool) error {
+		jsonResponse := json.RawMessage([]byte(`{"message": "resource deleted"}`))
+		c := mockClient(string(jsonResponse), respCode)
+		_, err := c.ForceDeleteServerComponentFirmware(ctx, hollow.ComponentFirmwareVersion{UUID: uuid.New()})
+
+		return err
+	})
+}
+
+func TestServerServiceServerComponentFirmwareUsage(t *testing.T) {
+	mockClientTests(t, func(ctx context.Context, respCode int, expectError bool) error {
+		usage := hollow.ComponentFirmwareUsage{
+			FirmwareUUID: uuid.New(),
+			FirmwareSets: []hollow.ComponentFirmwareUsageSet{{UUID: uuid.New(), Name: "r640"}},
+			Servers:      []hollow.ComponentFirmwareUsageServer{{ServerUUID: uuid.New(), ServerName: "Nemo", ComponentUUID: uuid.New(), ComponentSerial: "bmc"}},
+		}
+		jsonResponse, err := json.Marshal(hollow.ServerResponse{Record: usage})
+		require.Nil(t, err)
+
+		c := mockClient(string(jsonResponse), respCode)
+		res, _, err := c.GetServerComponentFirmwareUsage(ctx, usage.FirmwareUUID, "")
+		if !expectError {
+			assert.Equal(t, &usage, res)
+		}
+
+		return err
+	})
+}
 func TestServerServiceServerComponentFirmwareGet(t *testing.T) {
 	mockClientTests(t, func(ctx context.Context, respCode int, expectError bool) error {
 		firmware := hollow.ComponentFirmwareVersion{
@@ -342,3 +489,112 @@ func TestServerServiceServerComponentFirmwareUpdate(t *testing.T) {
 		return err
 	})
 }
+
+func TestServerServiceSetServerComponentFirmwareStatus(t *testing.T) {
+	mockClientTests(t, func(ctx context.Context, respCode int, expectError bool) error {
+		jsonResponse, err := json.Marshal(hollow.ServerResponse{Message: "resource updated"})
+		require.Nil(t, err)
+
+		c := mockClient(string(jsonResponse), respCode)
+		_, err = c.SetServerComponentFirmwareStatus(ctx, uuid.New(), hollow.ComponentFirmwareStatus{
+			Status: hollow.FirmwareStatusRecalled,
+			Reason: "bricks the BMC",
+		})
+
+		return err
+	})
+}
+
+func TestServerServiceCloneServerComponentFirmwareSet(t *testing.T) {
+	mockClientTests(t, func(ctx context.Context, respCode int, expectError bool) error {
+		jsonResponse := json.RawMessage([]byte(`{"message": "resource created", "slug":"00000000-0000-0000-0000-000000001234"}`))
+
+		c := mockClient(string(jsonResponse), respCode)
+		res, _, err := c.CloneServerComponentFirmwareSet(ctx, uuid.New(), hollow.ComponentFirmwareSetCloneRequest{Name: "r640-clone"})
+		if !expectError {
+			assert.Equal(t, "00000000-0000-0000-0000-000000001234", res.String())
+		}
+
+		return err
+	})
+}
+
+func TestServerServiceListServerComponentFirmwareSetRevisions(t *testing.T) {
+	mockClientTests(t, func(ctx context.Context, respCode int, expectError bool) error {
+		revisions := []hollow.ComponentFirmwareSetRevision{
+			{Revision: 2, Document: hollow.ComponentFirmwareSetDocument{Name: "r640"}},
+			{Revision: 1, Document: hollow.ComponentFirmwareSetDocument{Name: "r640"}},
+		}
+		jsonResponse, err := json.Marshal(hollow.ServerResponse{Record: revisions})
+		require.Nil(t, err)
+
+		c := mockClient(string(jsonResponse), respCode)
+		res, _, err := c.ListServerComponentFirmwareSetRevisions(ctx, uuid.New())
+		if !expectError {
+			assert.Equal(t, revisions, res)
+		}
+
+		return err
+	})
+}
+
+func TestServerServiceGetServerComponentFirmwareSetRevision(t *testing.T) {
+	mockClientTests(t, func(ctx context.Context, respCode int, expectError bool) error {
+		revision := hollow.ComponentFirmwareSetRevision{Revision: 1, Document: hollow.ComponentFirmwareSetDocument{Name: "r640"}}
+		jsonResponse, err := json.Marshal(hollow.ServerResponse{Record: revision})
+		require.Nil(t, err)
+
+		c := mockClient(string(jsonResponse), respCode)
+		res, _, err := c.GetServerComponentFirmwareSetRevision(ctx, uuid.New(), 1)
+		if !expectError {
+			assert.Equal(t, &revision, res)
+		}
+
+		return err
+	})
+}
+
+func TestServerServiceDiffServerComponentFirmwareSetRevisions(t *testing.T) {
+	mockClientTests(t, func(ctx context.Context, respCode int, expectError bool) error {
+		diff := hollow.ComponentFirmwareSetDiff{
+			From:     1,
+			To:       2,
+			FromName: "r640",
+			ToName:   "r640",
+			Added:    []hollow.ComponentFirmwareReference{{Vendor: "Dell", Component: "cpld", Version: "1.0.1", Filename: "CPLD_1.0.1.EXE"}},
+		}
+		jsonResponse, err := json.Marshal(hollow.ServerResponse{Record: diff})
+		require.Nil(t, err)
+
+		c := mockClient(string(jsonResponse), respCode)
+		res, _, err := c.DiffServerComponentFirmwareSetRevisions(ctx, uuid.New(), 1, 2)
+		if !expectError {
+			assert.Equal(t, &diff, res)
+		}
+
+		return err
+	})
+}
+
+func TestServerServiceGetServerFirmwarePlan(t *testing.T) {
+	mockClientTests(t, func(ctx context.Context, respCode int, expectError bool) error {
+		plan := hollow.ServerFirmwarePlan{
+			ServerUUID:      uuid.New(),
+			FirmwareSetUUID: uuid.New(),
+			FirmwareSetName: "r640",
+			Steps: []hollow.ServerFirmwarePlanStep{
+				{ComponentSlug: "bmc", Installed: "4.40.00.00", Version: "5.10.00.00", Checksum: "98db2fe5"},
+			},
+		}
+		jsonResponse, err := json.Marshal(hollow.ServerResponse{Record: plan})
+		require.Nil(t, err)
+
+		c := mockClient(string(jsonResponse), respCode)
+		res, _, err := c.GetServerFirmwarePlan(ctx, plan.ServerUUID, plan.FirmwareSetUUID, "R640", "")
+		if !expectError {
+			assert.Equal(t, &plan, res)
+		}
+
+		return err
+	})
+}